@@ -2,25 +2,106 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/keagan/slopcannon/internal/config"
+	"github.com/keagan/slopcannon/internal/ffmpeg"
 	"github.com/keagan/slopcannon/internal/logging"
+	"github.com/keagan/slopcannon/internal/overlays"
 	"github.com/keagan/slopcannon/internal/pipeline"
+	"github.com/keagan/slopcannon/internal/subtitles"
+	"github.com/keagan/slopcannon/internal/ui"
+	"github.com/keagan/slopcannon/pkg/util"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
 var (
 	cfgFile string
 	verbose bool
+	noCache bool
+
+	concurrency   int
+	ffmpegThreads int
+	logFormat     string
+	logFile       string
+	logMaxSize    int
+	logMaxBackups int
+
+	transcriptPath string
+	analyzeJSON    bool
+	maxClipLen     time.Duration
+	dumpFeatures   string
+	scoreTimeout   time.Duration
+
+	batchPattern        string
+	batchMaxClips       int
+	batchDedupe         bool
+	batchDedupeDistance int
+
+	renderOutput          string
+	renderClipsDir        string
+	renderReframe         string
+	renderOverlay         string
+	renderBlurSigma       float64
+	renderOverlayStrategy string
+	renderBitrate         string
+	renderTwoPass         bool
+	renderPreview         bool
+	renderFormat          string
+	renderVideoCodec      string
+	renderAudioCodec      string
+	renderSocialPreset    string
+	renderEnforce         bool
+	renderTransition      string
+	renderNameTemplate    string
+
+	gifStart     time.Duration
+	gifDuration  time.Duration
+	gifFPS       int
+	gifWidth     int
+	gifNoPalette bool
+
+	thumbAt     string
+	thumbOutput string
+	thumbWidth  int
+
+	splitDuration  time.Duration
+	splitParts     int
+	splitOutputDir string
+	splitReencode  bool
+
+	configForce bool
+	configJSON  bool
 )
 
 func main() {
-	ctx := context.Background()
+	// The first Ctrl-C cancels the context so deferred temp file cleanup
+	// still runs; a second one falls back to the default (immediate exit)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		signal.Stop(signals)
+		log.Warn().Msg("interrupted; cleaning up (press Ctrl-C again to force quit)")
+		cancel()
+	}()
 
-	if err := rootCmd.ExecuteContext(ctx); err != nil {
+	err := rootCmd.ExecuteContext(ctx)
+	logging.Close()
+	if err != nil {
+		cancel()
 		os.Exit(1)
 	}
 }
@@ -31,10 +112,18 @@ var rootCmd = &cobra.Command{
 	Long:  "A modular Go-powered viral-clip generation toolkit that slices, scores, edits, and exports.",
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		// Initialize logging
-		logging.Init(verbose)
+		if err := logging.InitWith(logging.Options{
+			Verbose:    verbose,
+			Format:     logFormat,
+			File:       logFile,
+			MaxSizeMB:  logMaxSize,
+			MaxBackups: logMaxBackups,
+		}); err != nil {
+			return err
+		}
 
 		// Load config
-		cfg, err := config.Load(cfgFile)
+		cfg, err := loadConfig(cmd, cfgFile)
 		if err != nil {
 			return err
 		}
@@ -50,12 +139,46 @@ var rootCmd = &cobra.Command{
 func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default: ./config.yaml)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", logging.FormatConsole, "log format: console|json")
+	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "also append logs to this file")
+	rootCmd.PersistentFlags().IntVar(&logMaxSize, "log-max-size", 0, "rotate --log-file at this many MB (0 = never)")
+	rootCmd.PersistentFlags().IntVar(&logMaxBackups, "log-max-backups", logging.DefaultMaxBackups, "rotated log files to keep")
+	rootCmd.PersistentFlags().IntVar(&concurrency, "concurrency", 0, "concurrent workers (overrides config; at least 1)")
+	rootCmd.PersistentFlags().IntVar(&ffmpegThreads, "ffmpeg-threads", 0, "ffmpeg threads (overrides config; 0 = ffmpeg decides)")
 
 	rootCmd.AddCommand(analyzeCmd)
+	rootCmd.AddCommand(batchCmd)
 	rootCmd.AddCommand(renderCmd)
 	rootCmd.AddCommand(clipCmd)
 	rootCmd.AddCommand(configCmd)
 	rootCmd.AddCommand(listCmd)
+	rootCmd.AddCommand(modelsCmd)
+}
+
+// loadConfig reads the config file, applies flag overrides and validates
+// the result once, so flags can fix bad file values and bad flags are
+// reported like bad file values
+func loadConfig(cmd *cobra.Command, path string) (*config.Config, error) {
+	cfg, err := config.Read(path)
+	if err != nil {
+		return nil, err
+	}
+	applyFlagOverrides(cmd, cfg)
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	return cfg, nil
+}
+
+// applyFlagOverrides replaces config values with the flags the user set
+func applyFlagOverrides(cmd *cobra.Command, cfg *config.Config) {
+	if cmd.Flags().Changed("concurrency") {
+		cfg.Concurrency = concurrency
+	}
+	if cmd.Flags().Changed("ffmpeg-threads") {
+		cfg.FFmpeg.Threads = ffmpegThreads
+	}
 }
 
 var analyzeCmd = &cobra.Command{
@@ -65,21 +188,42 @@ var analyzeCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg := config.FromContext(cmd.Context())
 
+		// Keep stdout machine-parseable
+		if analyzeJSON && !verbose {
+			logging.Quiet()
+		}
+
 		// Create pipeline
 		pipeCfg := &pipeline.Config{
 			Workers:     cfg.Concurrency,
-			EnableCache: true,
+			EnableCache: !noCache,
+		}
+		if bar := newProgressBar("analyzing", !analyzeJSON); bar != nil {
+			defer bar.Finish()
+			pipeCfg.Progress = bar.Update
 		}
 		pipe, err := pipeline.New(log.Logger, pipeCfg, cfg)
 		if err != nil {
 			return err
 		}
+		defer pipe.Close()
 
 		// Run analysis
 		opts := pipeline.AnalyzeOptions{
-			MinClipLen: 5 * time.Second,
-			MaxClips:   10,
-			Model:      cfg.AI.ModelPath,
+			MinClipLen:   5 * time.Second,
+			MaxClipLen:   maxClipLen,
+			MaxClips:     10,
+			Model:        cfg.AI.ModelPath,
+			DumpFeatures: dumpFeatures,
+			ScoreTimeout: scoreTimeout,
+		}
+
+		if transcriptPath != "" {
+			transcript, err := subtitles.LoadTranscript(transcriptPath)
+			if err != nil {
+				return err
+			}
+			opts.Transcript = transcript
 		}
 
 		project, err := pipe.Analyze(cmd.Context(), args[0], opts)
@@ -87,11 +231,92 @@ var analyzeCmd = &cobra.Command{
 			return err
 		}
 
+		projectPath := filepath.Join(cfg.WorkDir, "project.json")
+		if err := project.Save(projectPath); err != nil {
+			return fmt.Errorf("failed to save project: %w", err)
+		}
+
 		log.Info().
 			Str("project", project.Name).
+			Str("path", projectPath).
 			Int("clips", len(project.Clips)).
 			Msg("analysis complete")
 
+		if analyzeJSON {
+			data, err := project.MarshalIndent()
+			if err != nil {
+				return fmt.Errorf("failed to encode project: %w", err)
+			}
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), string(data))
+			return err
+		}
+
+		return nil
+	},
+}
+
+var batchCmd = &cobra.Command{
+	Use:   "batch [directory]",
+	Short: "Analyze every matching video in a directory",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := config.FromContext(cmd.Context())
+
+		inputs, err := filepath.Glob(filepath.Join(args[0], batchPattern))
+		if err != nil {
+			return fmt.Errorf("invalid pattern: %w", err)
+		}
+		if len(inputs) == 0 {
+			return fmt.Errorf("no files matching %q in %s", batchPattern, args[0])
+		}
+
+		pipe, err := pipeline.New(log.Logger, &pipeline.Config{
+			Workers:     cfg.Concurrency,
+			EnableCache: !noCache,
+		}, cfg)
+		if err != nil {
+			return err
+		}
+		defer pipe.Close()
+
+		opts := pipeline.AnalyzeOptions{
+			MinClipLen:     5 * time.Second,
+			MaxClipLen:     maxClipLen,
+			MaxClips:       batchMaxClips,
+			Model:          cfg.AI.ModelPath,
+			Dedupe:         batchDedupe,
+			DedupeDistance: batchDedupeDistance,
+			ScoreTimeout:   scoreTimeout,
+		}
+
+		results, err := pipe.AnalyzeBatch(cmd.Context(), inputs, cfg.WorkDir, cfg.Concurrency, opts)
+		if err != nil {
+			return err
+		}
+
+		totalClips, duplicates, failures := 0, 0, 0
+		for _, r := range results {
+			if r.Err != nil {
+				failures++
+				log.Error().Err(r.Err).Str("input", r.Input).Msg("analysis failed")
+				continue
+			}
+			totalClips += r.Clips
+			duplicates += r.Duplicates
+		}
+
+		log.Info().
+			Int("videos", len(results)).
+			Int("succeeded", len(results)-failures).
+			Int("failed", failures).
+			Int("clips", totalClips).
+			Int("duplicates_skipped", duplicates).
+			Str("work_dir", cfg.WorkDir).
+			Msg("batch complete")
+
+		if failures > 0 {
+			return fmt.Errorf("%d of %d videos failed", failures, len(results))
+		}
 		return nil
 	},
 }
@@ -101,12 +326,88 @@ var renderCmd = &cobra.Command{
 	Short: "Render final video from project",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		log.Info().Str("project", args[0]).Msg("rendering project")
-		// TODO: wire up pipeline.Render()
-		return nil
+		cfg := config.FromContext(cmd.Context())
+
+		project, err := pipeline.LoadProject(args[0])
+		if err != nil {
+			return err
+		}
+
+		// Reject bad flag combinations before any work starts
+		opts, err := renderOptions(cfg, project.Name)
+		if err != nil {
+			return err
+		}
+		if err := pipeline.CheckRenderOptions(opts); err != nil {
+			return err
+		}
+
+		log.Info().Str("project", project.Name).Msg("rendering project")
+
+		pipeCfg := &pipeline.Config{Workers: cfg.Concurrency}
+		if bar := newProgressBar("rendering", true); bar != nil {
+			defer bar.Finish()
+			pipeCfg.Progress = bar.Update
+		}
+
+		pipe, err := pipeline.New(log.Logger, pipeCfg, cfg)
+		if err != nil {
+			return err
+		}
+		defer pipe.Close()
+
+		if opts.OutputDir != "" {
+			_, err = pipe.RenderClips(cmd.Context(), project, opts)
+			return err
+		}
+		_, err = pipe.Render(cmd.Context(), project, opts)
+		return err
 	},
 }
 
+// renderOptions builds the render command's options from its flags. With
+// --clips-dir every clip gets its own file; otherwise the joined output
+// defaults to <work_dir>/<project><format extension>.
+func renderOptions(cfg *config.Config, projectName string) (pipeline.RenderOptions, error) {
+	opts := pipeline.RenderOptions{
+		Format:          renderFormat,
+		VideoCodec:      renderVideoCodec,
+		AudioCodec:      renderAudioCodec,
+		Preset:          cfg.FFmpeg.Preset,
+		Reframe:         ffmpeg.ReframeMode(renderReframe),
+		OverlayPath:     renderOverlay,
+		BlurSigma:       renderBlurSigma,
+		OverlayStrategy: overlays.Strategy(renderOverlayStrategy),
+		TargetBitrate:   renderBitrate,
+		TwoPass:         renderTwoPass,
+		Transition:      ffmpeg.Transition(renderTransition),
+		Preview:         renderPreview,
+		SocialPreset:    renderSocialPreset,
+		EnforceDuration: renderEnforce,
+	}
+
+	// Render each clip separately
+	if renderClipsDir != "" {
+		opts.OutputDir = renderClipsDir
+		opts.NameTemplate = renderNameTemplate
+		return opts, nil
+	}
+
+	opts.OutputPath = renderOutput
+	if opts.OutputPath == "" {
+		ext := ".mp4"
+		if renderFormat != "" {
+			format, err := ffmpeg.LookupFormat(renderFormat)
+			if err != nil {
+				return opts, err
+			}
+			ext = format.Ext()
+		}
+		opts.OutputPath = filepath.Join(cfg.WorkDir, projectName+ext)
+	}
+	return opts, nil
+}
+
 var clipCmd = &cobra.Command{
 	Use:   "clip",
 	Short: "Clip editing commands",
@@ -122,6 +423,99 @@ var clipTrimCmd = &cobra.Command{
 	},
 }
 
+var clipGIFCmd = &cobra.Command{
+	Use:   "gif [input video] [output.gif|output.webp]",
+	Short: "Export a looping GIF or animated WebP",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := config.FromContext(cmd.Context())
+
+		exec, err := ffmpeg.NewWithConfig(log.Logger, cfg.FFmpeg)
+		if err != nil {
+			return err
+		}
+
+		return exec.ExportGIF(cmd.Context(), args[0], args[1], ffmpeg.GIFOptions{
+			Start:    gifStart,
+			Duration: gifDuration,
+			FPS:      gifFPS,
+			Width:    gifWidth,
+			Palette:  !gifNoPalette,
+		})
+	},
+}
+
+var clipThumbnailCmd = &cobra.Command{
+	Use:   "thumbnail [input video]",
+	Short: "Grab a single frame as an image",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := config.FromContext(cmd.Context())
+
+		at, err := util.ParseTimestamp(thumbAt)
+		if err != nil {
+			return fmt.Errorf("invalid --at: %w", err)
+		}
+
+		exec, err := ffmpeg.NewWithConfig(log.Logger, cfg.FFmpeg)
+		if err != nil {
+			return err
+		}
+
+		info, err := exec.ProbeVideoCached(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+		if err := ffmpeg.CheckTimestamp(at, info.Duration); err != nil {
+			return err
+		}
+
+		filter := ffmpeg.NewFilterBuilder().ScaleWidth(thumbWidth).Build()
+		return exec.GenerateThumbnailWithFilter(cmd.Context(), args[0], thumbOutput, at, filter, nil)
+	},
+}
+
+var clipSplitCmd = &cobra.Command{
+	Use:   "split [input video]",
+	Short: "Cut a video into equal-length parts without analysis",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := config.FromContext(cmd.Context())
+
+		if (splitDuration > 0) == (splitParts > 0) {
+			return fmt.Errorf("set exactly one of --duration or --parts")
+		}
+
+		exec, err := ffmpeg.NewWithConfig(log.Logger, cfg.FFmpeg)
+		if err != nil {
+			return err
+		}
+
+		chunk := splitDuration
+		if splitParts > 0 {
+			info, err := exec.ProbeVideoCached(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			chunk = info.Duration / time.Duration(splitParts)
+		}
+
+		outputs, err := exec.Split(cmd.Context(), args[0], ffmpeg.SplitOptions{
+			Chunk:     chunk,
+			OutputDir: splitOutputDir,
+			Reencode:  splitReencode,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, output := range outputs {
+			fmt.Fprintln(cmd.OutOrStdout(), output)
+		}
+		return nil
+	},
+}
+
 var configCmd = &cobra.Command{
 	Use:   "config",
 	Short: "Config management commands",
@@ -137,18 +531,166 @@ var configEditCmd = &cobra.Command{
 	},
 }
 
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the effective config and the file it came from",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := config.FromContext(cmd.Context())
+
+		source := config.ResolvePath(cfgFile)
+		if source == "" {
+			source = "(none, using defaults)"
+		} else if _, err := os.Stat(source); err != nil {
+			source += " (not found, using defaults)"
+		}
+
+		out := cmd.OutOrStdout()
+		if configJSON {
+			// Go through the YAML form so keys match the config file
+			fields, err := cfg.Map()
+			if err != nil {
+				return fmt.Errorf("failed to encode config: %w", err)
+			}
+			data, err := json.MarshalIndent(struct {
+				Source string                 `json:"source"`
+				Config map[string]interface{} `json:"config"`
+			}{source, fields}, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to encode config: %w", err)
+			}
+			_, err = fmt.Fprintln(out, string(data))
+			return err
+		}
+
+		data, err := yaml.Marshal(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to encode config: %w", err)
+		}
+		_, err = fmt.Fprintf(out, "# source: %s\n%s", source, data)
+		return err
+	},
+}
+
+var configInitCmd = &cobra.Command{
+	Use:   "init [path]",
+	Short: "Write a commented default config file",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := "./config.yaml"
+		if len(args) > 0 {
+			path = args[0]
+		}
+
+		if err := config.WriteDefault(path, configForce); err != nil {
+			return err
+		}
+
+		log.Info().Str("path", path).Msg("config written")
+		return nil
+	},
+}
+
 var listCmd = &cobra.Command{
-	Use:   "list [plugins|overlays|models]",
+	Use:   "list [plugins|overlays|models|presets]",
 	Short: "List available resources",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := config.FromContext(cmd.Context())
+
+		switch args[0] {
+		case "overlays":
+			registry := overlays.NewRegistry()
+			registry.SetLogger(log.Logger)
+			if err := registry.LoadAll(cfg.Overlays); err != nil {
+				return err
+			}
+			for _, name := range registry.List() {
+				path, _ := registry.Get(name)
+				fmt.Printf("%-24s %s\n", name, path)
+			}
+			return nil
+		case "presets":
+			for _, name := range pipeline.SocialPresetNames() {
+				p, _ := pipeline.LookupSocialPreset(name)
+				fmt.Printf("%-10s %dx%d  <=%gfps  <=%s  %s  %g LUFS\n",
+					p.Name, p.Width, p.Height, p.MaxFPS, p.MaxBitrate, p.MaxDuration, p.Loudness)
+			}
+			return nil
+		}
+
 		log.Info().Str("resource", args[0]).Msg("listing resources")
-		// TODO: wire up registry
+		// TODO: wire up plugins and models
 		return nil
 	},
 }
 
 func init() {
+	renderCmd.Flags().StringVarP(&renderOutput, "output", "o", "", "output video path (default: <work_dir>/<project>.<format>)")
+	renderCmd.Flags().StringVar(&renderClipsDir, "clips-dir", "", "render each clip to its own file in this directory")
+	renderCmd.Flags().StringVar(&renderReframe, "reframe", "", "vertical reframing: center-crop|blur-pad|split-screen")
+	renderCmd.Flags().Float64Var(&renderBlurSigma, "blur-sigma", ffmpeg.DefaultBlurSigma, "background blur strength for --reframe blur-pad")
+	renderCmd.Flags().StringVar(&renderOverlay, "overlay", "", "split-screen gameplay overlay (registered name or file)")
+	renderCmd.Flags().StringVar(&renderBitrate, "bitrate", "", "target video bitrate (e.g. 4M) instead of CRF quality")
+	renderCmd.Flags().BoolVar(&renderTwoPass, "two-pass", false, "two-pass encode for accurate --bitrate")
+	renderCmd.Flags().BoolVar(&renderPreview, "preview", false, "fast 480p proxies in <work_dir>/preview for checking clips before a full render")
+	renderCmd.Flags().StringVar(&renderFormat, "format", "", "output container with default codecs: "+strings.Join(ffmpeg.FormatNames(), "|"))
+	renderCmd.Flags().StringVar(&renderVideoCodec, "video-codec", "", "video encoder, overriding --format's (e.g. libx265)")
+	renderCmd.Flags().StringVar(&renderAudioCodec, "audio-codec", "", "audio encoder, overriding --format's (e.g. libmp3lame)")
+	renderCmd.Flags().StringVar(&renderSocialPreset, "preset-social", "", "upload target setting size, fps cap, bitrate ceiling, loudness and max duration: "+strings.Join(pipeline.SocialPresetNames(), "|"))
+	renderCmd.Flags().BoolVar(&renderEnforce, "enforce", false, "trim output past the --preset-social max duration instead of only warning")
+	renderCmd.Flags().StringVar(&renderTransition, "transition", "none", "effect between joined clips: none|fade|crossfade")
+	renderCmd.Flags().StringVar(&renderNameTemplate, "name-template", "", "file names with --clips-dir (default clip_{index} plus the --format extension); tokens: {index} {score} {start} {source}")
+	renderCmd.Flags().StringVar(&renderOverlayStrategy, "overlay-strategy", "fixed", "per-clip overlay choice with --clips-dir: fixed|random|roundrobin")
+	analyzeCmd.Flags().StringVar(&transcriptPath, "transcript", "", "Whisper JSON transcript; enables keyword scoring")
+	analyzeCmd.Flags().BoolVar(&analyzeJSON, "json", false, "print the project as JSON to stdout (logs stay on stderr)")
+	analyzeCmd.Flags().BoolVar(&noCache, "no-cache", false, "ignore and don't write the analysis cache")
+	analyzeCmd.Flags().DurationVar(&maxClipLen, "max-clip-len", 0, "split candidates longer than this (default 90s)")
+	analyzeCmd.Flags().DurationVar(&scoreTimeout, "score-timeout", 0, "give up scoring a clip after this long (0 = no limit)")
+	analyzeCmd.Flags().StringVar(&dumpFeatures, "dump-features", "", "write every candidate's features and score to a .csv or .jsonl file")
+
+	batchCmd.Flags().StringVar(&batchPattern, "pattern", "*.mp4", "glob for input files inside the directory")
+	batchCmd.Flags().DurationVar(&scoreTimeout, "score-timeout", 0, "give up scoring a clip after this long (0 = no limit)")
+	batchCmd.Flags().IntVar(&batchMaxClips, "max-clips", 10, "maximum clips per video")
+	batchCmd.Flags().BoolVar(&batchDedupe, "dedupe", false, "drop clips that look like one already kept (perceptual hash)")
+	batchCmd.Flags().IntVar(&batchDedupeDistance, "dedupe-distance", pipeline.DefaultDedupeDistance, "max differing hash bits (of 64) for --dedupe to count a duplicate")
+	batchCmd.Flags().BoolVar(&noCache, "no-cache", false, "ignore and don't write the analysis cache")
+	batchCmd.Flags().DurationVar(&maxClipLen, "max-clip-len", 0, "split candidates longer than this (default 90s)")
+
+	clipGIFCmd.Flags().DurationVar(&gifStart, "start", 0, "start offset in the input")
+	clipGIFCmd.Flags().DurationVar(&gifDuration, "duration", 0, "length to export (default: to end)")
+	clipGIFCmd.Flags().IntVar(&gifFPS, "fps", ffmpeg.DefaultGIFFPS, "frames per second")
+	clipGIFCmd.Flags().IntVar(&gifWidth, "width", ffmpeg.DefaultGIFWidth, fmt.Sprintf("output width (max %d)", ffmpeg.MaxGIFWidth))
+	clipGIFCmd.Flags().BoolVar(&gifNoPalette, "no-palette", false, "skip palette generation (faster, lower quality)")
+
+	clipThumbnailCmd.Flags().StringVar(&thumbAt, "at", "", "timestamp to grab (HH:MM:SS.mmm, MM:SS or seconds)")
+	clipThumbnailCmd.Flags().StringVarP(&thumbOutput, "output", "o", "", "output image path (e.g. thumb.jpg)")
+	clipThumbnailCmd.Flags().IntVar(&thumbWidth, "width", 0, "output width, keeping aspect ratio (0 = source size)")
+	_ = clipThumbnailCmd.MarkFlagRequired("at")
+	_ = clipThumbnailCmd.MarkFlagRequired("output")
+
+	clipSplitCmd.Flags().DurationVar(&splitDuration, "duration", 0, "length of each part, e.g. 60s (the last part may be shorter)")
+	clipSplitCmd.Flags().IntVar(&splitParts, "parts", 0, "number of equal parts, instead of --duration")
+	clipSplitCmd.Flags().StringVar(&splitOutputDir, "output-dir", "", "directory for the parts (default: next to the input)")
+	clipSplitCmd.Flags().BoolVar(&splitReencode, "reencode", false, "re-encode for frame-accurate cuts instead of stream copying")
+
 	clipCmd.AddCommand(clipTrimCmd)
+	clipCmd.AddCommand(clipGIFCmd)
+	clipCmd.AddCommand(clipThumbnailCmd)
+	clipCmd.AddCommand(clipSplitCmd)
+	configInitCmd.Flags().BoolVar(&configForce, "force", false, "overwrite an existing file")
+
+	configShowCmd.Flags().BoolVar(&configJSON, "json", false, "print as JSON instead of YAML")
+
+	configCmd.AddCommand(configInitCmd)
+	configCmd.AddCommand(configShowCmd)
 	configCmd.AddCommand(configEditCmd)
 }
+
+// newProgressBar returns a stderr progress bar, or nil when disabled or
+// when output isn't going to a terminal
+func newProgressBar(label string, enabled bool) *ui.ProgressBar {
+	if !enabled || !ui.IsTerminal(os.Stdout) || !ui.IsTerminal(os.Stderr) {
+		return nil
+	}
+	return ui.NewProgressBar(os.Stderr, label)
+}
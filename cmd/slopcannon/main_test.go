@@ -0,0 +1,78 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/keagan/slopcannon/internal/config"
+	"github.com/keagan/slopcannon/internal/ffmpeg"
+	"github.com/keagan/slopcannon/internal/pipeline"
+	"github.com/spf13/cobra"
+)
+
+func TestRenderOptionsDefaultsMatchFormat(t *testing.T) {
+	defer func(dir, format string) {
+		renderClipsDir, renderFormat = dir, format
+	}(renderClipsDir, renderFormat)
+
+	cfg := &config.Config{WorkDir: t.TempDir()}
+	for _, format := range ffmpeg.FormatNames() {
+		renderFormat = format
+		for _, clipsDir := range []string{"", t.TempDir()} {
+			renderClipsDir = clipsDir
+
+			opts, err := renderOptions(cfg, "project")
+			if err != nil {
+				t.Fatalf("%s: %v", format, err)
+			}
+			if err := pipeline.CheckRenderOptions(opts); err != nil {
+				t.Errorf("--format %s (clips dir %q) with default flags: %v", format, clipsDir, err)
+			}
+		}
+	}
+}
+
+func TestRenderOptionsRejectUnknownTransition(t *testing.T) {
+	defer func(transition string) { renderTransition = transition }(renderTransition)
+
+	renderTransition = "wipe"
+	opts, err := renderOptions(&config.Config{WorkDir: t.TempDir()}, "project")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pipeline.CheckRenderOptions(opts); err == nil {
+		t.Error("expected an unknown --transition to be rejected before rendering")
+	}
+}
+
+func TestLoadConfigValidatesAfterFlags(t *testing.T) {
+	defer func(n int) { concurrency = n }(concurrency)
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("concurrency: 0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	withFlag := func(value string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().IntVar(&concurrency, "concurrency", 0, "")
+		if err := cmd.Flags().Set("concurrency", value); err != nil {
+			t.Fatal(err)
+		}
+		return cmd
+	}
+
+	cfg, err := loadConfig(withFlag("4"), path)
+	if err != nil {
+		t.Fatalf("--concurrency 4 should override the file's 0: %v", err)
+	}
+	if cfg.Concurrency != 4 {
+		t.Errorf("concurrency = %d, want 4", cfg.Concurrency)
+	}
+
+	for _, bad := range []string{"0", "-3"} {
+		if _, err := loadConfig(withFlag(bad), path); err == nil {
+			t.Errorf("--concurrency %s should be rejected", bad)
+		}
+	}
+}
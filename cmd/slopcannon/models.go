@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/keagan/slopcannon/internal/ai"
+	"github.com/keagan/slopcannon/internal/config"
+	"github.com/keagan/slopcannon/internal/ffmpeg"
+	"github.com/keagan/slopcannon/internal/ui"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var modelsURL string
+
+var modelsCmd = &cobra.Command{
+	Use:   "models",
+	Short: "Model management commands",
+}
+
+var modelsDownloadCmd = &cobra.Command{
+	Use:   "download [clip|whisper]",
+	Short: "Download model files into the model directory",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := config.FromContext(cmd.Context())
+
+		set := "clip"
+		if len(args) == 1 {
+			set = args[0]
+		}
+		files, err := ai.ModelFiles(set, cfg.AI.WhisperModel)
+		if err != nil {
+			return err
+		}
+
+		baseURL := cfg.AI.ModelBaseURL
+		if modelsURL != "" {
+			baseURL = modelsURL
+		}
+		dir := ai.ModelDir(cfg.AI.ModelPath)
+
+		for _, name := range files {
+			bar := newProgressBar(name, true)
+			downloader := ai.NewModelDownloader(log.Logger, baseURL, downloadProgress(bar))
+
+			downloaded, err := downloader.Download(cmd.Context(), name, dir)
+			if bar != nil {
+				bar.Finish()
+			}
+			if err != nil {
+				return err
+			}
+
+			status := "up to date"
+			if downloaded {
+				status = "downloaded"
+			}
+			fmt.Printf("%-28s %s\n", name, status)
+		}
+		return nil
+	},
+}
+
+// downloadProgress feeds download byte counts into a progress bar
+func downloadProgress(bar *ui.ProgressBar) ai.DownloadProgress {
+	if bar == nil {
+		return nil
+	}
+	return func(name string, done, total int64) {
+		p := &ffmpeg.Progress{Time: fmt.Sprintf("%.1fMB", float64(done)/(1<<20))}
+		if total > 0 {
+			p.Percentage = float64(done) / float64(total) * 100
+		}
+		bar.Update(p)
+	}
+}
+
+func init() {
+	modelsDownloadCmd.Flags().StringVar(&modelsURL, "url", "", "base URL to download from (default: ai.model_base_url)")
+	modelsCmd.AddCommand(modelsDownloadCmd)
+}
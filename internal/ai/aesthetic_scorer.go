@@ -6,43 +6,72 @@ import (
 	"image"
 	"math"
 	"os"
-	"path/filepath"
-	"time"
 
 	"github.com/keagan/slopcannon/internal/clips"
 	"github.com/keagan/slopcannon/internal/ffmpeg"
 	"github.com/rs/zerolog"
 )
 
-// AestheticScorer uses simple image analysis heuristics
+// AestheticScorer uses simple image analysis heuristics. Each Score call
+// works on its own keyframes, so it is safe for concurrent use.
 type AestheticScorer struct {
-	logger zerolog.Logger
-	ffmpeg *ffmpeg.Executor
+	logger      zerolog.Logger
+	ffmpeg      *ffmpeg.Executor
+	samples     int
+	aggregation Aggregation
+	frameOpts   ffmpeg.FrameOptions
 }
 
 // NewAestheticScorer creates a lightweight image-based scorer
 func NewAestheticScorer(logger zerolog.Logger, exec *ffmpeg.Executor) *AestheticScorer {
 	return &AestheticScorer{
-		logger: logger.With().Str("scorer", "aesthetic").Logger(),
-		ffmpeg: exec,
+		logger:      logger.With().Str("scorer", "aesthetic").Logger(),
+		ffmpeg:      exec,
+		samples:     DefaultFrameSamples,
+		aggregation: AggregateMean,
 	}
 }
 
-// Score analyzes visual aesthetics of clip keyframe
-func (a *AestheticScorer) Score(ctx context.Context, clip *clips.Clip) (float64, error) {
-	// Extract keyframe from middle of clip
-	keyframeTime := clip.Start + (clip.Duration / 2)
-	keyframePath := filepath.Join(os.TempDir(), fmt.Sprintf("keyframe_%s_%d.jpg", clip.ID, time.Now().UnixNano()))
-	defer os.Remove(keyframePath)
+// SetFrameSampling sets how many keyframes are scored and how they combine
+func (a *AestheticScorer) SetFrameSampling(samples int, agg Aggregation) {
+	a.samples = samples
+	a.aggregation = agg
+}
+
+// SetFrameOptions sets the format, size and quality of extracted keyframes
+func (a *AestheticScorer) SetFrameOptions(opts ffmpeg.FrameOptions) {
+	a.frameOpts = opts
+}
+
+// Name returns "aesthetic"
+func (a *AestheticScorer) Name() string {
+	return "aesthetic"
+}
 
-	err := a.ffmpeg.ExtractFrame(ctx, clip.SourceURL, keyframeTime, keyframePath)
+// Score analyzes visual aesthetics of sampled clip keyframes
+func (a *AestheticScorer) Score(ctx context.Context, clip *clips.Clip) (float64, error) {
+	frames, cleanup, err := extractKeyframes(ctx, a.ffmpeg, clip, a.samples, a.frameOpts, "keyframe")
+	defer cleanup()
 	if err != nil {
 		a.logger.Warn().Err(err).Str("clip", clip.ID).Msg("keyframe extraction failed")
 		return 0.0, err
 	}
 
-	// Load image
-	file, err := os.Open(keyframePath)
+	scores := make([]float64, 0, len(frames))
+	for _, frame := range frames {
+		score, err := a.scoreFrame(clip, frame)
+		if err != nil {
+			return 0.0, err
+		}
+		scores = append(scores, score)
+	}
+
+	return aggregateScores(scores, a.aggregation), nil
+}
+
+// scoreFrame computes the aesthetic score of a single extracted frame
+func (a *AestheticScorer) scoreFrame(clip *clips.Clip, framePath string) (float64, error) {
+	file, err := os.Open(framePath)
 	if err != nil {
 		return 0.0, err
 	}
@@ -0,0 +1,344 @@
+package ai
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/keagan/slopcannon/internal/clips"
+	"github.com/keagan/slopcannon/internal/ffmpeg"
+	"github.com/rs/zerolog"
+	ort "github.com/yalue/onnxruntime_go"
+)
+
+// AudioEventModelFile is the audio classifier's default file inside the
+// model dir
+const AudioEventModelFile = "audio_events.onnx"
+
+// AudioEventClassMapFile is the optional class list next to the model
+// (index,mid,display_name rows, as shipped with YAMNet); without it the
+// built-in YAMNet labels are used
+const AudioEventClassMapFile = "yamnet_class_map.csv"
+
+const (
+	// Sample rate the classifier expects (YAMNet-style, 16 kHz mono)
+	audioEventSampleRate = 16000
+	// Samples per classifier window when the model input is dynamic
+	defaultAudioEventWindow = 15600
+	// audioEventThreshold is the class probability that counts as an event
+	audioEventThreshold = 0.3
+	// Event density at which a clip gets the full density score
+	fullAudioEventDensity = 0.25
+)
+
+// crowdReactionLabels are the display names of the classes counted as
+// crowd reactions: laughter, cheering, applause and crowd noise
+var crowdReactionLabels = []string{
+	"Laughter", "Baby laughter", "Giggle", "Snicker", "Belly laugh", "Chuckle, chortle",
+	"Cheering", "Applause", "Crowd",
+}
+
+// yamnetLabels are YAMNet's display names in output order, from
+// yamnet_class_map.csv, up to the last crowd reaction class
+var yamnetLabels = []string{
+	"Speech", "Child speech, kid speaking", "Conversation", "Narration, monologue",
+	"Babbling", "Speech synthesizer", "Shout", "Bellow", "Whoop", "Yell",
+	"Children shouting", "Screaming", "Whispering", "Laughter", "Baby laughter",
+	"Giggle", "Snicker", "Belly laugh", "Chuckle, chortle", "Crying, sobbing",
+	"Baby cry, infant cry", "Whimper", "Wail, moan", "Sigh", "Singing", "Choir",
+	"Yodeling", "Chant", "Mantra", "Child singing", "Synthetic singing", "Rapping",
+	"Humming", "Groan", "Grunt", "Whistling", "Breathing", "Wheeze", "Snoring",
+	"Gasp", "Pant", "Snort", "Cough", "Throat clearing", "Sneeze", "Sniff", "Run",
+	"Shuffle", "Walk, footsteps", "Chewing, mastication", "Biting", "Gargling",
+	"Stomach rumble", "Burping, eructation", "Hiccup", "Fart", "Hands",
+	"Finger snapping", "Clapping", "Heart sounds, heartbeat", "Heart murmur",
+	"Cheering", "Applause", "Chatter", "Crowd",
+}
+
+// classIndices returns the output index of each name in labels
+func classIndices(labels, names []string) ([]int, error) {
+	index := make(map[string]int, len(labels))
+	for i, label := range labels {
+		index[label] = i
+	}
+
+	classes := make([]int, len(names))
+	for i, name := range names {
+		class, ok := index[name]
+		if !ok {
+			return nil, fmt.Errorf("audio event class %q not in the class map", name)
+		}
+		classes[i] = class
+	}
+	return classes, nil
+}
+
+// loadClassMap reads display names from a yamnet_class_map.csv-style file
+// (header, then index,mid,display_name rows)
+func loadClassMap(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read class map: %w", err)
+	}
+	if len(rows) < 2 {
+		return nil, fmt.Errorf("class map %s is empty", path)
+	}
+
+	labels := make([]string, len(rows)-1)
+	for _, row := range rows[1:] {
+		if len(row) < 3 {
+			return nil, fmt.Errorf("class map row %v: expected index,mid,display_name", row)
+		}
+		index, err := strconv.Atoi(row[0])
+		if err != nil || index < 0 || index >= len(labels) {
+			return nil, fmt.Errorf("class map row %v: bad index", row)
+		}
+		labels[index] = row[2]
+	}
+	return labels, nil
+}
+
+// audioEventClasses resolves the crowd reaction classes for the model at
+// modelPath, preferring a class map next to it over the built-in labels
+func audioEventClasses(modelPath string) ([]int, error) {
+	labels := yamnetLabels
+	mapPath := filepath.Join(filepath.Dir(modelPath), AudioEventClassMapFile)
+	if _, err := os.Stat(mapPath); err == nil {
+		if labels, err = loadClassMap(mapPath); err != nil {
+			return nil, err
+		}
+	}
+	return classIndices(labels, crowdReactionLabels)
+}
+
+// AudioEventScorer boosts clips with crowd reactions (laughter, applause,
+// cheering) found by an ONNX audio classifier. The model takes a window of
+// 16 kHz mono samples and outputs per-class AudioSet scores. Concurrent
+// Score calls share the ONNX session, whose Run is thread-safe.
+type AudioEventScorer struct {
+	logger  zerolog.Logger
+	ffmpeg  *ffmpeg.Executor
+	session *ort.DynamicAdvancedSession
+	model   string
+
+	// Samples per run; the clip's audio is classified window by window
+	window int64
+	// batched is set for [1,N] inputs rather than a bare [N] waveform
+	batched bool
+	// classes are the output indices counted as crowd reactions
+	classes []int
+}
+
+// NewAudioEventScorer loads an audio event classifier
+func NewAudioEventScorer(logger zerolog.Logger, ffmpegExec *ffmpeg.Executor, modelPath string, provider ExecutionProvider) (*AudioEventScorer, error) {
+	if _, err := os.Stat(modelPath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("audio event model file not found: %s", modelPath)
+	}
+
+	if err := initONNX(); err != nil {
+		return nil, err
+	}
+
+	inputs, outputs, err := ort.GetInputOutputInfo(modelPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audio event model inputs/outputs: %w", err)
+	}
+	input, err := pickTensor(inputs, "waveform", "audio event model input")
+	if err != nil {
+		return nil, err
+	}
+	output, err := pickTensor(outputs, "scores", "audio event model output")
+	if err != nil {
+		return nil, err
+	}
+
+	window := lastDim(input.Dimensions)
+	if window <= 0 {
+		window = defaultAudioEventWindow
+	}
+	classes, err := audioEventClasses(modelPath)
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := newSessionOptions(logger, provider)
+	if err != nil {
+		return nil, err
+	}
+	if options != nil {
+		defer options.Destroy()
+	}
+
+	session, err := ort.NewDynamicAdvancedSession(modelPath, []string{input.Name}, []string{output.Name}, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create audio event model session: %w", err)
+	}
+
+	logger.Info().
+		Str("model", modelPath).
+		Str("provider", string(provider)).
+		Int64("window", window).
+		Msg("audio event model loaded")
+
+	return &AudioEventScorer{
+		logger:  logger.With().Str("scorer", "audio_event").Logger(),
+		ffmpeg:  ffmpegExec,
+		session: session,
+		model:   modelPath,
+		window:  window,
+		batched: len(input.Dimensions) == 2,
+		classes: classes,
+	}, nil
+}
+
+// Name returns "audio_event"
+func (a *AudioEventScorer) Name() string {
+	return "audio_event"
+}
+
+// Fingerprint identifies the model file and the classes counted
+func (a *AudioEventScorer) Fingerprint() string {
+	return fmt.Sprintf("%s|classes=%v", fileFingerprint(a.model), a.classes)
+}
+
+// Score classifies the clip's audio and rates how much of it holds crowd
+// reactions
+func (a *AudioEventScorer) Score(ctx context.Context, clip *clips.Clip) (float64, error) {
+	samples, err := a.clipAudio(ctx, clip)
+	if err != nil {
+		a.logger.Warn().Err(err).Str("clip", clip.ID).Msg("audio extraction failed")
+		return 0.0, err
+	}
+
+	probs := make([]float64, 0, len(samples)/int(a.window)+1)
+	for start := 0; start < len(samples); start += int(a.window) {
+		if err := ctx.Err(); err != nil {
+			return 0.0, err
+		}
+		prob, err := a.classify(samples[start:])
+		if err != nil {
+			return 0.0, err
+		}
+		probs = append(probs, prob)
+	}
+
+	density, score := audioEventScore(probs)
+	clip.SetMeta("audio_event_density", density)
+	clip.SetMeta("audio_event_score", score)
+
+	a.logger.Debug().
+		Str("clip", clip.ID).
+		Int("windows", len(probs)).
+		Float64("density", density).
+		Float64("audio_event_score", score).
+		Msg("audio event scoring complete")
+
+	return score, nil
+}
+
+// clipAudio extracts the clip's audio as 16 kHz mono samples
+func (a *AudioEventScorer) clipAudio(ctx context.Context, clip *clips.Clip) ([]float32, error) {
+	f, err := os.CreateTemp("", "audio_event_*.wav")
+	if err != nil {
+		return nil, err
+	}
+	path := f.Name()
+	f.Close()
+	defer os.Remove(path)
+
+	format := ffmpeg.DefaultWhisperFormat()
+	format.SampleRate = audioEventSampleRate
+	if err := a.ffmpeg.ExtractAudioRange(ctx, clip.SourceURL, path, clip.Start, clip.End, format); err != nil {
+		return nil, err
+	}
+
+	samples, _, err := readWAVFile(path)
+	return samples, err
+}
+
+// classify runs one window (zero-padded when short) and returns the highest
+// crowd-reaction probability across the model's output frames
+func (a *AudioEventScorer) classify(samples []float32) (float64, error) {
+	window := make([]float32, a.window)
+	copy(window, samples)
+
+	shape := ort.NewShape(a.window)
+	if a.batched {
+		shape = ort.NewShape(1, a.window)
+	}
+	inputTensor, err := ort.NewTensor(shape, window)
+	if err != nil {
+		return 0.0, fmt.Errorf("failed to create waveform tensor: %w", err)
+	}
+	defer inputTensor.Destroy()
+
+	// Frame count depends on the model; let ONNX Runtime allocate it
+	outputs := []ort.ArbitraryTensor{nil}
+	if err := a.session.Run([]ort.ArbitraryTensor{inputTensor}, outputs); err != nil {
+		return 0.0, fmt.Errorf("audio event inference failed: %w", err)
+	}
+	defer outputs[0].Destroy()
+
+	scores, ok := outputs[0].(*ort.Tensor[float32])
+	if !ok {
+		return 0.0, fmt.Errorf("audio event model scores are not float32")
+	}
+	return maxClassScore(scores.GetData(), int(lastDim(scores.GetShape())), a.classes)
+}
+
+// maxClassScore returns the highest score among classes over rows of
+// numClasses scores
+func maxClassScore(data []float32, numClasses int, classes []int) (float64, error) {
+	if numClasses <= 0 || len(data)%numClasses != 0 {
+		return 0.0, fmt.Errorf("unexpected audio event output size %d for %d classes", len(data), numClasses)
+	}
+
+	var best float64
+	for row := 0; row < len(data); row += numClasses {
+		for _, class := range classes {
+			if class < numClasses {
+				best = math.Max(best, float64(data[row+class]))
+			}
+		}
+	}
+	return best, nil
+}
+
+// audioEventScore folds per-window event probabilities into a clip score:
+// mostly how often reactions occur, plus how strong the loudest one is
+func audioEventScore(probs []float64) (density, score float64) {
+	if len(probs) == 0 {
+		return 0.0, 0.0
+	}
+
+	var events int
+	var peak float64
+	for _, p := range probs {
+		if p >= audioEventThreshold {
+			events++
+		}
+		peak = math.Max(peak, p)
+	}
+
+	density = float64(events) / float64(len(probs))
+	score = 0.6*math.Min(1.0, density/fullAudioEventDensity) + 0.4*peak
+	return density, clamp01(score)
+}
+
+// Close releases the model session
+func (a *AudioEventScorer) Close() error {
+	if a.session != nil {
+		return a.session.Destroy()
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package ai
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+// testWAV builds a 16-bit PCM WAV with an extra chunk before the data
+func testWAV(channels int, frames [][]int16) []byte {
+	var data bytes.Buffer
+	for _, frame := range frames {
+		binary.Write(&data, binary.LittleEndian, frame)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("RIFF")
+	binary.Write(&buf, binary.LittleEndian, uint32(0))
+	buf.WriteString("WAVE")
+
+	buf.WriteString("fmt ")
+	binary.Write(&buf, binary.LittleEndian, uint32(16))
+	binary.Write(&buf, binary.LittleEndian, uint16(1))
+	binary.Write(&buf, binary.LittleEndian, uint16(channels))
+	binary.Write(&buf, binary.LittleEndian, uint32(audioEventSampleRate))
+	binary.Write(&buf, binary.LittleEndian, uint32(audioEventSampleRate*2*channels))
+	binary.Write(&buf, binary.LittleEndian, uint16(2*channels))
+	binary.Write(&buf, binary.LittleEndian, uint16(16))
+
+	buf.WriteString("LIST")
+	binary.Write(&buf, binary.LittleEndian, uint32(3))
+	buf.Write([]byte{1, 2, 3, 0}) // odd size plus padding
+
+	buf.WriteString("data")
+	binary.Write(&buf, binary.LittleEndian, uint32(data.Len()))
+	buf.Write(data.Bytes())
+	return buf.Bytes()
+}
+
+func TestReadWAVAveragesChannels(t *testing.T) {
+	wav := testWAV(2, [][]int16{{16384, 0}, {-32768, -32768}, {100, -100}})
+
+	samples, rate, err := readWAV(bytes.NewReader(wav))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rate != audioEventSampleRate {
+		t.Errorf("expected rate %d, got %d", audioEventSampleRate, rate)
+	}
+	want := []float32{0.25, -1, 0}
+	if len(samples) != len(want) {
+		t.Fatalf("expected %d samples, got %v", len(want), samples)
+	}
+	for i := range want {
+		if math.Abs(float64(samples[i]-want[i])) > 1e-6 {
+			t.Errorf("sample %d: expected %v, got %v", i, want[i], samples[i])
+		}
+	}
+
+	if _, _, err := readWAV(bytes.NewReader([]byte("not a wav file"))); err == nil {
+		t.Error("expected an error for non-WAV input")
+	}
+}
+
+func TestMaxClassScore(t *testing.T) {
+	// Two frames of four classes; class 1 and 3 are events
+	data := []float32{
+		0.9, 0.1, 0.0, 0.2,
+		0.1, 0.6, 0.9, 0.0,
+	}
+	got, err := maxClassScore(data, 4, []int{1, 3, 99})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(got-0.6) > 1e-6 {
+		t.Errorf("expected 0.6, got %v", got)
+	}
+
+	if _, err := maxClassScore(data[:7], 4, []int{1}); err == nil {
+		t.Error("expected an error for a ragged output")
+	}
+}
+
+func TestAudioEventScore(t *testing.T) {
+	if density, score := audioEventScore(nil); density != 0 || score != 0 {
+		t.Errorf("expected zero for no audio, got %v/%v", density, score)
+	}
+
+	quiet := []float64{0.01, 0.02, 0.05, 0.0}
+	_, quietScore := audioEventScore(quiet)
+	density, laughScore := audioEventScore([]float64{0.01, 0.8, 0.9, 0.0})
+	if density != 0.5 {
+		t.Errorf("expected density 0.5, got %v", density)
+	}
+	if laughScore <= quietScore || laughScore < 0.9 {
+		t.Errorf("expected crowd reactions to score high: quiet=%v laugh=%v", quietScore, laughScore)
+	}
+}
+
+func TestCrowdReactionClassIndices(t *testing.T) {
+	classes, err := classIndices(yamnetLabels, crowdReactionLabels)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []int{13, 14, 15, 16, 17, 18, 61, 62, 64}
+	for i, class := range classes {
+		if class != want[i] {
+			t.Errorf("%s: expected YAMNet index %d, got %d", crowdReactionLabels[i], want[i], class)
+		}
+		if yamnetLabels[class] != crowdReactionLabels[i] {
+			t.Errorf("index %d is %q, not %q", class, yamnetLabels[class], crowdReactionLabels[i])
+		}
+	}
+}
+
+func TestAudioEventClassesFromClassMap(t *testing.T) {
+	dir := t.TempDir()
+	classMap := "index,mid,display_name\n0,/m/09x0r,Speech\n1,/m/01j3sz,Laughter\n2,/t/dd00013,\"Chuckle, chortle\"\n"
+	for i, label := range []string{"Baby laughter", "Giggle", "Snicker", "Belly laugh", "Cheering", "Applause", "Crowd"} {
+		classMap += strconv.Itoa(i+3) + ",/m/x," + label + "\n"
+	}
+	if err := os.WriteFile(filepath.Join(dir, AudioEventClassMapFile), []byte(classMap), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	classes, err := audioEventClasses(filepath.Join(dir, AudioEventModelFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []int{1, 3, 4, 5, 6, 2, 7, 8, 9}
+	for i := range want {
+		if classes[i] != want[i] {
+			t.Fatalf("expected classes %v from the class map, got %v", want, classes)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package ai
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/keagan/slopcannon/internal/ffmpeg"
+	"github.com/rs/zerolog"
+)
+
+// AnalysisCache persists expensive detector results (scene, silence and
+// volume analysis plus per-clip scores) on disk. Entries are keyed by the
+// input file's path+size+mtime and by the detector/scorer parameters, so
+// changing either yields a miss and the stale entry is replaced on save.
+type AnalysisCache struct {
+	dir    string
+	logger zerolog.Logger
+}
+
+// NewAnalysisCache creates a cache rooted at dir
+func NewAnalysisCache(logger zerolog.Logger, dir string) *AnalysisCache {
+	return &AnalysisCache{
+		dir:    dir,
+		logger: logger.With().Str("component", "analysis-cache").Logger(),
+	}
+}
+
+// cacheVersion is part of every cache key. Bump it when cacheEntry gains
+// fields that older entries would lack (2: scene cut strengths).
+const cacheVersion = 2
+
+// cacheEntry holds everything Detect needs to skip ffmpeg on a rerun
+type cacheEntry struct {
+	Scenes   []time.Duration         `json:"scenes"`
+	Cuts     []ffmpeg.SceneChange    `json:"cuts,omitempty"`
+	Silences []ffmpeg.SilenceSegment `json:"silences"`
+	Volume   *ffmpeg.VolumeStats     `json:"volume"`
+	Motion   []ffmpeg.MotionSample   `json:"motion,omitempty"`
+	Scores   map[string]cachedScore  `json:"scores"`
+}
+
+// cachedScore is a clip score plus any metadata the scorer attached
+type cachedScore struct {
+	Score    float64                `json:"score"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// cacheKey identifies one video analyzed with one parameter set
+type cacheKey struct {
+	file   string
+	params string
+}
+
+func (k cacheKey) filename() string {
+	return k.file + "_" + k.params + ".json"
+}
+
+// keyFor builds the cache key for a video and parameter set
+func (c *AnalysisCache) keyFor(videoPath string, params ...interface{}) (cacheKey, error) {
+	absPath, err := filepath.Abs(videoPath)
+	if err != nil {
+		return cacheKey{}, err
+	}
+
+	info, err := os.Stat(absPath)
+	if err != nil {
+		return cacheKey{}, err
+	}
+
+	paramData, err := json.Marshal(append([]interface{}{cacheVersion}, params...))
+	if err != nil {
+		return cacheKey{}, fmt.Errorf("failed to encode cache params: %w", err)
+	}
+
+	return cacheKey{
+		file:   shortHash(fmt.Sprintf("%s|%d|%d", absPath, info.Size(), info.ModTime().UnixNano())),
+		params: shortHash(string(paramData)),
+	}, nil
+}
+
+// SetCache enables on-disk caching of analysis results and scores
+func (d *ClipDetector) SetCache(cache *AnalysisCache) {
+	d.cache = cache
+}
+
+// loadCache looks up cached analysis for the video. The returned key is only
+// meaningful when caching is enabled.
+func (d *ClipDetector) loadCache(videoPath string) (cacheKey, *cacheEntry, bool) {
+	if d.cache == nil {
+		return cacheKey{}, nil, false
+	}
+	if ffmpeg.IsURLInput(videoPath) {
+		// Remote content can change without notice and has no mtime to key on
+		d.logger.Debug().Str("video", videoPath).Msg("not caching analysis of a URL input")
+		d.cache = nil
+		return cacheKey{}, nil, false
+	}
+
+	key, err := d.cache.keyFor(videoPath, d.config, scorerFingerprint(d.scorer))
+	if err != nil {
+		d.logger.Warn().Err(err).Msg("cannot build cache key; analysis will not be cached")
+		d.cache = nil
+		return cacheKey{}, nil, false
+	}
+
+	entry, ok := d.cache.load(key)
+	if ok {
+		d.logger.Info().Str("video", videoPath).Msg("using cached analysis")
+	}
+	return key, entry, ok
+}
+
+// load returns the cached entry for key, if present and readable
+func (c *AnalysisCache) load(key cacheKey) (*cacheEntry, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(filepath.Join(c.dir, key.filename()))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		c.logger.Warn().Err(err).Str("key", key.filename()).Msg("ignoring corrupt cache entry")
+		return nil, false
+	}
+	if entry.Scores == nil {
+		entry.Scores = make(map[string]cachedScore)
+	}
+
+	return &entry, true
+}
+
+// save writes the entry and removes entries for the same file that were
+// produced with different parameters
+func (c *AnalysisCache) save(key cacheKey, entry *cacheEntry) error {
+	if c == nil {
+		return nil
+	}
+
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create cache dir: %w", err)
+	}
+
+	stale, _ := filepath.Glob(filepath.Join(c.dir, key.file+"_*.json"))
+	for _, path := range stale {
+		if filepath.Base(path) != key.filename() {
+			c.logger.Debug().Str("entry", path).Msg("invalidating stale cache entry")
+			_ = os.Remove(path)
+		}
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+
+	// Write atomically so a crash never leaves a half-written entry
+	tmp, err := os.CreateTemp(c.dir, "entry-*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), filepath.Join(c.dir, key.filename()))
+}
+
+// segmentKey identifies a candidate segment within a cache entry
+func segmentKey(seg candidateSegment) string {
+	return fmt.Sprintf("%d-%d", seg.Start, seg.End)
+}
+
+// scorerFingerprint describes a scorer's composition so that score caches
+// are invalidated when scorers, weights or the failure mode change
+func scorerFingerprint(s Scorer) string {
+	composite, ok := s.(*CompositeScorer)
+	if !ok {
+		if f, ok := s.(Fingerprinter); ok {
+			return s.Name() + "[" + f.Fingerprint() + "]"
+		}
+		return s.Name()
+	}
+
+	parts := make([]string, len(composite.scorers))
+	for i, child := range composite.scorers {
+		weight := 1.0
+		if i < len(composite.weights) {
+			weight = composite.weights[i]
+		}
+		parts[i] = fmt.Sprintf("%s:%g", scorerFingerprint(child), weight)
+	}
+	return fmt.Sprintf("composite[%s](%s)", composite.failureMode, strings.Join(parts, ","))
+}
+
+// fileFingerprint identifies a model file by path, size and mtime, so a
+// replaced model invalidates scores cached with the old one
+func fileFingerprint(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return path + "|missing"
+	}
+	return fmt.Sprintf("%s|%d|%d", path, info.Size(), info.ModTime().UnixNano())
+}
+
+func shortHash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:8])
+}
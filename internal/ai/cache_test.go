@@ -0,0 +1,94 @@
+package ai
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/keagan/slopcannon/internal/ffmpeg"
+	"github.com/rs/zerolog"
+)
+
+func TestAnalysisCacheRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	video := filepath.Join(dir, "video.mp4")
+	if err := os.WriteFile(video, []byte("fake video"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cache := NewAnalysisCache(zerolog.Nop(), filepath.Join(dir, "cache"))
+	cfg := DefaultDetectorConfig()
+
+	key, err := cache.keyFor(video, cfg)
+	if err != nil {
+		t.Fatalf("keyFor failed: %v", err)
+	}
+	if _, ok := cache.load(key); ok {
+		t.Fatal("expected miss on empty cache")
+	}
+
+	entry := &cacheEntry{
+		Scenes:   []time.Duration{1500 * time.Millisecond, 12 * time.Second},
+		Silences: []ffmpeg.SilenceSegment{{Start: 1, End: 2.5, Duration: 1.5}},
+		Volume:   &ffmpeg.VolumeStats{MeanVolume: -20, MaxVolume: -3},
+		Scores:   map[string]cachedScore{"0-10": {Score: 0.8, Metadata: map[string]interface{}{"clip_score": 0.9}}},
+	}
+	if err := cache.save(key, entry); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	got, ok := cache.load(key)
+	if !ok {
+		t.Fatal("expected cache hit")
+	}
+	if len(got.Scenes) != 2 || got.Scenes[0] != 1500*time.Millisecond {
+		t.Errorf("scenes not round-tripped: %v", got.Scenes)
+	}
+	if got.Scores["0-10"].Score != 0.8 {
+		t.Errorf("score not round-tripped: %+v", got.Scores)
+	}
+
+	// Changing detector params invalidates the old entry on save
+	cfg.SceneThreshold = 0.2
+	newKey, _ := cache.keyFor(video, cfg)
+	if _, ok := cache.load(newKey); ok {
+		t.Fatal("expected miss after config change")
+	}
+	if err := cache.save(newKey, entry); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	if _, ok := cache.load(key); ok {
+		t.Error("stale entry should have been removed")
+	}
+}
+
+func TestScorerFingerprintKeywords(t *testing.T) {
+	dir := t.TempDir()
+	video := filepath.Join(dir, "video.mp4")
+	if err := os.WriteFile(video, []byte("fake video"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cache := NewAnalysisCache(zerolog.Nop(), filepath.Join(dir, "cache"))
+	cfg := DefaultDetectorConfig()
+
+	keyWith := func(keywords map[string]float64) cacheKey {
+		scorer := NewCompositeScorer([]Scorer{NewHeuristicScorer(), NewKeywordScorer(nil, keywords)}, []float64{0.5, 0.5})
+		key, err := cache.keyFor(video, cfg, scorerFingerprint(scorer))
+		if err != nil {
+			t.Fatalf("keyFor failed: %v", err)
+		}
+		return key
+	}
+
+	base := keyWith(map[string]float64{"no way": 1, "insane": 0.5})
+	if keyWith(map[string]float64{"insane": 0.5, "no way": 1}) != base {
+		t.Error("keyword order must not change the key")
+	}
+	if keyWith(map[string]float64{"no way": 1, "insane": 0.8}) == base {
+		t.Error("changing a keyword weight must invalidate the key")
+	}
+	if keyWith(map[string]float64{"no way": 1, "crazy": 0.5}) == base {
+		t.Error("changing a keyword must invalidate the key")
+	}
+}
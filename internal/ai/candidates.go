@@ -0,0 +1,259 @@
+package ai
+
+import (
+	"sort"
+	"time"
+
+	"github.com/keagan/slopcannon/internal/ffmpeg"
+)
+
+// CandidateStrategy selects where candidate clip boundaries are placed
+type CandidateStrategy string
+
+const (
+	// StrategyScene cuts on scene changes
+	StrategyScene CandidateStrategy = "scene"
+	// StrategySilence cuts on speech/silence transitions, for low-cut
+	// content like talking heads and podcasts
+	StrategySilence CandidateStrategy = "silence"
+	// StrategyHybrid unions scene cuts and silence transitions
+	StrategyHybrid CandidateStrategy = "hybrid"
+	// StrategyWindow slides overlapping fixed-length windows over the
+	// whole input, so content with few cuts is still covered
+	StrategyWindow CandidateStrategy = "window"
+)
+
+// candidateSegment represents a potential clip
+type candidateSegment struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// generateCandidates creates candidate clips between boundaries chosen by
+// the configured strategy
+func (d *ClipDetector) generateCandidates(scenes []time.Duration, silences []ffmpeg.SilenceSegment, totalDuration time.Duration) []candidateSegment {
+	if d.config.CandidateStrategy == StrategyWindow {
+		return d.windowCandidates(totalDuration)
+	}
+
+	var segments []candidateSegment
+
+	// Silence gaps are dropped when the strategy cuts on silences, leaving
+	// a hard boundary for merging; scene cuts alone keep every segment
+	dropSilence := d.config.CandidateStrategy == StrategySilence || d.config.CandidateStrategy == StrategyHybrid
+	keep := func(segment candidateSegment) bool {
+		return !dropSilence || !withinSilence(segment, silences)
+	}
+
+	// Start from beginning
+	lastBoundary := time.Duration(0)
+
+	for _, boundary := range d.candidateBoundaries(scenes, silences) {
+		if boundary <= lastBoundary || boundary >= totalDuration {
+			continue
+		}
+
+		segment := candidateSegment{Start: lastBoundary, End: boundary}
+		if keep(segment) {
+			segments = append(segments, segment)
+		}
+		lastBoundary = boundary
+	}
+
+	// Add final segment
+	final := candidateSegment{Start: lastBoundary, End: totalDuration}
+	if final.End > final.Start && keep(final) {
+		segments = append(segments, final)
+	}
+
+	// Merge adjacent short segments, then split long ones
+	return d.mergeShortSegments(segments)
+}
+
+// mergeShortSegments coalesces runs of adjacent segments shorter than
+// MinClipLength until they reach it, then splits anything longer than
+// MaxClipLength. A gap between segments is a hard boundary: a run that
+// can't reach the minimum before one is dropped. A short run at the very
+// end joins the previous candidate when that stays within MaxClipLength,
+// and is otherwise kept as a trailing remainder.
+func (d *ClipDetector) mergeShortSegments(segments []candidateSegment) []candidateSegment {
+	var merged []candidateSegment
+	var run candidateSegment
+	inRun := false
+
+	for _, seg := range segments {
+		if inRun && seg.Start != run.End {
+			// Hard boundary: a short run can't grow any further
+			inRun = false
+		}
+
+		if inRun {
+			run.End = seg.End
+		} else {
+			run, inRun = seg, true
+		}
+
+		if run.End-run.Start >= d.config.MinClipLength {
+			merged = append(merged, run)
+			inRun = false
+		}
+	}
+
+	// Trailing remainder
+	if inRun {
+		if n := len(merged); n > 0 && merged[n-1].End == run.Start &&
+			(d.config.MaxClipLength <= 0 || run.End-merged[n-1].Start <= d.config.MaxClipLength) {
+			merged[n-1].End = run.End
+		} else {
+			merged = append(merged, run)
+		}
+	}
+
+	return d.splitLongSegments(merged)
+}
+
+// splitLongSegments splits segments longer than MaxClipLength into equal
+// chunks (MaxClipLength 0 = no cap). Chunks never go under MinClipLength:
+// when both limits can't be met, fewer, longer chunks are used.
+func (d *ClipDetector) splitLongSegments(segments []candidateSegment) []candidateSegment {
+	split := make([]candidateSegment, 0, len(segments))
+
+	for _, current := range segments {
+		length := current.End - current.Start
+		if d.config.MaxClipLength <= 0 || length <= d.config.MaxClipLength {
+			split = append(split, current)
+			continue
+		}
+
+		chunks := int(length/d.config.MaxClipLength) + 1
+		if minLen := d.config.MinClipLength; minLen > 0 && length/time.Duration(chunks) < minLen {
+			chunks = int(length / minLen)
+		}
+		if chunks <= 1 {
+			split = append(split, current)
+			continue
+		}
+
+		splitPoints := chunks - 1
+		chunkSize := length / time.Duration(chunks)
+
+		for j := 0; j <= splitPoints; j++ {
+			start := current.Start + time.Duration(j)*chunkSize
+			end := start + chunkSize
+			if j == splitPoints || end > current.End {
+				end = current.End
+			}
+			split = append(split, candidateSegment{Start: start, End: end})
+		}
+	}
+
+	return split
+}
+
+// windowCandidates covers the input with windows of WindowLength, each
+// starting OverlapSeconds before the previous one ends. A last window is
+// aligned to the end of the input so the tail is covered too.
+func (d *ClipDetector) windowCandidates(totalDuration time.Duration) []candidateSegment {
+	length := d.windowLength()
+	if totalDuration <= length {
+		if totalDuration <= 0 {
+			return nil
+		}
+		return []candidateSegment{{Start: 0, End: totalDuration}}
+	}
+
+	step := length - time.Duration(d.config.OverlapSeconds*float64(time.Second))
+	if step <= 0 {
+		step = length
+	}
+
+	var windows []candidateSegment
+	start := time.Duration(0)
+	for ; start+length <= totalDuration; start += step {
+		windows = append(windows, candidateSegment{Start: start, End: start + length})
+	}
+	if last := windows[len(windows)-1]; last.End < totalDuration {
+		windows = append(windows, candidateSegment{Start: totalDuration - length, End: totalDuration})
+	}
+	return windows
+}
+
+// windowLength returns WindowLength clamped to [MinClipLength, MaxClipLength],
+// defaulting to halfway between them
+func (d *ClipDetector) windowLength() time.Duration {
+	minLen, maxLen := d.config.MinClipLength, d.config.MaxClipLength
+	length := d.config.WindowLength
+	if length <= 0 {
+		length = (minLen + maxLen) / 2
+	}
+	if maxLen > 0 && length > maxLen {
+		length = maxLen
+	}
+	if length < minLen {
+		length = minLen
+	}
+	if length <= 0 {
+		length = DefaultDetectorConfig().MinClipLength
+	}
+	return length
+}
+
+// candidateBoundaries returns sorted, de-duplicated cut points for the strategy
+func (d *ClipDetector) candidateBoundaries(scenes []time.Duration, silences []ffmpeg.SilenceSegment) []time.Duration {
+	var boundaries []time.Duration
+
+	switch d.config.CandidateStrategy {
+	case StrategySilence:
+		boundaries = silenceBoundaries(silences)
+	case StrategyHybrid:
+		boundaries = append(append(boundaries, scenes...), silenceBoundaries(silences)...)
+	default:
+		boundaries = append(boundaries, scenes...)
+	}
+
+	sort.Slice(boundaries, func(i, j int) bool { return boundaries[i] < boundaries[j] })
+
+	unique := boundaries[:0]
+	for i, b := range boundaries {
+		if i == 0 || b != boundaries[i-1] {
+			unique = append(unique, b)
+		}
+	}
+	return unique
+}
+
+// silenceBoundaries turns each silence into two cut points so that
+// candidates start when speech starts and end when it stops
+func silenceBoundaries(silences []ffmpeg.SilenceSegment) []time.Duration {
+	boundaries := make([]time.Duration, 0, len(silences)*2)
+	for _, s := range silences {
+		boundaries = append(boundaries,
+			time.Duration(s.Start*float64(time.Second)),
+			time.Duration(s.End*float64(time.Second)),
+		)
+	}
+	return boundaries
+}
+
+// withinSilence reports whether a segment lies entirely inside one silence
+func withinSilence(segment candidateSegment, silences []ffmpeg.SilenceSegment) bool {
+	for _, s := range silences {
+		start := time.Duration(s.Start * float64(time.Second))
+		end := time.Duration(s.End * float64(time.Second))
+		if segment.Start >= start && segment.End <= end {
+			return true
+		}
+	}
+	return false
+}
+
+// cutStrength is the scene score of the cut a candidate starts on, or 0
+// when it starts at the beginning of the video or on a silence boundary
+func cutStrength(start time.Duration, cuts []ffmpeg.SceneChange) float64 {
+	for _, c := range cuts {
+		if c.Time == start {
+			return c.Score
+		}
+	}
+	return 0
+}
@@ -0,0 +1,89 @@
+package ai
+
+import (
+	"context"
+
+	"github.com/keagan/slopcannon/internal/clips"
+)
+
+// DefaultBatchSize is how many keyframes the CLIP encoder scores per run
+const DefaultBatchSize = 16
+
+// SetBatchSize sets the maximum keyframes per inference run; 1 scores one
+// frame at a time for memory-constrained machines
+func (c *CLIPScorer) SetBatchSize(n int) {
+	if n < 1 {
+		n = 1
+	}
+	c.batchSize = n
+}
+
+// Prepare extracts keyframes for every clip and scores them in as few
+// encoder/head runs as the batch size allows. Clips whose frames can't be
+// extracted are left for Score to retry individually.
+func (c *CLIPScorer) Prepare(ctx context.Context, list []*clips.Clip) error {
+	var (
+		paths  []string
+		owners []int // index into list for each path
+	)
+	for i, clip := range list {
+		frames, cleanup, err := extractKeyframes(ctx, c.ffmpeg, clip, c.samples, c.frameOpts, "clip_keyframe")
+		defer cleanup()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			c.logger.Debug().Err(err).Str("clip", clip.ID).Msg("keyframe extraction failed; scoring later")
+			continue
+		}
+		for _, frame := range frames {
+			paths = append(paths, frame)
+			owners = append(owners, i)
+		}
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+
+	scores, err := c.scoreFrames(paths)
+	if err != nil {
+		return err
+	}
+
+	perClip := groupScores(scores, owners)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, frameScores := range perClip {
+		c.prepared[list[i]] = aggregateScores(frameScores, c.aggregation)
+	}
+
+	c.logger.Debug().
+		Int("clips", len(perClip)).
+		Int("frames", len(paths)).
+		Int("batch_size", c.batchSize).
+		Msg("CLIP batch scoring complete")
+
+	return nil
+}
+
+// takePrepared returns and forgets the score Prepare computed for clip
+func (c *CLIPScorer) takePrepared(clip *clips.Clip) (float64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	score, ok := c.prepared[clip]
+	if ok {
+		delete(c.prepared, clip)
+	}
+	return score, ok
+}
+
+// groupScores collects frame scores by the clip index that owns each frame
+func groupScores(scores []float64, owners []int) map[int][]float64 {
+	grouped := make(map[int][]float64)
+	for i, score := range scores {
+		grouped[owners[i]] = append(grouped[owners[i]], score)
+	}
+	return grouped
+}
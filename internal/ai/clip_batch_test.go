@@ -0,0 +1,14 @@
+package ai
+
+import "testing"
+
+func TestGroupScores(t *testing.T) {
+	grouped := groupScores([]float64{0.1, 0.2, 0.3, 0.9}, []int{0, 0, 0, 2})
+
+	if len(grouped) != 2 || len(grouped[0]) != 3 || grouped[2][0] != 0.9 {
+		t.Errorf("unexpected grouping %v", grouped)
+	}
+	if _, ok := grouped[1]; ok {
+		t.Error("clip without frames should have no scores")
+	}
+}
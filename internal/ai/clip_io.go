@@ -0,0 +1,140 @@
+package ai
+
+import (
+	"fmt"
+
+	ort "github.com/yalue/onnxruntime_go"
+)
+
+// Conventional CLIP export names, preferred when a model has several
+// inputs or outputs
+const (
+	clipPixelInput  = "pixel_values"
+	clipEmbedOutput = "image_embeds"
+	clipScoreOutput = "score_logits"
+
+	defaultEmbedDim  = 512
+	defaultImageSize = 224
+)
+
+// clipIO describes the tensors of a CLIP encoder + virality head pair
+type clipIO struct {
+	pixelInput  string
+	embedOutput string
+	headInput   string
+	scoreOutput string
+	embedDim    int64
+	imageSize   int64
+	// scoreRank is 1 for [N] score outputs and 2 for [N,1]
+	scoreRank int
+	// fixedBatch is set when the encoder only accepts one image per run
+	fixedBatch bool
+}
+
+// loadCLIPIO reads the input/output metadata of both models
+func loadCLIPIO(encoderPath, headPath string) (clipIO, error) {
+	encIn, encOut, err := ort.GetInputOutputInfo(encoderPath)
+	if err != nil {
+		return clipIO{}, fmt.Errorf("failed to read encoder inputs/outputs: %w", err)
+	}
+	headIn, headOut, err := ort.GetInputOutputInfo(headPath)
+	if err != nil {
+		return clipIO{}, fmt.Errorf("failed to read virality head inputs/outputs: %w", err)
+	}
+	return resolveCLIPIO(encIn, encOut, headIn, headOut)
+}
+
+// resolveCLIPIO picks tensor names and checks that the encoder's embedding
+// size matches what the head consumes. Dynamic dimensions (-1) are filled
+// in from the other model or the CLIP defaults.
+func resolveCLIPIO(encIn, encOut, headIn, headOut []ort.InputOutputInfo) (clipIO, error) {
+	pixel, err := pickTensor(encIn, clipPixelInput, "encoder input")
+	if err != nil {
+		return clipIO{}, err
+	}
+	embed, err := pickTensor(encOut, clipEmbedOutput, "encoder output")
+	if err != nil {
+		return clipIO{}, err
+	}
+	headInput, err := pickTensor(headIn, clipEmbedOutput, "head input")
+	if err != nil {
+		return clipIO{}, err
+	}
+	score, err := pickTensor(headOut, clipScoreOutput, "head output")
+	if err != nil {
+		return clipIO{}, err
+	}
+
+	layout := clipIO{
+		pixelInput:  pixel.Name,
+		embedOutput: embed.Name,
+		headInput:   headInput.Name,
+		scoreOutput: score.Name,
+		embedDim:    defaultEmbedDim,
+		imageSize:   defaultImageSize,
+		scoreRank:   len(score.Dimensions),
+	}
+	if layout.scoreRank != 1 {
+		layout.scoreRank = 2
+	}
+
+	encDim, headDim := lastDim(embed.Dimensions), lastDim(headInput.Dimensions)
+	switch {
+	case encDim > 0 && headDim > 0 && encDim != headDim:
+		return clipIO{}, fmt.Errorf("encoder output %q has %d dims but virality head input %q expects %d; "+
+			"the head was trained for a different CLIP variant", embed.Name, encDim, headInput.Name, headDim)
+	case encDim > 0:
+		layout.embedDim = encDim
+	case headDim > 0:
+		layout.embedDim = headDim
+	}
+
+	layout.imageSize, layout.fixedBatch, err = imageInputShape(pixel, "encoder input")
+	if err != nil {
+		return clipIO{}, err
+	}
+
+	return layout, nil
+}
+
+// imageInputShape returns the square image size of an [N,3,S,S] input
+// (defaultImageSize when dynamic) and whether it only takes one image per run
+func imageInputShape(info ort.InputOutputInfo, what string) (int64, bool, error) {
+	dims := info.Dimensions
+	if len(dims) == 0 {
+		return defaultImageSize, false, nil
+	}
+	if len(dims) != 4 {
+		return 0, false, fmt.Errorf("%s %q has shape %s; expected [N,3,H,W]", what, info.Name, dims)
+	}
+	if dims[2] > 0 && dims[3] > 0 && dims[2] != dims[3] {
+		return 0, false, fmt.Errorf("%s %q is %dx%d; only square inputs are supported", what, info.Name, dims[3], dims[2])
+	}
+
+	size := int64(defaultImageSize)
+	if dims[2] > 0 {
+		size = dims[2]
+	}
+	return size, dims[0] == 1, nil
+}
+
+// pickTensor returns the tensor named preferred, or the only/first one
+func pickTensor(infos []ort.InputOutputInfo, preferred, what string) (ort.InputOutputInfo, error) {
+	if len(infos) == 0 {
+		return ort.InputOutputInfo{}, fmt.Errorf("model has no %s", what)
+	}
+	for _, info := range infos {
+		if info.Name == preferred {
+			return info, nil
+		}
+	}
+	return infos[0], nil
+}
+
+// lastDim returns the innermost dimension, or -1 when unknown
+func lastDim(shape ort.Shape) int64 {
+	if len(shape) == 0 {
+		return -1
+	}
+	return shape[len(shape)-1]
+}
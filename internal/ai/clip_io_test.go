@@ -0,0 +1,57 @@
+package ai
+
+import (
+	"strings"
+	"testing"
+
+	ort "github.com/yalue/onnxruntime_go"
+)
+
+func tensorInfo(name string, dims ...int64) []ort.InputOutputInfo {
+	return []ort.InputOutputInfo{{Name: name, Dimensions: ort.NewShape(dims...)}}
+}
+
+func TestResolveCLIPIO(t *testing.T) {
+	layout, err := resolveCLIPIO(
+		tensorInfo("input", -1, 3, 336, 336),
+		[]ort.InputOutputInfo{
+			{Name: "last_hidden_state", Dimensions: ort.NewShape(-1, 50, 1024)},
+			{Name: "image_embeds", Dimensions: ort.NewShape(-1, 768)},
+		},
+		tensorInfo("embeds", -1, -1),
+		tensorInfo("score", -1),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if layout.pixelInput != "input" || layout.embedOutput != "image_embeds" || layout.headInput != "embeds" || layout.scoreOutput != "score" {
+		t.Errorf("unexpected names %+v", layout)
+	}
+	if layout.embedDim != 768 || layout.imageSize != 336 || layout.scoreRank != 1 || layout.fixedBatch {
+		t.Errorf("unexpected shapes %+v", layout)
+	}
+}
+
+func TestResolveCLIPIODimMismatch(t *testing.T) {
+	_, err := resolveCLIPIO(
+		tensorInfo("pixel_values", 1, 3, 224, 224),
+		tensorInfo("image_embeds", 1, 768),
+		tensorInfo("image_embeds", 1, 512),
+		tensorInfo("score_logits", 1, 1),
+	)
+	if err == nil || !strings.Contains(err.Error(), "768") || !strings.Contains(err.Error(), "512") {
+		t.Errorf("expected dimension mismatch error, got %v", err)
+	}
+}
+
+func TestImageInputShape(t *testing.T) {
+	size, fixed, err := imageInputShape(tensorInfo("x", 1, 3, 384, 384)[0], "model input")
+	if err != nil || size != 384 || !fixed {
+		t.Errorf("expected fixed 384 input, got %d %v (%v)", size, fixed, err)
+	}
+
+	if _, _, err := imageInputShape(tensorInfo("x", -1, 512)[0], "model input"); err == nil {
+		t.Error("expected error for non-image input")
+	}
+}
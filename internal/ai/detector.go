@@ -2,12 +2,17 @@ package ai
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/keagan/slopcannon/internal/clips"
 	"github.com/keagan/slopcannon/internal/ffmpeg"
 	"github.com/rs/zerolog"
+	"golang.org/x/sync/errgroup"
 )
 
 // DetectorConfig configures clip detection behavior
@@ -19,6 +24,21 @@ type DetectorConfig struct {
 	MinSilenceDuration float64
 	OverlapSeconds     float64
 	TopN               int
+	// Keyframes sampled per clip by visual scorers, and how they combine
+	FrameSamples     int
+	FrameAggregation Aggregation
+	// Format, size and quality of those keyframes (zero = JPEG, best quality)
+	FrameOptions ffmpeg.FrameOptions
+	// How candidate boundaries are chosen: scene, silence, hybrid or window
+	CandidateStrategy CandidateStrategy
+	// Window length for StrategyWindow (0 = halfway between min and max)
+	WindowLength time.Duration
+	// Longest a single clip may take to score (0 = no limit); it doesn't
+	// change results, so it's left out of cache keys
+	ScoreTimeout time.Duration `json:"-"`
+	// Clips scored concurrently (<1 = one at a time); also left out of
+	// cache keys
+	Workers int `json:"-"`
 }
 
 func DefaultDetectorConfig() DetectorConfig {
@@ -30,6 +50,10 @@ func DefaultDetectorConfig() DetectorConfig {
 		MinSilenceDuration: 1.0,
 		OverlapSeconds:     2.0,
 		TopN:               10,
+		FrameSamples:       DefaultFrameSamples,
+		FrameAggregation:   AggregateMean,
+		CandidateStrategy:  StrategyScene,
+		Workers:            1,
 	}
 }
 
@@ -40,6 +64,12 @@ type ClipDetector struct {
 	scorer    Scorer
 	extractor *FeatureExtractor
 	config    DetectorConfig
+	cache     *AnalysisCache
+	onEvent   func(DetectEvent)
+	// eventMu serializes onEvent calls from scoring workers
+	eventMu sync.Mutex
+	// scoresMu guards the cache entry's Scores while workers score
+	scoresMu sync.Mutex
 }
 
 // NewClipDetector creates a detector with a custom scorer
@@ -62,79 +92,73 @@ func NewDefaultClipDetector(logger zerolog.Logger, exec *ffmpeg.Executor, cfg De
 func (d *ClipDetector) Detect(ctx context.Context, videoPath string) ([]*clips.Clip, error) {
 	d.logger.Info().Str("video", videoPath).Msg("starting clip detection")
 
-	// Step 1: Probe video
-	info, err := d.ffmpeg.ProbeVideo(ctx, videoPath)
-	if err != nil {
-		return nil, fmt.Errorf("probe failed: %w", err)
-	}
-
-	// Step 2: Detect scene changes
-	scenes, err := d.ffmpeg.DetectScenes(ctx, videoPath, d.config.SceneThreshold)
-	if err != nil {
-		return nil, fmt.Errorf("scene detection failed: %w", err)
+	// Share probes and keyframes across scorers for this run
+	rc := runCacheFrom(ctx)
+	if rc == nil {
+		rc = NewRunCache(d.logger)
+		defer rc.Close()
+		ctx = WithRunCache(ctx, rc)
 	}
 
-	// Step 3: Detect silence periods
-	silences, err := d.ffmpeg.DetectSilence(ctx, videoPath,
-		d.config.SilenceThreshold, d.config.MinSilenceDuration)
+	// Step 1: Probe video
+	info, err := rc.ProbeVideo(ctx, d.ffmpeg, videoPath)
 	if err != nil {
-		return nil, fmt.Errorf("silence detection failed: %w", err)
+		return nil, fmt.Errorf("probe failed: %w", err)
 	}
 
-	// Step 4: Analyze volume
-	volumeStats, err := d.ffmpeg.AnalyzeVolume(ctx, videoPath)
-	if err != nil {
-		return nil, fmt.Errorf("volume analysis failed: %w", err)
+	// Steps 2-4: Scene, silence and volume analysis (cached when enabled)
+	key, entry, cached := d.loadCache(videoPath)
+	if !cached {
+		entry, err = d.analyzeMedia(ctx, videoPath)
+		if err != nil {
+			return nil, err
+		}
 	}
+	scenes, silences, volumeStats := entry.Scenes, entry.Silences, entry.Volume
+	motion := entry.Motion
+	d.emit(DetectEvent{Stage: StageScenesDetected, Count: len(scenes)})
 
 	// Step 5: Generate candidate clips
 	candidates := d.generateCandidates(scenes, silences, info.Duration)
+	d.logger.Debug().
+		Str("strategy", string(d.config.CandidateStrategy)).
+		Int("candidates", len(candidates)).
+		Msg("candidates generated")
+	d.emit(DetectEvent{Stage: StageCandidates, Count: len(candidates)})
 
 	// Step 6: Score each candidate using the Scorer interface
-	scoredClips := make([]*clips.Clip, 0, len(candidates))
+	candidateClips := make([]*clips.Clip, len(candidates))
+	features := make([]ClipFeatures, len(candidates))
 	for i, candidate := range candidates {
-		features := d.extractFeatures(candidate, scenes, silences, volumeStats)
+		features[i] = d.extractFeatures(candidate, scenes, silences, motion, volumeStats)
 
-		clip := &clips.Clip{
+		candidateClips[i] = &clips.Clip{
 			ID:        fmt.Sprintf("clip_%d", i),
 			Start:     candidate.Start,
 			End:       candidate.End,
 			Duration:  candidate.End - candidate.Start,
 			SourceURL: videoPath,
 			Metadata: map[string]interface{}{
-				"scene_changes":  features.SceneChangeCount,
-				"silence_ratio":  features.SilenceRatio,
-				"peak_volume":    features.PeakVolume,
-				"mean_volume":    features.MeanVolume,
-				"audio_dynamics": features.AudioDynamics,
+				"scene_changes":    features[i].SceneChangeCount,
+				"silence_ratio":    features[i].SilenceRatio,
+				"peak_volume":      features[i].PeakVolume,
+				"mean_volume":      features[i].MeanVolume,
+				"audio_dynamics":   features[i].AudioDynamics,
+				"motion_intensity": features[i].MotionIntensity,
+				"cut_strength":     cutStrength(candidate.Start, entry.Cuts),
 			},
 		}
+	}
 
-		// Use the scorer interface
-		score, err := d.scorer.Score(ctx, clip)
-		if err != nil {
-			d.logger.Warn().Err(err).Str("clip_id", clip.ID).Msg("scoring failed, using 0")
-			score = 0.0
-		}
-		clip.Score = score
-
-		// Safe logging of optional clip_score metadata
-		var clipScoreVal float64
-		if v, ok := clip.Metadata["clip_score"]; ok {
-			if f, ok2 := v.(float64); ok2 {
-				clipScoreVal = f
-			}
-		}
-
-		d.logger.Info().
-			Str("clip", clip.ID).
-			Float64("score_total", clip.Score).
-			Float64("score_clip", clipScoreVal).
-			Msg("ranked clip")
+	d.prepareScores(ctx, candidateClips, candidates, entry)
 
-		scoredClips = append(scoredClips, clip)
+	scoredClips := d.scoreAll(ctx, candidateClips, candidates, features, entry)
+	if err := ctx.Err(); err != nil {
+		return d.cancelled(key, entry, scoredClips, len(candidates), err)
 	}
 
+	d.saveCache(key, entry)
+
 	// Step 7: Sort and return top N
 	topClips := d.rankAndFilter(scoredClips)
 
@@ -146,77 +170,211 @@ func (d *ClipDetector) Detect(ctx context.Context, videoPath string) ([]*clips.C
 	return topClips, nil
 }
 
-// Close releases scorer resources
-func (d *ClipDetector) Close() error {
-	return d.scorer.Close()
+// scoreAll scores candidates on up to Workers goroutines and returns the
+// scored clips in candidate order. Once ctx is done no more clips start,
+// and clips whose scoring was interrupted are left out.
+func (d *ClipDetector) scoreAll(ctx context.Context, list []*clips.Clip, segments []candidateSegment, features []ClipFeatures, entry *cacheEntry) []*clips.Clip {
+	workers := d.config.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	done := make([]bool, len(list))
+	var g errgroup.Group
+	g.SetLimit(workers)
+
+	for i := range list {
+		if ctx.Err() != nil {
+			break
+		}
+		i := i
+		g.Go(func() error {
+			if ctx.Err() != nil {
+				return nil
+			}
+			clip := list[i]
+
+			// Use the scorer interface (or a cached result)
+			clip.Score = d.scoreClip(ctx, clip, segments[i], entry)
+			if ctx.Err() != nil {
+				// Interrupted rather than scored
+				return nil
+			}
+			done[i] = true
+
+			// clip_score is only set by the CLIP scorer; absent means 0
+			clipScoreVal, _ := clip.MetaFloat("clip_score")
+
+			d.logger.Info().
+				Str("clip", clip.ID).
+				Float64("score_total", clip.Score).
+				Float64("score_clip", clipScoreVal).
+				Msg("ranked clip")
+			d.emit(DetectEvent{Stage: StageClipScored, Index: i, Total: len(list), Clip: clip, Features: &features[i]})
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	scored := make([]*clips.Clip, 0, len(list))
+	for i, clip := range list {
+		if done[i] {
+			scored = append(scored, clip)
+		}
+	}
+	return scored
 }
 
-// candidateSegment represents a potential clip
-type candidateSegment struct {
-	Start time.Duration
-	End   time.Duration
+// cancelled stops detection early. Scores so far are cached so a rerun
+// picks up where this one stopped, and the best of them are returned along
+// with an error wrapping the context's.
+func (d *ClipDetector) cancelled(key cacheKey, entry *cacheEntry, scored []*clips.Clip, total int, err error) ([]*clips.Clip, error) {
+	d.saveCache(key, entry)
+
+	d.logger.Warn().
+		Int("scored", len(scored)).
+		Int("candidates", total).
+		Msg("clip detection cancelled")
+
+	return d.rankAndFilter(scored), fmt.Errorf("detection stopped after scoring %d of %d clips: %w", len(scored), total, err)
 }
 
-// generateCandidates creates candidate clips from scene boundaries
-func (d *ClipDetector) generateCandidates(scenes []time.Duration, silences []ffmpeg.SilenceSegment, totalDuration time.Duration) []candidateSegment {
-	var candidates []candidateSegment
+// saveCache writes entry when caching is enabled
+func (d *ClipDetector) saveCache(key cacheKey, entry *cacheEntry) {
+	if d.cache == nil {
+		return
+	}
+	if err := d.cache.save(key, entry); err != nil {
+		d.logger.Warn().Err(err).Msg("failed to write analysis cache")
+	}
+}
 
-	// Start from beginning
-	lastBoundary := time.Duration(0)
+// analyzeMedia runs the ffmpeg scene, silence and volume passes
+func (d *ClipDetector) analyzeMedia(ctx context.Context, videoPath string) (*cacheEntry, error) {
+	cuts, err := d.ffmpeg.DetectSceneChanges(ctx, videoPath, d.config.SceneThreshold)
+	if err != nil {
+		return nil, fmt.Errorf("scene detection failed: %w", err)
+	}
 
-	for _, sceneTime := range scenes {
-		// Check if segment is long enough
-		if sceneTime-lastBoundary >= d.config.MinClipLength {
-			candidates = append(candidates, candidateSegment{
-				Start: lastBoundary,
-				End:   sceneTime,
-			})
+	silences, err := d.ffmpeg.DetectSilence(ctx, videoPath,
+		d.config.SilenceThreshold, d.config.MinSilenceDuration)
+	if err != nil {
+		return nil, fmt.Errorf("silence detection failed: %w", err)
+	}
+
+	volumeStats, err := d.ffmpeg.AnalyzeVolume(ctx, videoPath)
+	if err != nil {
+		return nil, fmt.Errorf("volume analysis failed: %w", err)
+	}
+
+	// Motion is optional: without it we fall back to scene-change density
+	motion, err := d.ffmpeg.AnalyzeMotion(ctx, videoPath)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
 		}
-		lastBoundary = sceneTime
+		d.logger.Warn().Err(err).Msg("motion analysis failed; estimating motion from scene changes")
 	}
 
-	// Add final segment
-	if totalDuration-lastBoundary >= d.config.MinClipLength {
-		candidates = append(candidates, candidateSegment{
-			Start: lastBoundary,
-			End:   totalDuration,
-		})
+	return &cacheEntry{
+		Scenes:   ffmpeg.SceneTimes(cuts),
+		Cuts:     cuts,
+		Silences: silences,
+		Volume:   volumeStats,
+		Motion:   motion,
+		Scores:   make(map[string]cachedScore),
+	}, nil
+}
+
+// prepareScores lets a batching scorer score every uncached candidate in
+// one go. Failures are logged; scoreClip then scores clips individually.
+func (d *ClipDetector) prepareScores(ctx context.Context, list []*clips.Clip, segments []candidateSegment, entry *cacheEntry) {
+	preparer, ok := d.scorer.(BatchPreparer)
+	if !ok {
+		return
+	}
+
+	var uncached []*clips.Clip
+	for i, clip := range list {
+		if _, ok := entry.Scores[segmentKey(segments[i])]; !ok {
+			uncached = append(uncached, clip)
+		}
+	}
+	if len(uncached) == 0 {
+		return
 	}
 
-	// Merge adjacent short segments
-	return d.mergeShortSegments(candidates)
+	if err := preparer.Prepare(ctx, uncached); err != nil && ctx.Err() == nil {
+		d.logger.Warn().Err(err).Str("scorer", d.scorer.Name()).Msg("batch scoring failed; scoring clips individually")
+	}
 }
 
-func (d *ClipDetector) mergeShortSegments(segments []candidateSegment) []candidateSegment {
-	merged := make([]candidateSegment, 0)
-
-	for i := 0; i < len(segments); i++ {
-		current := segments[i]
-
-		// If too long, split it
-		if current.End-current.Start > d.config.MaxClipLength {
-			// Split into smaller chunks
-			splitPoints := int((current.End - current.Start) / d.config.MaxClipLength)
-			chunkSize := (current.End - current.Start) / time.Duration(splitPoints+1)
-
-			for j := 0; j <= splitPoints; j++ {
-				start := current.Start + time.Duration(j)*chunkSize
-				end := start + chunkSize
-				if end > current.End {
-					end = current.End
-				}
-				merged = append(merged, candidateSegment{Start: start, End: end})
-			}
-		} else {
-			merged = append(merged, current)
+// scoreClip scores a clip, reusing and recording cached scores
+func (d *ClipDetector) scoreClip(ctx context.Context, clip *clips.Clip, segment candidateSegment, entry *cacheEntry) float64 {
+	segKey := segmentKey(segment)
+	d.scoresMu.Lock()
+	cached, ok := entry.Scores[segKey]
+	d.scoresMu.Unlock()
+	if ok {
+		for k, v := range cached.Metadata {
+			clip.SetMeta(k, v)
 		}
+		return cached.Score
+	}
+
+	before := make(map[string]bool, len(clip.Metadata))
+	for k := range clip.Metadata {
+		before[k] = true
 	}
 
-	return merged
+	scoreCtx := ctx
+	if d.config.ScoreTimeout > 0 {
+		var cancel context.CancelFunc
+		scoreCtx, cancel = context.WithTimeout(ctx, d.config.ScoreTimeout)
+		defer cancel()
+	}
+
+	score, err := d.scorer.Score(scoreCtx, clip)
+	partial := errors.Is(err, ErrPartialScore)
+	if err != nil && !partial {
+		if ctx.Err() != nil {
+			// The whole detection was cancelled; Detect reports it
+			return 0.0
+		}
+		d.logger.Warn().Err(err).
+			Str("clip_id", clip.ID).
+			Str("scorer", d.scorer.Name()).
+			Msg("scoring failed, using 0")
+		// Don't cache failures; they may be transient
+		return 0.0
+	}
+
+	if partial {
+		// Usable, but some scorers failed; don't cache that either
+		return score
+	}
+
+	// Remember metadata the scorer attached so cache hits restore it
+	added := make(map[string]interface{})
+	for k, v := range clip.Metadata {
+		if !before[k] {
+			added[k] = v
+		}
+	}
+	d.scoresMu.Lock()
+	entry.Scores[segKey] = cachedScore{Score: score, Metadata: added}
+	d.scoresMu.Unlock()
+
+	return score
+}
+
+// Close releases scorer resources
+func (d *ClipDetector) Close() error {
+	return d.scorer.Close()
 }
 
 // extractFeatures calculates features for a clip candidate
-func (d *ClipDetector) extractFeatures(segment candidateSegment, scenes []time.Duration, silences []ffmpeg.SilenceSegment, volumeStats *ffmpeg.VolumeStats) ClipFeatures {
+func (d *ClipDetector) extractFeatures(segment candidateSegment, scenes []time.Duration, silences []ffmpeg.SilenceSegment, motion []ffmpeg.MotionSample, volumeStats *ffmpeg.VolumeStats) ClipFeatures {
 	// Count scene changes in this segment
 	sceneCount := 0
 	for _, scene := range scenes {
@@ -231,8 +389,17 @@ func (d *ClipDetector) extractFeatures(segment candidateSegment, scenes []time.D
 		silStart := time.Duration(silence.Start * float64(time.Second))
 		silEnd := time.Duration(silence.End * float64(time.Second))
 
-		if silStart >= segment.Start && silEnd <= segment.End {
-			silenceDuration += silEnd - silStart
+		// Count only the portion of the silence inside the segment
+		overlapStart := silStart
+		if segment.Start > overlapStart {
+			overlapStart = segment.Start
+		}
+		overlapEnd := silEnd
+		if segment.End < overlapEnd {
+			overlapEnd = segment.End
+		}
+		if overlapEnd > overlapStart {
+			silenceDuration += overlapEnd - overlapStart
 		}
 	}
 
@@ -246,27 +413,70 @@ func (d *ClipDetector) extractFeatures(segment candidateSegment, scenes []time.D
 		Duration:         clipDuration,
 		SceneChangeCount: sceneCount,
 		SilenceRatio:     silenceRatio,
+		MotionIntensity:  motionIntensity(segment, motion, sceneCount),
 		MeanVolume:       volumeStats.MeanVolume,
 		PeakVolume:       volumeStats.MaxVolume,
 		AudioDynamics:    volumeStats.MaxVolume - volumeStats.MeanVolume,
 	}
 }
 
-// rankAndFilter sorts clips by score and returns top N
-func (d *ClipDetector) rankAndFilter(clips []*clips.Clip) []*clips.Clip {
+// motionIntensity returns the segment's motion normalized to 0-1. It averages
+// the frame-difference samples inside the segment, falling back to scene
+// changes per second when no samples are available.
+func motionIntensity(segment candidateSegment, motion []ffmpeg.MotionSample, sceneCount int) float64 {
+	var sum float64
+	var n int
+	for _, sample := range motion {
+		if sample.Time >= segment.Start && sample.Time < segment.End {
+			sum += sample.YDiff
+			n++
+		}
+	}
+
+	if n > 0 {
+		// YDIF above ~20 is already very busy footage
+		return math.Min(1.0, (sum/float64(n))/20.0)
+	}
+
+	seconds := (segment.End - segment.Start).Seconds()
+	if seconds <= 0 {
+		return 0
+	}
+	// One cut every two seconds counts as maximal motion
+	return math.Min(1.0, float64(sceneCount)/seconds/0.5)
+}
+
+// rankAndFilter sorts clips by score and returns the top N, suppressing
+// clips that overlap a higher-scoring clip by more than OverlapSeconds
+func (d *ClipDetector) rankAndFilter(candidates []*clips.Clip) []*clips.Clip {
 	// Sort by score descending
-	for i := 0; i < len(clips); i++ {
-		for j := i + 1; j < len(clips); j++ {
-			if clips[j].Score > clips[i].Score {
-				clips[i], clips[j] = clips[j], clips[i]
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+
+	// Non-maximum suppression: greedily keep the best non-overlapping clips
+	maxOverlap := time.Duration(d.config.OverlapSeconds * float64(time.Second))
+	accepted := make([]*clips.Clip, 0, len(candidates))
+
+	for _, clip := range candidates {
+		if d.config.TopN > 0 && len(accepted) >= d.config.TopN {
+			break
+		}
+
+		suppressed := false
+		for _, kept := range accepted {
+			if clip.OverlapDuration(kept) > maxOverlap {
+				suppressed = true
+				break
 			}
 		}
-	}
 
-	// Return top N
-	if len(clips) > d.config.TopN {
-		return clips[:d.config.TopN]
+		if suppressed {
+			d.logger.Debug().Str("clip", clip.ID).Msg("suppressed overlapping clip")
+			continue
+		}
+		accepted = append(accepted, clip)
 	}
 
-	return clips
+	return accepted
 }
@@ -0,0 +1,566 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/keagan/slopcannon/internal/clips"
+	"github.com/keagan/slopcannon/internal/ffmpeg"
+	"github.com/rs/zerolog"
+)
+
+func testDetector(cfg DetectorConfig) *ClipDetector {
+	return NewClipDetector(zerolog.Nop(), nil, NewHeuristicScorer(), cfg)
+}
+
+func scoredClip(id string, start, end time.Duration, score float64) *clips.Clip {
+	return &clips.Clip{ID: id, Start: start, End: end, Duration: end - start, Score: score}
+}
+
+func TestRankAndFilterSuppressesOverlaps(t *testing.T) {
+	cfg := DefaultDetectorConfig()
+	cfg.OverlapSeconds = 2
+	cfg.TopN = 3
+	d := testDetector(cfg)
+
+	ranked := d.rankAndFilter([]*clips.Clip{
+		scoredClip("a", 0, 30*time.Second, 0.9),
+		scoredClip("a_dup", 5*time.Second, 35*time.Second, 0.85), // overlaps a by 25s
+		scoredClip("b", 29*time.Second, 60*time.Second, 0.8),     // overlaps a by 1s
+		scoredClip("c", 60*time.Second, 90*time.Second, 0.5),
+		scoredClip("d", 90*time.Second, 120*time.Second, 0.4),
+	})
+
+	var ids []string
+	for _, c := range ranked {
+		ids = append(ids, c.ID)
+	}
+
+	want := []string{"a", "b", "c"}
+	if len(ids) != len(want) {
+		t.Fatalf("expected %v, got %v", want, ids)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, ids)
+		}
+	}
+}
+
+func TestMotionIntensity(t *testing.T) {
+	seg := candidateSegment{Start: 0, End: 10 * time.Second}
+
+	samples := []ffmpeg.MotionSample{
+		{Time: 1 * time.Second, YDiff: 5},
+		{Time: 2 * time.Second, YDiff: 15},
+		{Time: 20 * time.Second, YDiff: 200}, // outside the segment
+	}
+	if got := motionIntensity(seg, samples, 0); got != 0.5 {
+		t.Errorf("expected 0.5 from frame differences, got %v", got)
+	}
+
+	// Without samples, 2 cuts in 10s = 0.2 cuts/s = 0.4 of maximal motion
+	if got := motionIntensity(seg, nil, 2); got < 0.399 || got > 0.401 {
+		t.Errorf("expected 0.4 from scene changes, got %v", got)
+	}
+}
+
+func TestExtractFeaturesPartialSilence(t *testing.T) {
+	d := testDetector(DefaultDetectorConfig())
+	seg := candidateSegment{Start: 10 * time.Second, End: 20 * time.Second}
+
+	silences := []ffmpeg.SilenceSegment{
+		{Start: 8, End: 12, Duration: 4},  // straddles the start: 2s inside
+		{Start: 14, End: 15, Duration: 1}, // fully inside: 1s
+		{Start: 19, End: 25, Duration: 6}, // straddles the end: 1s inside
+		{Start: 30, End: 31, Duration: 1}, // outside
+	}
+
+	features := d.extractFeatures(seg, nil, silences, nil, &ffmpeg.VolumeStats{})
+	if features.SilenceRatio < 0.399 || features.SilenceRatio > 0.401 {
+		t.Errorf("expected silence ratio 0.4, got %v", features.SilenceRatio)
+	}
+}
+
+func TestGenerateCandidatesStrategies(t *testing.T) {
+	scenes := []time.Duration{40 * time.Second}
+	silences := []ffmpeg.SilenceSegment{
+		{Start: 10, End: 12, Duration: 2},
+		{Start: 25, End: 26, Duration: 1},
+	}
+	total := 60 * time.Second
+
+	tests := []struct {
+		strategy CandidateStrategy
+		want     []candidateSegment
+	}{
+		{StrategyScene, []candidateSegment{
+			{0, 40 * time.Second},
+			{40 * time.Second, 60 * time.Second},
+		}},
+		{StrategySilence, []candidateSegment{
+			{0, 10 * time.Second},
+			{12 * time.Second, 25 * time.Second},
+			{26 * time.Second, 60 * time.Second},
+		}},
+		{StrategyHybrid, []candidateSegment{
+			{0, 10 * time.Second},
+			{12 * time.Second, 25 * time.Second},
+			{26 * time.Second, 40 * time.Second},
+			{40 * time.Second, 60 * time.Second},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			cfg := DefaultDetectorConfig()
+			cfg.MinClipLength = 5 * time.Second
+			cfg.MaxClipLength = 60 * time.Second
+			cfg.CandidateStrategy = tt.strategy
+
+			got := testDetector(cfg).generateCandidates(scenes, silences, total)
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %d candidates, got %v", len(tt.want), got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("candidate %d: expected %v, got %v", i, tt.want[i], got[i])
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateCandidatesSceneKeepsSilentSegments(t *testing.T) {
+	// The 20s-22s scene lies entirely inside a silence
+	scenes := []time.Duration{20 * time.Second, 22 * time.Second}
+	silences := []ffmpeg.SilenceSegment{{Start: 19, End: 23, Duration: 4}}
+
+	cfg := DefaultDetectorConfig()
+	cfg.MinClipLength = time.Second
+	cfg.MaxClipLength = 60 * time.Second
+	cfg.CandidateStrategy = StrategyScene
+
+	got := testDetector(cfg).generateCandidates(scenes, silences, 60*time.Second)
+	want := []candidateSegment{
+		{0, 20 * time.Second},
+		{20 * time.Second, 22 * time.Second},
+		{22 * time.Second, 60 * time.Second},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("candidate %d: expected %v, got %v", i, want[i], got[i])
+		}
+	}
+}
+
+// seededDetector returns a detector over a fake 120s video with four scene
+// segments, plus a context whose caches mean Detect never runs ffmpeg
+func seededDetector(t *testing.T, scorer Scorer, cfg DetectorConfig) (*ClipDetector, context.Context, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	video := filepath.Join(dir, "video.mp4")
+	if err := os.WriteFile(video, []byte("fake video"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	rc := NewRunCache(zerolog.Nop())
+	t.Cleanup(func() { rc.Close() })
+	rc.probes[video] = &ffmpeg.VideoInfo{Duration: 120 * time.Second}
+
+	d := NewClipDetector(zerolog.Nop(), nil, scorer, cfg)
+	cache := NewAnalysisCache(zerolog.Nop(), filepath.Join(dir, "cache"))
+	d.SetCache(cache)
+
+	key, err := cache.keyFor(video, cfg, scorerFingerprint(d.scorer))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.save(key, &cacheEntry{
+		Scenes: []time.Duration{30 * time.Second, 60 * time.Second, 90 * time.Second},
+		Volume: &ffmpeg.VolumeStats{MeanVolume: -20, MaxVolume: -3},
+		Scores: map[string]cachedScore{},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	return d, WithRunCache(context.Background(), rc), video
+}
+
+func TestDetectHeuristicOnly(t *testing.T) {
+	d, ctx, video := seededDetector(t, NewHeuristicScorer(), DefaultDetectorConfig())
+
+	detected, err := d.Detect(ctx, video)
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+	if len(detected) == 0 {
+		t.Fatal("expected clips from heuristic scoring")
+	}
+	for _, c := range detected {
+		if _, ok := c.Metadata["clip_score"]; ok {
+			t.Errorf("clip %s: unexpected clip_score without a CLIP scorer", c.ID)
+		}
+	}
+}
+
+// cancellingScorer cancels detection once it has scored after clips
+type cancellingScorer struct {
+	HeuristicScorer
+	after  int
+	scored int
+	cancel context.CancelFunc
+}
+
+func (c *cancellingScorer) Score(ctx context.Context, clip *clips.Clip) (float64, error) {
+	c.scored++
+	if c.scored == c.after {
+		c.cancel()
+	}
+	return 0.5, nil
+}
+
+func TestDetectStopsWhenCancelled(t *testing.T) {
+	scorer := &cancellingScorer{HeuristicScorer: *NewHeuristicScorer(), after: 2}
+	d, ctx, video := seededDetector(t, scorer, DefaultDetectorConfig())
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	scorer.cancel = cancel
+
+	detected, err := d.Detect(ctx, video)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected a cancellation error, got %v", err)
+	}
+	if scorer.scored != 2 {
+		t.Errorf("expected scoring to stop after 2 of 4 clips, scored %d", scorer.scored)
+	}
+	if len(detected) != 1 {
+		t.Errorf("expected the clip scored before cancelling, got %v", detected)
+	}
+}
+
+// slowScorer blocks until its context is done
+type slowScorer struct{ HeuristicScorer }
+
+func (*slowScorer) Score(ctx context.Context, clip *clips.Clip) (float64, error) {
+	<-ctx.Done()
+	return 0, ctx.Err()
+}
+
+func TestDetectScoreTimeout(t *testing.T) {
+	cfg := DefaultDetectorConfig()
+	cfg.ScoreTimeout = 10 * time.Millisecond
+	d, ctx, video := seededDetector(t, &slowScorer{}, cfg)
+
+	detected, err := d.Detect(ctx, video)
+	if err != nil {
+		t.Fatalf("a per-clip timeout should not fail detection: %v", err)
+	}
+	for _, c := range detected {
+		if c.Score != 0 {
+			t.Errorf("clip %s: expected a timed-out clip to score 0, got %v", c.ID, c.Score)
+		}
+	}
+}
+
+// concurrentScorer scores by start time and records how many clips it was
+// scoring at once
+type concurrentScorer struct {
+	HeuristicScorer
+	mu       sync.Mutex
+	inFlight int
+	peak     int
+}
+
+func (c *concurrentScorer) Score(ctx context.Context, clip *clips.Clip) (float64, error) {
+	c.mu.Lock()
+	c.inFlight++
+	if c.inFlight > c.peak {
+		c.peak = c.inFlight
+	}
+	c.mu.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+
+	c.mu.Lock()
+	c.inFlight--
+	c.mu.Unlock()
+	return 1 - clip.Start.Seconds()/1000, nil
+}
+
+func TestDetectParallelMatchesSequential(t *testing.T) {
+	detect := func(workers int) ([]*clips.Clip, *concurrentScorer) {
+		cfg := DefaultDetectorConfig()
+		cfg.Workers = workers
+		scorer := &concurrentScorer{HeuristicScorer: *NewHeuristicScorer()}
+		d, ctx, video := seededDetector(t, scorer, cfg)
+
+		var indexes []int
+		d.SetEventFunc(func(e DetectEvent) {
+			if e.Stage == StageClipScored {
+				indexes = append(indexes, e.Index)
+			}
+		})
+
+		detected, err := d.Detect(ctx, video)
+		if err != nil {
+			t.Fatalf("Detect with %d workers failed: %v", workers, err)
+		}
+		sort.Ints(indexes)
+		for i, idx := range indexes {
+			if idx != i {
+				t.Fatalf("expected one scored event per candidate, got indexes %v", indexes)
+			}
+		}
+		return detected, scorer
+	}
+
+	sequential, _ := detect(1)
+	parallel, scorer := detect(4)
+
+	if scorer.peak < 2 {
+		t.Errorf("expected clips to be scored concurrently, peak was %d", scorer.peak)
+	}
+	if len(parallel) != len(sequential) {
+		t.Fatalf("expected %d clips, got %d", len(sequential), len(parallel))
+	}
+	for i := range sequential {
+		s, p := sequential[i], parallel[i]
+		if s.Start != p.Start || s.End != p.End || s.Score != p.Score {
+			t.Errorf("clip %d: parallel %v-%v (%v) differs from sequential %v-%v (%v)",
+				i, p.Start, p.End, p.Score, s.Start, s.End, s.Score)
+		}
+	}
+}
+
+// preparingScorer records the clips it was asked to prepare
+type preparingScorer struct {
+	HeuristicScorer
+	prepared []*clips.Clip
+}
+
+func (p *preparingScorer) Prepare(ctx context.Context, list []*clips.Clip) error {
+	p.prepared = append(p.prepared, list...)
+	return nil
+}
+
+func TestPrepareScoresSkipsCached(t *testing.T) {
+	scorer := &preparingScorer{HeuristicScorer: *NewHeuristicScorer()}
+	d := NewClipDetector(zerolog.Nop(), nil, scorer, DefaultDetectorConfig())
+
+	segments := []candidateSegment{
+		{Start: 0, End: 30 * time.Second},
+		{Start: 30 * time.Second, End: 60 * time.Second},
+	}
+	list := []*clips.Clip{{ID: "cached"}, {ID: "fresh"}}
+	entry := &cacheEntry{Scores: map[string]cachedScore{segmentKey(segments[0]): {Score: 0.5}}}
+
+	d.prepareScores(context.Background(), list, segments, entry)
+
+	if len(scorer.prepared) != 1 || scorer.prepared[0].ID != "fresh" {
+		t.Errorf("expected only the uncached clip to be prepared, got %v", scorer.prepared)
+	}
+}
+
+func TestScoreClipDoesNotCachePartialScores(t *testing.T) {
+	composite := NewCompositeScorer([]Scorer{fixedScorer{"heuristic", 0.7}, failingScorer{}}, nil)
+	d := NewClipDetector(zerolog.Nop(), nil, composite, DefaultDetectorConfig())
+
+	segment := candidateSegment{Start: 0, End: 30 * time.Second}
+	entry := &cacheEntry{Scores: map[string]cachedScore{}}
+	if score := d.scoreClip(context.Background(), &clips.Clip{ID: "c"}, segment, entry); score != 0.7 {
+		t.Errorf("expected the remaining scorer's 0.7, got %v", score)
+	}
+	if len(entry.Scores) != 0 {
+		t.Errorf("partial scores must not be cached, got %v", entry.Scores)
+	}
+}
+
+func TestCutStrength(t *testing.T) {
+	cuts := []ffmpeg.SceneChange{
+		{Time: 30 * time.Second, Score: 0.9},
+		{Time: 60 * time.Second, Score: 0.4},
+	}
+
+	if got := cutStrength(30*time.Second, cuts); got != 0.9 {
+		t.Errorf("strong cut: got %g, want 0.9", got)
+	}
+	if got := cutStrength(60*time.Second, cuts); got != 0.4 {
+		t.Errorf("weak cut: got %g, want 0.4", got)
+	}
+	if got := cutStrength(0, cuts); got != 0 {
+		t.Errorf("video start: got %g, want 0", got)
+	}
+
+	// Equal clips rank higher when they open on the stronger cut
+	h := NewHeuristicScorer()
+	score := func(strength float64) float64 {
+		s, _ := h.Score(context.Background(), &clips.Clip{
+			Duration: 30 * time.Second,
+			Metadata: map[string]interface{}{"cut_strength": strength},
+		})
+		return s
+	}
+	if score(0.9) <= score(0.4) {
+		t.Errorf("expected a strong cut to outscore a weak one")
+	}
+}
+
+func TestMergeShortSegmentsSplitsAtMaxClipLength(t *testing.T) {
+	cfg := DefaultDetectorConfig()
+	cfg.MaxClipLength = 30 * time.Second
+	segments := []candidateSegment{{0, 100 * time.Second}, {100 * time.Second, 120 * time.Second}}
+
+	got := testDetector(cfg).mergeShortSegments(segments)
+	if len(got) != 5 {
+		t.Fatalf("expected 4 chunks plus the short segment, got %v", got)
+	}
+	for _, seg := range got {
+		if seg.End-seg.Start > cfg.MaxClipLength {
+			t.Errorf("segment %v exceeds max clip length", seg)
+		}
+	}
+
+	cfg.MaxClipLength = 0
+	if got := testDetector(cfg).mergeShortSegments(segments); len(got) != 2 {
+		t.Errorf("expected no splitting without a max, got %v", got)
+	}
+}
+
+func TestSplitLongSegmentsKeepsMinClipLength(t *testing.T) {
+	cfg := DefaultDetectorConfig()
+	cfg.MinClipLength = 10 * time.Second
+	cfg.MaxClipLength = 15 * time.Second
+	d := testDetector(cfg)
+
+	// Two 9s halves would break the minimum; the run stays whole
+	got := d.splitLongSegments([]candidateSegment{{0, 18 * time.Second}})
+	if len(got) != 1 || got[0] != (candidateSegment{0, 18 * time.Second}) {
+		t.Errorf("expected 18s to stay in one piece, got %v", got)
+	}
+
+	// 32s: three chunks would be 10.67s each and fit both limits
+	got = d.splitLongSegments([]candidateSegment{{0, 32 * time.Second}})
+	if len(got) != 3 {
+		t.Fatalf("expected 3 chunks, got %v", got)
+	}
+	for _, seg := range got {
+		if seg.End-seg.Start < cfg.MinClipLength || seg.End-seg.Start > cfg.MaxClipLength {
+			t.Errorf("chunk %v is outside [min, max]", seg)
+		}
+	}
+}
+
+func TestGenerateCandidatesMergesRapidCuts(t *testing.T) {
+	// A cut every 2s: each segment alone is far below the minimum
+	var scenes []time.Duration
+	for at := 2 * time.Second; at < 95*time.Second; at += 2 * time.Second {
+		scenes = append(scenes, at)
+	}
+
+	cfg := DefaultDetectorConfig()
+	cfg.MinClipLength = 10 * time.Second
+	cfg.MaxClipLength = 60 * time.Second
+
+	got := testDetector(cfg).generateCandidates(scenes, nil, 95*time.Second)
+	if len(got) == 0 {
+		t.Fatal("expected candidates")
+	}
+
+	for i, seg := range got {
+		length := seg.End - seg.Start
+		if length < cfg.MinClipLength && i != len(got)-1 {
+			t.Errorf("candidate %d (%v) is shorter than the minimum", i, seg)
+		}
+		if length > cfg.MaxClipLength {
+			t.Errorf("candidate %d (%v) is longer than the maximum", i, seg)
+		}
+		if i > 0 && seg.Start != got[i-1].End {
+			t.Errorf("candidate %d (%v) leaves a gap after %v", i, seg, got[i-1])
+		}
+	}
+	if got[0].Start != 0 || got[len(got)-1].End != 95*time.Second {
+		t.Errorf("expected candidates to cover the whole input, got %v", got)
+	}
+}
+
+func TestMergeShortSegmentsStopsAtGaps(t *testing.T) {
+	cfg := DefaultDetectorConfig()
+	cfg.MinClipLength = 10 * time.Second
+	cfg.MaxClipLength = 60 * time.Second
+
+	got := testDetector(cfg).mergeShortSegments([]candidateSegment{
+		{0, 4 * time.Second},
+		{4 * time.Second, 8 * time.Second}, // run ends short at the gap: dropped
+		{12 * time.Second, 18 * time.Second},
+		{18 * time.Second, 30 * time.Second},
+		{30 * time.Second, 33 * time.Second}, // trailing remainder joins 12s-30s
+	})
+
+	want := []candidateSegment{{12 * time.Second, 33 * time.Second}}
+	if len(got) != len(want) || got[0] != want[0] {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestWindowCandidatesCoverInput(t *testing.T) {
+	cfg := DefaultDetectorConfig()
+	cfg.MinClipLength = 10 * time.Second
+	cfg.MaxClipLength = 30 * time.Second
+	cfg.OverlapSeconds = 2
+	cfg.WindowLength = 20 * time.Second
+	cfg.CandidateStrategy = StrategyWindow
+
+	// Scene cuts are ignored by the window strategy
+	got := testDetector(cfg).generateCandidates([]time.Duration{5 * time.Second}, nil, 60*time.Second)
+	want := []candidateSegment{
+		{0, 20 * time.Second},
+		{18 * time.Second, 38 * time.Second},
+		{36 * time.Second, 56 * time.Second},
+		{40 * time.Second, 60 * time.Second},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d windows, got %v", len(want), got)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("window %d: expected %v, got %v", i, want[i], got[i])
+		}
+	}
+
+	// Shorter than one window: a single candidate spanning the input
+	short := testDetector(cfg).windowCandidates(12 * time.Second)
+	if len(short) != 1 || short[0] != (candidateSegment{0, 12 * time.Second}) {
+		t.Errorf("expected one window over a short input, got %v", short)
+	}
+}
+
+func TestWindowLengthDefaultsAndClamps(t *testing.T) {
+	cfg := DefaultDetectorConfig()
+	cfg.MinClipLength = 10 * time.Second
+	cfg.MaxClipLength = 30 * time.Second
+
+	if got := testDetector(cfg).windowLength(); got != 20*time.Second {
+		t.Errorf("expected the midpoint 20s by default, got %v", got)
+	}
+
+	cfg.WindowLength = 90 * time.Second
+	if got := testDetector(cfg).windowLength(); got != 30*time.Second {
+		t.Errorf("expected clamp to MaxClipLength, got %v", got)
+	}
+
+	cfg.WindowLength = time.Second
+	if got := testDetector(cfg).windowLength(); got != 10*time.Second {
+		t.Errorf("expected clamp to MinClipLength, got %v", got)
+	}
+}
@@ -0,0 +1,194 @@
+package ai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+// ModelSets lists the files each downloadable model set consists of.
+// The whisper set is resolved per model name by ModelFiles.
+var ModelSets = []string{"clip", "whisper"}
+
+// DownloadProgress reports bytes written for one file (total is -1 when
+// the server doesn't send a length)
+type DownloadProgress func(name string, done, total int64)
+
+// ModelFiles returns the files making up a model set
+func ModelFiles(set, whisperModel string) ([]string, error) {
+	switch set {
+	case "clip":
+		return []string{"clip_image_encoder.onnx", "virality_head.onnx"}, nil
+	case "whisper":
+		if whisperModel == "" {
+			whisperModel = "base"
+		}
+		return []string{fmt.Sprintf("ggml-%s.bin", whisperModel)}, nil
+	default:
+		return nil, fmt.Errorf("unknown model set %q (one of: %s)", set, strings.Join(ModelSets, ", "))
+	}
+}
+
+// ModelDir returns the directory models live in; modelPath may name the
+// directory itself or a model file inside it
+func ModelDir(modelPath string) string {
+	if ext := filepath.Ext(modelPath); ext == ".onnx" || ext == ".bin" {
+		return filepath.Dir(modelPath)
+	}
+	return modelPath
+}
+
+// ModelDownloader fetches model files from baseURL. Every file must be
+// published with a "<file>.sha256" checksum next to it.
+type ModelDownloader struct {
+	logger   zerolog.Logger
+	client   *http.Client
+	baseURL  string
+	progress DownloadProgress
+}
+
+// NewModelDownloader creates a downloader; progress may be nil
+func NewModelDownloader(logger zerolog.Logger, baseURL string, progress DownloadProgress) *ModelDownloader {
+	return &ModelDownloader{
+		logger:   logger.With().Str("component", "model-download").Logger(),
+		client:   http.DefaultClient,
+		baseURL:  strings.TrimSuffix(baseURL, "/"),
+		progress: progress,
+	}
+}
+
+// Download fetches name into dir unless a file with the published checksum
+// is already there. It reports whether anything was downloaded.
+func (d *ModelDownloader) Download(ctx context.Context, name, dir string) (bool, error) {
+	if d.baseURL == "" {
+		return false, fmt.Errorf("no model download URL configured (ai.model_base_url)")
+	}
+
+	want, err := d.fetchChecksum(ctx, name)
+	if err != nil {
+		return false, err
+	}
+
+	dest := filepath.Join(dir, name)
+	if have, err := fileChecksum(dest); err == nil && have == want {
+		d.logger.Info().Str("model", dest).Msg("model already present; skipping")
+		return false, nil
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return false, fmt.Errorf("failed to create model dir: %w", err)
+	}
+
+	d.logger.Info().Str("model", name).Str("dest", dest).Msg("downloading model")
+
+	resp, err := d.get(ctx, d.baseURL+"/"+name)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	// Write to a temp file so an interrupted download never looks complete
+	tmp, err := os.CreateTemp(dir, name+".*.part")
+	if err != nil {
+		return false, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	hash := sha256.New()
+	counter := &progressWriter{name: name, total: resp.ContentLength, report: d.progress}
+	_, err = io.Copy(io.MultiWriter(tmp, hash, counter), resp.Body)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to download %s: %w", name, err)
+	}
+
+	if got := hex.EncodeToString(hash.Sum(nil)); got != want {
+		return false, fmt.Errorf("checksum mismatch for %s: expected %s, got %s", name, want, got)
+	}
+
+	if err := os.Rename(tmp.Name(), dest); err != nil {
+		return false, fmt.Errorf("failed to install %s: %w", name, err)
+	}
+
+	d.logger.Info().Str("model", dest).Int64("bytes", counter.done).Msg("model downloaded")
+	return true, nil
+}
+
+// fetchChecksum reads the published SHA-256 of name ("<hex>  <file>" or
+// just the hex digest)
+func (d *ModelDownloader) fetchChecksum(ctx context.Context, name string) (string, error) {
+	resp, err := d.get(ctx, d.baseURL+"/"+name+".sha256")
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return "", fmt.Errorf("failed to read checksum for %s: %w", name, err)
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 || len(fields[0]) != sha256.Size*2 {
+		return "", fmt.Errorf("invalid checksum file for %s", name)
+	}
+	return strings.ToLower(fields[0]), nil
+}
+
+func (d *ModelDownloader) get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url %s: %w", url, err)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return resp, nil
+}
+
+// fileChecksum returns the hex SHA-256 of a file
+func fileChecksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// progressWriter counts bytes and forwards them to a DownloadProgress
+type progressWriter struct {
+	name   string
+	done   int64
+	total  int64
+	report DownloadProgress
+}
+
+func (w *progressWriter) Write(p []byte) (int, error) {
+	w.done += int64(len(p))
+	if w.report != nil {
+		w.report(w.name, w.done, w.total)
+	}
+	return len(p), nil
+}
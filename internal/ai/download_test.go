@@ -0,0 +1,82 @@
+package ai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestModelDownloader(t *testing.T) {
+	model := []byte("fake onnx weights")
+	sum := sha256.Sum256(model)
+	checksum := hex.EncodeToString(sum[:])
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/virality_head.onnx":
+			requests++
+			w.Write(model)
+		case "/virality_head.onnx.sha256":
+			w.Write([]byte(checksum + "  virality_head.onnx\n"))
+		case "/bad.onnx":
+			w.Write([]byte("corrupted"))
+		case "/bad.onnx.sha256":
+			w.Write([]byte(checksum))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	var progressed int64
+	d := NewModelDownloader(zerolog.Nop(), server.URL, func(name string, done, total int64) {
+		progressed = done
+	})
+	d.client = server.Client()
+
+	downloaded, err := d.Download(context.Background(), "virality_head.onnx", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !downloaded || progressed != int64(len(model)) {
+		t.Errorf("expected download with progress, got downloaded=%v progress=%d", downloaded, progressed)
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, "virality_head.onnx")); err != nil || string(data) != string(model) {
+		t.Errorf("unexpected model contents %q (%v)", data, err)
+	}
+
+	// A matching file is left alone
+	downloaded, err = d.Download(context.Background(), "virality_head.onnx", dir)
+	if err != nil || downloaded || requests != 1 {
+		t.Errorf("expected skip, got downloaded=%v requests=%d err=%v", downloaded, requests, err)
+	}
+
+	if _, err := d.Download(context.Background(), "bad.onnx", dir); err == nil {
+		t.Error("expected checksum mismatch")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "bad.onnx")); !os.IsNotExist(err) {
+		t.Error("expected corrupted download to be discarded")
+	}
+}
+
+func TestModelFiles(t *testing.T) {
+	files, err := ModelFiles("whisper", "small")
+	if err != nil || len(files) != 1 || files[0] != "ggml-small.bin" {
+		t.Errorf("unexpected whisper files %v (%v)", files, err)
+	}
+	if _, err := ModelFiles("gpt", ""); err == nil {
+		t.Error("expected error for unknown set")
+	}
+	if got := ModelDir("./models/clip-vit-base.onnx"); got != "models" {
+		t.Errorf("expected models dir, got %q", got)
+	}
+}
@@ -0,0 +1,41 @@
+package ai
+
+import "github.com/keagan/slopcannon/internal/clips"
+
+// DetectStage identifies a clip detection milestone
+type DetectStage string
+
+const (
+	StageScenesDetected DetectStage = "scenes_detected"
+	StageCandidates     DetectStage = "candidates"
+	StageClipScored     DetectStage = "clip_scored"
+)
+
+// DetectEvent reports detection progress. Count is the number of scene
+// changes or candidates; Index, Total, Clip and the Features it was scored
+// on are set for StageClipScored.
+type DetectEvent struct {
+	Stage    DetectStage
+	Count    int
+	Index    int
+	Total    int
+	Clip     *clips.Clip
+	Features *ClipFeatures
+}
+
+// SetEventFunc registers a callback for detection milestones. It is called
+// synchronously from Detect, so it should return quickly. Calls never
+// overlap, but with several workers StageClipScored events arrive in
+// completion order rather than by Index.
+func (d *ClipDetector) SetEventFunc(fn func(DetectEvent)) {
+	d.onEvent = fn
+}
+
+// emit sends an event to the registered callback, if any
+func (d *ClipDetector) emit(ev DetectEvent) {
+	if d.onEvent != nil {
+		d.eventMu.Lock()
+		defer d.eventMu.Unlock()
+		d.onEvent(ev)
+	}
+}
@@ -0,0 +1,380 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"math"
+	"os"
+	"sort"
+
+	"github.com/keagan/slopcannon/internal/clips"
+	"github.com/keagan/slopcannon/internal/ffmpeg"
+	"github.com/nfnt/resize"
+	"github.com/rs/zerolog"
+	ort "github.com/yalue/onnxruntime_go"
+)
+
+// FaceModelFile is the face detector's default file inside the model dir
+const FaceModelFile = "face_detector.onnx"
+
+const (
+	// Conventional UltraFace export names and input size
+	faceInput        = "input"
+	faceScoresOutput = "scores"
+	faceBoxesOutput  = "boxes"
+	defaultFaceW     = 320
+	defaultFaceH     = 240
+
+	// faceConfidence is the minimum detection score counted as a face
+	faceConfidence = 0.7
+	// faceNMSThreshold merges detections overlapping by more than this IoU
+	faceNMSThreshold = 0.3
+	// neutralFaceScore is returned when no face model is loaded
+	neutralFaceScore = 0.5
+)
+
+// faceBox is one detection in normalized (0-1) frame coordinates
+type faceBox struct {
+	X1, Y1, X2, Y2 float64
+	Confidence     float64
+}
+
+// FaceScorer rewards keyframes with prominent, centered faces using a
+// lightweight ONNX face detector (UltraFace-style: scores [1,N,2] and
+// boxes [1,N,4]). Without a model it is disabled and scores every clip
+// neutrally. It is safe for concurrent use: each call allocates its own
+// tensors and ONNX Runtime allows parallel runs on one session.
+type FaceScorer struct {
+	logger  zerolog.Logger
+	ffmpeg  *ffmpeg.Executor
+	session *ort.DynamicAdvancedSession
+	model   string
+
+	input         string
+	width, height int64
+
+	samples     int
+	aggregation Aggregation
+	frameOpts   ffmpeg.FrameOptions
+}
+
+// NewFaceScorer loads a face detection model. A missing model file is not
+// an error: the returned scorer is disabled and returns a neutral score.
+func NewFaceScorer(logger zerolog.Logger, ffmpegExec *ffmpeg.Executor, modelPath string, provider ExecutionProvider) (*FaceScorer, error) {
+	f := &FaceScorer{
+		logger:      logger.With().Str("scorer", "face").Logger(),
+		ffmpeg:      ffmpegExec,
+		model:       modelPath,
+		samples:     DefaultFrameSamples,
+		aggregation: AggregateMean,
+	}
+
+	if _, err := os.Stat(modelPath); os.IsNotExist(err) {
+		f.logger.Warn().Str("model", modelPath).Msg("face model not found; face scoring disabled")
+		return f, nil
+	}
+
+	if err := initONNX(); err != nil {
+		return nil, err
+	}
+
+	inputs, outputs, err := ort.GetInputOutputInfo(modelPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read face model inputs/outputs: %w", err)
+	}
+	input, err := pickTensor(inputs, faceInput, "face model input")
+	if err != nil {
+		return nil, err
+	}
+	if len(outputs) < 2 {
+		return nil, fmt.Errorf("face model has %d outputs; expected scores and boxes", len(outputs))
+	}
+	scores, boxes := faceOutputNames(outputs)
+
+	f.input = input.Name
+	f.width, f.height = defaultFaceW, defaultFaceH
+	if dims := input.Dimensions; len(dims) == 4 {
+		if dims[2] > 0 {
+			f.height = dims[2]
+		}
+		if dims[3] > 0 {
+			f.width = dims[3]
+		}
+	} else if len(dims) != 0 {
+		return nil, fmt.Errorf("face model input %q has shape %s; expected [1,3,H,W]", input.Name, dims)
+	}
+
+	options, err := newSessionOptions(logger, provider)
+	if err != nil {
+		return nil, err
+	}
+	if options != nil {
+		defer options.Destroy()
+	}
+
+	f.session, err = ort.NewDynamicAdvancedSession(modelPath, []string{input.Name}, []string{scores, boxes}, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create face model session: %w", err)
+	}
+
+	logger.Info().
+		Str("model", modelPath).
+		Str("provider", string(provider)).
+		Int64("width", f.width).
+		Int64("height", f.height).
+		Msg("face detection model loaded")
+
+	return f, nil
+}
+
+// faceOutputNames returns the scores and boxes outputs, by their
+// conventional names or else in declaration order
+func faceOutputNames(outputs []ort.InputOutputInfo) (string, string) {
+	scores, boxes := outputs[0].Name, outputs[1].Name
+	for _, out := range outputs {
+		switch out.Name {
+		case faceScoresOutput:
+			scores = out.Name
+		case faceBoxesOutput:
+			boxes = out.Name
+		}
+	}
+	return scores, boxes
+}
+
+// SetFrameSampling sets how many keyframes are scored and how they combine
+func (f *FaceScorer) SetFrameSampling(samples int, agg Aggregation) {
+	f.samples = samples
+	f.aggregation = agg
+}
+
+// SetFrameOptions sets the format, size and quality of extracted keyframes
+func (f *FaceScorer) SetFrameOptions(opts ffmpeg.FrameOptions) {
+	f.frameOpts = opts
+}
+
+// Enabled reports whether a face model is loaded
+func (f *FaceScorer) Enabled() bool {
+	return f.session != nil
+}
+
+// Name returns "face"
+func (f *FaceScorer) Name() string {
+	return "face"
+}
+
+// Fingerprint identifies the model file; a disabled scorer's is "disabled"
+func (f *FaceScorer) Fingerprint() string {
+	if !f.Enabled() {
+		return "disabled"
+	}
+	return fileFingerprint(f.model)
+}
+
+// Score detects faces on sampled keyframes and rates their count, size and
+// centering
+func (f *FaceScorer) Score(ctx context.Context, clip *clips.Clip) (float64, error) {
+	if !f.Enabled() {
+		return neutralFaceScore, nil
+	}
+
+	frames, cleanup, err := extractKeyframes(ctx, f.ffmpeg, clip, f.samples, f.frameOpts, "face_keyframe")
+	defer cleanup()
+	if err != nil {
+		f.logger.Warn().Err(err).Str("clip", clip.ID).Msg("keyframe extraction failed")
+		return 0.0, err
+	}
+
+	scores := make([]float64, 0, len(frames))
+	maxFaces := 0
+	for _, frame := range frames {
+		faces, err := f.detect(frame)
+		if err != nil {
+			return 0.0, err
+		}
+		if len(faces) > maxFaces {
+			maxFaces = len(faces)
+		}
+		scores = append(scores, faceScore(faces))
+	}
+
+	score := aggregateScores(scores, f.aggregation)
+	clip.SetMeta("face_score", score)
+	clip.SetMeta("face_count", maxFaces)
+
+	f.logger.Debug().
+		Str("clip", clip.ID).
+		Int("faces", maxFaces).
+		Float64("face_score", score).
+		Msg("face scoring complete")
+
+	return score, nil
+}
+
+// detect runs the model on one keyframe and returns the faces found
+func (f *FaceScorer) detect(path string) ([]faceBox, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	img, _, err := image.Decode(file)
+	file.Close()
+	if err != nil {
+		return nil, fmt.Errorf("image preprocessing failed: %w", err)
+	}
+
+	pixels := make([]float32, 3*f.width*f.height)
+	facePixelValues(img, uint(f.width), uint(f.height), pixels)
+	inputTensor, err := ort.NewTensor(ort.NewShape(1, 3, f.height, f.width), pixels)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create face input tensor: %w", err)
+	}
+	defer inputTensor.Destroy()
+
+	// Detection counts depend on the model's anchors; let ONNX Runtime
+	// allocate the outputs
+	outputs := []ort.ArbitraryTensor{nil, nil}
+	if err := f.session.Run([]ort.ArbitraryTensor{inputTensor}, outputs); err != nil {
+		return nil, fmt.Errorf("face detection inference failed: %w", err)
+	}
+	defer func() {
+		for _, out := range outputs {
+			if out != nil {
+				out.Destroy()
+			}
+		}
+	}()
+
+	scoreTensor, ok := outputs[0].(*ort.Tensor[float32])
+	if !ok {
+		return nil, fmt.Errorf("face model scores are not float32")
+	}
+	boxTensor, ok := outputs[1].(*ort.Tensor[float32])
+	if !ok {
+		return nil, fmt.Errorf("face model boxes are not float32")
+	}
+
+	faces, err := decodeFaces(scoreTensor.GetData(), boxTensor.GetData(), faceConfidence)
+	if err != nil {
+		return nil, err
+	}
+	return suppressFaces(faces, faceNMSThreshold), nil
+}
+
+// facePixelValues resizes img to width x height and writes UltraFace-style
+// normalized values ((v-127)/128, channel-major) to dst
+func facePixelValues(img image.Image, width, height uint, dst []float32) {
+	resized := resize.Resize(width, height, img, resize.Bilinear)
+
+	bounds := resized.Bounds()
+	plane := int(width * height)
+	idx := 0
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b, _ := resized.At(x, y).RGBA()
+			dst[idx] = (float32(r>>8) - 127) / 128
+			dst[plane+idx] = (float32(g>>8) - 127) / 128
+			dst[2*plane+idx] = (float32(b>>8) - 127) / 128
+			idx++
+		}
+	}
+}
+
+// decodeFaces pairs [N,2] background/face scores with [N,4] boxes and keeps
+// detections at or above threshold
+func decodeFaces(scores, boxes []float32, threshold float64) ([]faceBox, error) {
+	if len(scores)%2 != 0 || len(boxes)%4 != 0 || len(scores)/2 != len(boxes)/4 {
+		return nil, fmt.Errorf("face model returned %d scores for %d boxes", len(scores), len(boxes))
+	}
+
+	var faces []faceBox
+	for i := 0; i < len(scores)/2; i++ {
+		confidence := float64(scores[2*i+1])
+		if confidence < threshold {
+			continue
+		}
+		b := boxes[4*i : 4*i+4]
+		faces = append(faces, faceBox{
+			X1:         clamp01(float64(b[0])),
+			Y1:         clamp01(float64(b[1])),
+			X2:         clamp01(float64(b[2])),
+			Y2:         clamp01(float64(b[3])),
+			Confidence: confidence,
+		})
+	}
+	return faces, nil
+}
+
+// suppressFaces keeps the most confident of any detections overlapping by
+// more than iouThreshold
+func suppressFaces(faces []faceBox, iouThreshold float64) []faceBox {
+	sorted := append([]faceBox(nil), faces...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Confidence > sorted[j].Confidence })
+
+	var kept []faceBox
+	for _, face := range sorted {
+		overlaps := false
+		for _, k := range kept {
+			if face.iou(k) > iouThreshold {
+				overlaps = true
+				break
+			}
+		}
+		if !overlaps {
+			kept = append(kept, face)
+		}
+	}
+	return kept
+}
+
+// faceScore rates one frame's faces: a few faces beat a crowd, and the
+// largest face scores higher the bigger and more centered it is
+func faceScore(faces []faceBox) float64 {
+	if len(faces) == 0 {
+		return 0.0
+	}
+
+	largest := faces[0]
+	for _, face := range faces[1:] {
+		if face.area() > largest.area() {
+			largest = face
+		}
+	}
+
+	count := math.Max(0.5, 1.0-0.1*float64(len(faces)-1))
+	// A face whose side spans 40% of the frame counts as full size
+	size := math.Min(1.0, math.Sqrt(largest.area())/0.4)
+	cx, cy := (largest.X1+largest.X2)/2, (largest.Y1+largest.Y2)/2
+	centering := 1.0 - math.Hypot(cx-0.5, cy-0.5)/math.Sqrt(0.5)
+
+	return clamp01(0.3*count + 0.4*size + 0.3*centering)
+}
+
+func (b faceBox) area() float64 {
+	return math.Max(0, b.X2-b.X1) * math.Max(0, b.Y2-b.Y1)
+}
+
+func (b faceBox) iou(o faceBox) float64 {
+	inter := faceBox{
+		X1: math.Max(b.X1, o.X1), Y1: math.Max(b.Y1, o.Y1),
+		X2: math.Min(b.X2, o.X2), Y2: math.Min(b.Y2, o.Y2),
+	}.area()
+	union := b.area() + o.area() - inter
+	if union <= 0 {
+		return 0
+	}
+	return inter / union
+}
+
+func clamp01(v float64) float64 {
+	return math.Max(0.0, math.Min(1.0, v))
+}
+
+// Close releases the model session
+func (f *FaceScorer) Close() error {
+	if f.session != nil {
+		return f.session.Destroy()
+	}
+	return nil
+}
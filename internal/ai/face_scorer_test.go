@@ -0,0 +1,89 @@
+package ai
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/keagan/slopcannon/internal/clips"
+	"github.com/rs/zerolog"
+)
+
+func TestFaceScorerMissingModelIsNeutral(t *testing.T) {
+	scorer, err := NewFaceScorer(zerolog.Nop(), nil, filepath.Join(t.TempDir(), FaceModelFile), ProviderCPU)
+	if err != nil {
+		t.Fatalf("missing model should not be an error: %v", err)
+	}
+	if scorer.Enabled() {
+		t.Fatal("expected scorer to be disabled without a model")
+	}
+
+	score, err := scorer.Score(context.Background(), &clips.Clip{ID: "c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if score != neutralFaceScore {
+		t.Errorf("expected neutral score %v, got %v", neutralFaceScore, score)
+	}
+}
+
+func TestDecodeFacesAndSuppress(t *testing.T) {
+	scores := []float32{
+		0.1, 0.9, // face
+		0.05, 0.95, // same face, more confident
+		0.8, 0.2, // background
+		0.2, 0.8, // second face
+	}
+	boxes := []float32{
+		0.40, 0.30, 0.60, 0.60,
+		0.41, 0.31, 0.61, 0.61,
+		0.00, 0.00, 1.00, 1.00,
+		0.05, 0.05, 0.15, 0.20,
+	}
+
+	faces, err := decodeFaces(scores, boxes, faceConfidence)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(faces) != 3 {
+		t.Fatalf("expected 3 detections above threshold, got %v", faces)
+	}
+
+	kept := suppressFaces(faces, faceNMSThreshold)
+	if len(kept) != 2 {
+		t.Fatalf("expected overlapping detections to merge into 2 faces, got %v", kept)
+	}
+	if kept[0].Confidence < 0.94 {
+		t.Errorf("expected the most confident duplicate to survive, got %v", kept[0])
+	}
+
+	if _, err := decodeFaces(scores[:6], boxes, faceConfidence); err == nil {
+		t.Error("expected an error for mismatched scores and boxes")
+	}
+}
+
+func TestFaceScore(t *testing.T) {
+	centered := faceBox{X1: 0.3, Y1: 0.3, X2: 0.7, Y2: 0.7}
+	corner := faceBox{X1: 0.0, Y1: 0.0, X2: 0.1, Y2: 0.1}
+
+	if got := faceScore(nil); got != 0 {
+		t.Errorf("expected 0 without faces, got %v", got)
+	}
+
+	big := faceScore([]faceBox{centered})
+	small := faceScore([]faceBox{corner})
+	if big <= small {
+		t.Errorf("expected a large centered face (%v) to beat a small corner one (%v)", big, small)
+	}
+	if big < 0.95 {
+		t.Errorf("expected a large centered face to score near 1, got %v", big)
+	}
+
+	crowd := make([]faceBox, 8)
+	for i := range crowd {
+		crowd[i] = centered
+	}
+	if got := faceScore(crowd); got >= big {
+		t.Errorf("expected a crowd (%v) to score below a single face (%v)", got, big)
+	}
+}
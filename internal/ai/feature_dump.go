@@ -0,0 +1,138 @@
+package ai
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/keagan/slopcannon/internal/clips"
+)
+
+// FeatureRecord is one candidate's heuristic features and final score, as
+// written by a FeatureDump
+type FeatureRecord struct {
+	Source           string  `json:"source"`
+	ClipID           string  `json:"clip_id"`
+	Start            float64 `json:"start"`
+	End              float64 `json:"end"`
+	Duration         float64 `json:"duration"`
+	SceneChangeCount int     `json:"scene_change_count"`
+	SilenceRatio     float64 `json:"silence_ratio"`
+	MeanVolume       float64 `json:"mean_volume"`
+	PeakVolume       float64 `json:"peak_volume"`
+	MotionIntensity  float64 `json:"motion_intensity"`
+	AudioDynamics    float64 `json:"audio_dynamics"`
+	CutStrength      float64 `json:"cut_strength"`
+	Score            float64 `json:"score"`
+}
+
+// featureColumns is the CSV header, in FeatureRecord field order
+var featureColumns = []string{
+	"source", "clip_id", "start", "end", "duration",
+	"scene_change_count", "silence_ratio", "mean_volume", "peak_volume",
+	"motion_intensity", "audio_dynamics", "cut_strength", "score",
+}
+
+// NewFeatureRecord combines a scored clip with the features it was scored on.
+// Times are in seconds.
+func NewFeatureRecord(clip *clips.Clip, features ClipFeatures) FeatureRecord {
+	cut, _ := clip.MetaFloat("cut_strength")
+	return FeatureRecord{
+		Source:           clip.SourceURL,
+		ClipID:           clip.ID,
+		Start:            clip.Start.Seconds(),
+		End:              clip.End.Seconds(),
+		Duration:         features.Duration.Seconds(),
+		SceneChangeCount: features.SceneChangeCount,
+		SilenceRatio:     features.SilenceRatio,
+		MeanVolume:       features.MeanVolume,
+		PeakVolume:       features.PeakVolume,
+		MotionIntensity:  features.MotionIntensity,
+		AudioDynamics:    features.AudioDynamics,
+		CutStrength:      cut,
+		Score:            clip.Score,
+	}
+}
+
+// csvRow formats r in featureColumns order
+func (r FeatureRecord) csvRow() []string {
+	f := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+	return []string{
+		r.Source, r.ClipID, f(r.Start), f(r.End), f(r.Duration),
+		strconv.Itoa(r.SceneChangeCount), f(r.SilenceRatio), f(r.MeanVolume), f(r.PeakVolume),
+		f(r.MotionIntensity), f(r.AudioDynamics), f(r.CutStrength), f(r.Score),
+	}
+}
+
+// FeatureDump writes FeatureRecords as CSV or JSON Lines, e.g. to build
+// training data for a virality model
+type FeatureDump struct {
+	closer io.Closer
+	csv    *csv.Writer
+	json   *json.Encoder
+}
+
+// CreateFeatureDump creates path and picks the format from its extension:
+// .csv, or .jsonl/.json for one JSON object per line
+func CreateFeatureDump(path string) (*FeatureDump, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext != ".csv" && ext != ".jsonl" && ext != ".json" {
+		return nil, fmt.Errorf("unsupported feature dump format %q (use .csv or .jsonl)", ext)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create feature dump: %w", err)
+	}
+
+	dump, err := NewFeatureDump(f, ext == ".csv")
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	dump.closer = f
+	return dump, nil
+}
+
+// NewFeatureDump writes records to w, as CSV with a header row when asCSV
+// is set and as JSON Lines otherwise
+func NewFeatureDump(w io.Writer, asCSV bool) (*FeatureDump, error) {
+	if !asCSV {
+		return &FeatureDump{json: json.NewEncoder(w)}, nil
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(featureColumns); err != nil {
+		return nil, fmt.Errorf("failed to write feature dump header: %w", err)
+	}
+	return &FeatureDump{csv: cw}, nil
+}
+
+// Write appends one record
+func (d *FeatureDump) Write(r FeatureRecord) error {
+	if d.csv != nil {
+		return d.csv.Write(r.csvRow())
+	}
+	return d.json.Encode(r)
+}
+
+// Close flushes buffered rows and closes the file, if CreateFeatureDump
+// opened one
+func (d *FeatureDump) Close() error {
+	var err error
+	if d.csv != nil {
+		d.csv.Flush()
+		err = d.csv.Error()
+	}
+	if d.closer != nil {
+		if cerr := d.closer.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
@@ -0,0 +1,119 @@
+package ai
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/keagan/slopcannon/internal/clips"
+)
+
+func dumpTestRecord() FeatureRecord {
+	clip := &clips.Clip{
+		ID:        "clip_3",
+		Start:     10 * time.Second,
+		End:       25 * time.Second,
+		SourceURL: "talk.mp4",
+		Score:     0.75,
+		Metadata:  map[string]interface{}{"cut_strength": 0.4},
+	}
+	return NewFeatureRecord(clip, ClipFeatures{
+		Duration:         15 * time.Second,
+		SceneChangeCount: 4,
+		SilenceRatio:     0.2,
+		MeanVolume:       -20,
+		PeakVolume:       -3,
+		MotionIntensity:  0.5,
+		AudioDynamics:    17,
+	})
+}
+
+func TestFeatureDumpCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "features.csv")
+	dump, err := CreateFeatureDump(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dump.Write(dumpTestRecord()); err != nil {
+		t.Fatal(err)
+	}
+	if err := dump.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected header and one row, got %v", rows)
+	}
+	if len(rows[1]) != len(featureColumns) {
+		t.Fatalf("expected %d columns, got %v", len(featureColumns), rows[1])
+	}
+	row := make(map[string]string)
+	for i, col := range rows[0] {
+		row[col] = rows[1][i]
+	}
+	if row["source"] != "talk.mp4" || row["start"] != "10" || row["scene_change_count"] != "4" ||
+		row["cut_strength"] != "0.4" || row["score"] != "0.75" {
+		t.Errorf("unexpected row: %v", row)
+	}
+}
+
+func TestFeatureDumpJSONL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "features.jsonl")
+	dump, err := CreateFeatureDump(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := dump.Write(dumpTestRecord()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := dump.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	lines := 0
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var got FeatureRecord
+		if err := json.Unmarshal(scanner.Bytes(), &got); err != nil {
+			t.Fatalf("line %d: %v", lines, err)
+		}
+		if got != dumpTestRecord() {
+			t.Errorf("line %d: got %+v", lines, got)
+		}
+		lines++
+	}
+	if lines != 2 {
+		t.Errorf("expected 2 lines, got %d", lines)
+	}
+}
+
+func TestCreateFeatureDumpRejectsUnknownExtension(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "features.xlsx")
+	if _, err := CreateFeatureDump(path); err == nil {
+		t.Fatal("expected an error for an unsupported extension")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("expected no file to be created")
+	}
+}
@@ -13,7 +13,7 @@ type ClipFeatures struct {
 	SilenceRatio     float64
 	MeanVolume       float64
 	PeakVolume       float64
-	MotionIntensity  float64 // derived from scene changes
+	MotionIntensity  float64 // 0-1, frame differences (or scene changes) per second
 	AudioDynamics    float64 // peak - mean volume
 }
 
@@ -0,0 +1,112 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/keagan/slopcannon/internal/clips"
+	"github.com/keagan/slopcannon/internal/ffmpeg"
+)
+
+// Aggregation combines per-frame scores into one clip score
+type Aggregation string
+
+const (
+	AggregateMean Aggregation = "mean"
+	AggregateMax  Aggregation = "max"
+)
+
+// DefaultFrameSamples is the number of keyframes visual scorers sample per clip
+const DefaultFrameSamples = 3
+
+// sampleTimestamps returns n timestamps evenly spaced inside the clip
+// (n=3 samples at 25%, 50% and 75%; n=1 at the midpoint)
+func sampleTimestamps(clip *clips.Clip, n int) []time.Duration {
+	if n < 1 {
+		n = 1
+	}
+
+	length := clip.End - clip.Start
+	times := make([]time.Duration, n)
+	for i := range times {
+		times[i] = clip.Start + length*time.Duration(i+1)/time.Duration(n+1)
+	}
+	return times
+}
+
+// extractKeyframes extracts n evenly spaced frames from the clip into temp
+// files. Frames that fail to extract are skipped; an error is returned only
+// if none succeed. The returned cleanup removes every extracted frame.
+// With a RunCache in ctx, frames are shared between scorers and owned by
+// the cache instead.
+func extractKeyframes(ctx context.Context, exec *ffmpeg.Executor, clip *clips.Clip, n int, opts ffmpeg.FrameOptions, prefix string) ([]string, func(), error) {
+	if rc := runCacheFrom(ctx); rc != nil {
+		return rc.keyframes(ctx, exec, clip, n, opts)
+	}
+
+	var paths []string
+	cleanup := func() {
+		for _, path := range paths {
+			_ = os.Remove(path)
+		}
+	}
+
+	var lastErr error
+	for i, ts := range sampleTimestamps(clip, n) {
+		// Don't start more extractions once cancelled
+		if err := ctx.Err(); err != nil {
+			cleanup()
+			return nil, func() {}, err
+		}
+
+		path := filepath.Join(os.TempDir(),
+			fmt.Sprintf("%s_%s_%d_%d%s", prefix, clip.ID, i, time.Now().UnixNano(), frameExt(opts)))
+
+		if err := exec.ExtractFrameOpts(ctx, clip.SourceURL, ts, path, opts); err != nil {
+			// Partial files may exist even on failure
+			_ = os.Remove(path)
+			lastErr = err
+			continue
+		}
+		paths = append(paths, path)
+	}
+
+	if len(paths) == 0 {
+		return nil, cleanup, fmt.Errorf("keyframe extraction failed: %w", lastErr)
+	}
+	return paths, cleanup, nil
+}
+
+// aggregateScores combines per-frame scores (mean by default)
+func aggregateScores(scores []float64, agg Aggregation) float64 {
+	if len(scores) == 0 {
+		return 0
+	}
+
+	if agg == AggregateMax {
+		best := scores[0]
+		for _, s := range scores[1:] {
+			if s > best {
+				best = s
+			}
+		}
+		return best
+	}
+
+	var sum float64
+	for _, s := range scores {
+		sum += s
+	}
+	return sum / float64(len(scores))
+}
+
+// frameExt is the file extension for keyframes extracted with opts
+func frameExt(opts ffmpeg.FrameOptions) string {
+	if opts.Format == "" {
+		return ffmpeg.FrameJPEG.Ext()
+	}
+	return opts.Format.Ext()
+}
@@ -0,0 +1,35 @@
+package ai
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keagan/slopcannon/internal/clips"
+)
+
+func TestSampleTimestamps(t *testing.T) {
+	clip := &clips.Clip{Start: 10 * time.Second, End: 50 * time.Second}
+
+	got := sampleTimestamps(clip, 3)
+	want := []time.Duration{20 * time.Second, 30 * time.Second, 40 * time.Second}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("sample %d: expected %v, got %v", i, want[i], got[i])
+		}
+	}
+
+	if mid := sampleTimestamps(clip, 1); mid[0] != 30*time.Second {
+		t.Errorf("single sample should be the midpoint, got %v", mid[0])
+	}
+}
+
+func TestAggregateScores(t *testing.T) {
+	scores := []float64{0.2, 0.8, 0.5}
+
+	if got := aggregateScores(scores, AggregateMean); got != 0.5 {
+		t.Errorf("mean: expected 0.5, got %v", got)
+	}
+	if got := aggregateScores(scores, AggregateMax); got != 0.8 {
+		t.Errorf("max: expected 0.8, got %v", got)
+	}
+}
@@ -0,0 +1,86 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/keagan/slopcannon/internal/clips"
+	"github.com/keagan/slopcannon/internal/subtitles"
+)
+
+// keywordSaturation is the weighted matches per second that scores 1.0
+// (roughly one strong hit every five seconds)
+const keywordSaturation = 0.2
+
+// KeywordScorer rewards clips whose transcript contains viral phrases. Its
+// transcript and keywords are read-only, so it is safe for concurrent use.
+type KeywordScorer struct {
+	transcript subtitles.Transcript
+	keywords   map[string]float64
+}
+
+// NewKeywordScorer creates a scorer over a transcript with per-keyword weights.
+// Keywords are matched case-insensitively as substrings.
+func NewKeywordScorer(transcript subtitles.Transcript, keywords map[string]float64) *KeywordScorer {
+	normalized := make(map[string]float64, len(keywords))
+	for keyword, weight := range keywords {
+		keyword = strings.ToLower(strings.TrimSpace(keyword))
+		if keyword != "" && weight > 0 {
+			normalized[keyword] = weight
+		}
+	}
+
+	return &KeywordScorer{
+		transcript: transcript,
+		keywords:   normalized,
+	}
+}
+
+// Name returns "keyword"
+func (k *KeywordScorer) Name() string {
+	return "keyword"
+}
+
+// Score rates weighted keyword matches per second within the clip window
+func (k *KeywordScorer) Score(ctx context.Context, clip *clips.Clip) (float64, error) {
+	seconds := clip.Duration.Seconds()
+	if seconds <= 0 || len(k.keywords) == 0 {
+		return 0.0, nil
+	}
+
+	var weighted float64
+	hits := 0
+	for _, seg := range k.transcript.Overlapping(clip.Start, clip.End) {
+		text := strings.ToLower(seg.Text)
+		for keyword, weight := range k.keywords {
+			n := strings.Count(text, keyword)
+			hits += n
+			weighted += float64(n) * weight
+		}
+	}
+
+	clip.SetMeta("keyword_hits", hits)
+
+	return math.Min(1.0, weighted/seconds/keywordSaturation), nil
+}
+
+// Fingerprint covers the keyword weights and a hash of the transcript
+func (k *KeywordScorer) Fingerprint() string {
+	parts := make([]string, 0, len(k.keywords))
+	for keyword, weight := range k.keywords {
+		parts = append(parts, fmt.Sprintf("%q:%g", keyword, weight))
+	}
+	sort.Strings(parts)
+
+	transcript, _ := json.Marshal(k.transcript)
+	return strings.Join(parts, ",") + "|transcript=" + shortHash(string(transcript))
+}
+
+// Close releases resources
+func (k *KeywordScorer) Close() error {
+	return nil
+}
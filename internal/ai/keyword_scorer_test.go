@@ -0,0 +1,46 @@
+package ai
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/keagan/slopcannon/internal/clips"
+	"github.com/keagan/slopcannon/internal/subtitles"
+)
+
+func TestKeywordScorer(t *testing.T) {
+	transcript := subtitles.Transcript{
+		{Start: 0, End: 4 * time.Second, Text: "so anyway, the meeting ran long"},
+		{Start: 4 * time.Second, End: 8 * time.Second, Text: "Wait for it... NO WAY haha"},
+		{Start: 20 * time.Second, End: 24 * time.Second, Text: "haha haha"},
+	}
+	scorer := NewKeywordScorer(transcript, map[string]float64{
+		"wait for it": 1.0,
+		"no way":      0.5,
+		"haha":        0.5,
+		"":            5.0,
+	})
+
+	quiet := &clips.Clip{Start: 0, End: 4 * time.Second, Duration: 4 * time.Second, Metadata: map[string]interface{}{}}
+	score, err := scorer.Score(context.Background(), quiet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if score != 0 {
+		t.Errorf("expected 0 for clip without keywords, got %v", score)
+	}
+
+	// 1.0 + 0.5 + 0.5 = 2 weighted hits over 20s = 0.1/s -> 0.5
+	hooky := &clips.Clip{Start: 0, End: 20 * time.Second, Duration: 20 * time.Second, Metadata: map[string]interface{}{}}
+	score, err = scorer.Score(context.Background(), hooky)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if score < 0.499 || score > 0.501 {
+		t.Errorf("expected 0.5, got %v", score)
+	}
+	if hits := hooky.Metadata["keyword_hits"]; hits != 3 {
+		t.Errorf("expected 3 keyword hits, got %v", hits)
+	}
+}
@@ -4,13 +4,12 @@ import (
 	"context"
 	"fmt"
 	"image"
+	"image/draw"
 	_ "image/jpeg"
 	_ "image/png"
 	"math"
 	"os"
-	"path/filepath"
 	"sync"
-	"time"
 
 	"github.com/keagan/slopcannon/internal/clips"
 	"github.com/keagan/slopcannon/internal/ffmpeg"
@@ -19,14 +18,27 @@ import (
 	ort "github.com/yalue/onnxruntime_go"
 )
 
-// CLIPScorer uses the sayantan47/clip-vit-b32-onnx model.
+// CLIPScorer uses the sayantan47/clip-vit-b32-onnx model. It is safe for
+// concurrent use: both sessions may run in parallel, and Prepare's results
+// are guarded by mu.
 type CLIPScorer struct {
-	logger     zerolog.Logger
-	ffmpeg     *ffmpeg.Executor
-	inputShape ort.Shape
+	logger zerolog.Logger
+	ffmpeg *ffmpeg.Executor
+	layout clipIO
 
 	encoderSession *ort.DynamicAdvancedSession
 	headSession    *ort.DynamicAdvancedSession
+	// models are the encoder and head paths, for Fingerprint
+	models [2]string
+
+	samples     int
+	aggregation Aggregation
+	frameOpts   ffmpeg.FrameOptions
+	batchSize   int
+
+	// Scores computed ahead of time by Prepare, consumed by Score
+	mu       sync.Mutex
+	prepared map[*clips.Clip]float64
 }
 
 var onnxInitOnce sync.Once
@@ -38,12 +50,35 @@ func init() {
 	ort.SetSharedLibraryPath("/usr/local/lib/libonnxruntime.1.22.2.dylib")
 }
 
+// initONNX initializes ONNX Runtime once per process
+func initONNX() error {
+	onnxInitOnce.Do(func() {
+		onnxInitErr = ort.InitializeEnvironment()
+	})
+	if onnxInitErr != nil {
+		return fmt.Errorf("failed to initialize ONNX runtime: %w", onnxInitErr)
+	}
+	return nil
+}
+
 // NewCLIPScorer creates a new CLIP-based scorer using image encoder + virality head.
 func NewCLIPScorer(
 	logger zerolog.Logger,
 	ffmpegExec *ffmpeg.Executor,
 	encoderModelPath string,
 	headModelPath string,
+) (*CLIPScorer, error) {
+	return NewCLIPScorerWithProvider(logger, ffmpegExec, encoderModelPath, headModelPath, ProviderCPU)
+}
+
+// NewCLIPScorerWithProvider creates a CLIP scorer whose sessions run on the
+// given execution provider (CPU when it can't be registered).
+func NewCLIPScorerWithProvider(
+	logger zerolog.Logger,
+	ffmpegExec *ffmpeg.Executor,
+	encoderModelPath string,
+	headModelPath string,
+	provider ExecutionProvider,
 ) (*CLIPScorer, error) {
 	if _, err := os.Stat(encoderModelPath); os.IsNotExist(err) {
 		return nil, fmt.Errorf("encoder model file not found: %s", encoderModelPath)
@@ -52,19 +87,28 @@ func NewCLIPScorer(
 		return nil, fmt.Errorf("head model file not found: %s", headModelPath)
 	}
 
-	// Initialize ONNX Runtime only once per process
-	onnxInitOnce.Do(func() {
-		onnxInitErr = ort.InitializeEnvironment()
-	})
-	if onnxInitErr != nil {
-		return nil, fmt.Errorf("failed to initialize ONNX runtime: %w", onnxInitErr)
+	if err := initONNX(); err != nil {
+		return nil, err
+	}
+
+	layout, err := loadCLIPIO(encoderModelPath, headModelPath)
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := newSessionOptions(logger, provider)
+	if err != nil {
+		return nil, err
+	}
+	if options != nil {
+		defer options.Destroy()
 	}
 
 	encoderSession, err := ort.NewDynamicAdvancedSession(
 		encoderModelPath,
-		[]string{"pixel_values"},
-		[]string{"image_embeds"},
-		nil,
+		[]string{layout.pixelInput},
+		[]string{layout.embedOutput},
+		options,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create CLIP image encoder session: %w", err)
@@ -72,9 +116,9 @@ func NewCLIPScorer(
 
 	headSession, err := ort.NewDynamicAdvancedSession(
 		headModelPath,
-		[]string{"image_embeds"},
-		[]string{"score_logits"}, // or "score"
-		nil,
+		[]string{layout.headInput},
+		[]string{layout.scoreOutput},
+		options,
 	)
 	if err != nil {
 		encoderSession.Destroy()
@@ -84,44 +128,124 @@ func NewCLIPScorer(
 	logger.Info().
 		Str("encoder_model", encoderModelPath).
 		Str("head_model", headModelPath).
+		Str("provider", string(provider)).
+		Int64("embed_dim", layout.embedDim).
+		Int64("image_size", layout.imageSize).
 		Msg("CLIP encoder + virality head models loaded")
 
 	return &CLIPScorer{
 		logger:         logger.With().Str("scorer", "clip").Logger(),
 		ffmpeg:         ffmpegExec,
-		inputShape:     ort.NewShape(1, 3, 224, 224),
+		layout:         layout,
 		encoderSession: encoderSession,
 		headSession:    headSession,
+		models:         [2]string{encoderModelPath, headModelPath},
+		samples:        DefaultFrameSamples,
+		aggregation:    AggregateMean,
+		batchSize:      DefaultBatchSize,
+		prepared:       make(map[*clips.Clip]float64),
 	}, nil
 }
 
-// Score runs CLIP image encoder + virality head on a keyframe.
+// SetFrameSampling sets how many keyframes are scored and how they combine
+func (c *CLIPScorer) SetFrameSampling(samples int, agg Aggregation) {
+	c.samples = samples
+	c.aggregation = agg
+}
+
+// SetFrameOptions sets the format, size and quality of extracted keyframes
+func (c *CLIPScorer) SetFrameOptions(opts ffmpeg.FrameOptions) {
+	c.frameOpts = opts
+}
+
+// Name returns "clip"
+func (c *CLIPScorer) Name() string {
+	return "clip"
+}
+
+// Fingerprint identifies the encoder and head model files
+func (c *CLIPScorer) Fingerprint() string {
+	return fileFingerprint(c.models[0]) + "," + fileFingerprint(c.models[1])
+}
+
+// Score runs CLIP image encoder + virality head on sampled keyframes.
+// Clips scored ahead of time by Prepare return their batched result.
 func (c *CLIPScorer) Score(ctx context.Context, clip *clips.Clip) (float64, error) {
-	// Extract keyframe from middle of clip
-	keyframeTime := clip.Start + (clip.Duration / 2)
-	keyframePath := filepath.Join(os.TempDir(),
-		fmt.Sprintf("clip_keyframe_%s_%d.jpg", clip.ID, time.Now().UnixNano()))
-	defer os.Remove(keyframePath)
+	if score, ok := c.takePrepared(clip); ok {
+		clip.SetMeta("clip_score", score)
+		return score, nil
+	}
 
-	if err := c.ffmpeg.ExtractFrame(ctx, clip.SourceURL, keyframeTime, keyframePath); err != nil {
+	frames, cleanup, err := extractKeyframes(ctx, c.ffmpeg, clip, c.samples, c.frameOpts, "clip_keyframe")
+	defer cleanup()
+	if err != nil {
 		c.logger.Warn().Err(err).Str("clip", clip.ID).Msg("keyframe extraction failed")
 		return 0.0, err
 	}
 
+	scores, err := c.scoreFrames(frames)
+	if err != nil {
+		return 0.0, err
+	}
+
+	score := aggregateScores(scores, c.aggregation)
+	clip.SetMeta("clip_score", score)
+
+	c.logger.Debug().
+		Str("clip", clip.ID).
+		Int("frames", len(frames)).
+		Float64("clip_score", score).
+		Msg("CLIP virality scoring complete")
+
+	return score, nil
+}
+
+// scoreFrames scores keyframes in batches of at most batchSize frames
+func (c *CLIPScorer) scoreFrames(paths []string) ([]float64, error) {
+	size := c.batchSize
+	if size < 1 || c.layout.fixedBatch {
+		size = 1
+	}
+
+	scores := make([]float64, 0, len(paths))
+	for start := 0; start < len(paths); start += size {
+		end := start + size
+		if end > len(paths) {
+			end = len(paths)
+		}
+		batch, err := c.runBatch(paths[start:end])
+		if err != nil {
+			return nil, err
+		}
+		scores = append(scores, batch...)
+	}
+	return scores, nil
+}
+
+// runBatch runs the encoder once over [N,3,S,S] and the head once over
+// [N,embedDim], returning a sigmoid score per frame
+func (c *CLIPScorer) runBatch(paths []string) ([]float64, error) {
+	n := int64(len(paths))
+	size := c.layout.imageSize
+	stride := 3 * size * size
+
 	// IMAGE -> pixel_values
-	pixelTensor, err := c.preprocessImage(keyframePath)
+	pixels := make([]float32, n*stride)
+	for i, path := range paths {
+		if err := loadPixelValues(path, uint(size), pixels[int64(i)*stride:(int64(i)+1)*stride]); err != nil {
+			return nil, fmt.Errorf("image preprocessing failed: %w", err)
+		}
+	}
+	pixelTensor, err := ort.NewTensor(ort.NewShape(n, 3, size, size), pixels)
 	if err != nil {
-		return 0.0, fmt.Errorf("image preprocessing failed: %w", err)
+		return nil, fmt.Errorf("failed to create pixel_values tensor: %w", err)
 	}
 	defer pixelTensor.Destroy()
 
 	// 1) Run image encoder: pixel_values -> image_embeds
-	// Match this to the actual dimension of your ONNX encoder output.
-	const embedDim = 512
-	embedShape := ort.NewShape(1, embedDim)
-	embedTensor, err := ort.NewEmptyTensor[float32](embedShape)
+	embedTensor, err := ort.NewEmptyTensor[float32](ort.NewShape(n, c.layout.embedDim))
 	if err != nil {
-		return 0.0, fmt.Errorf("failed to create image_embeds tensor: %w", err)
+		return nil, fmt.Errorf("failed to create image_embeds tensor: %w", err)
 	}
 	defer embedTensor.Destroy()
 
@@ -129,13 +253,17 @@ func (c *CLIPScorer) Score(ctx context.Context, clip *clips.Clip) (float64, erro
 		[]ort.ArbitraryTensor{pixelTensor},
 		[]ort.ArbitraryTensor{embedTensor},
 	); err != nil {
-		return 0.0, fmt.Errorf("CLIP image encoder inference failed: %w", err)
+		return nil, fmt.Errorf("CLIP image encoder inference failed: %w", err)
 	}
 
 	// 2) Run virality head: image_embeds -> score_logits (or score)
-	scoreTensor, err := ort.NewEmptyTensor[float32](ort.NewShape(1, 1))
+	scoreShape := ort.NewShape(n, 1)
+	if c.layout.scoreRank == 1 {
+		scoreShape = ort.NewShape(n)
+	}
+	scoreTensor, err := ort.NewEmptyTensor[float32](scoreShape)
 	if err != nil {
-		return 0.0, fmt.Errorf("failed to create score tensor: %w", err)
+		return nil, fmt.Errorf("failed to create score tensor: %w", err)
 	}
 	defer scoreTensor.Destroy()
 
@@ -143,44 +271,49 @@ func (c *CLIPScorer) Score(ctx context.Context, clip *clips.Clip) (float64, erro
 		[]ort.ArbitraryTensor{embedTensor},
 		[]ort.ArbitraryTensor{scoreTensor},
 	); err != nil {
-		return 0.0, fmt.Errorf("virality head inference failed: %w", err)
+		return nil, fmt.Errorf("virality head inference failed: %w", err)
 	}
 
 	data := scoreTensor.GetData()
-	if len(data) != 1 {
-		return 0.0, fmt.Errorf("unexpected score tensor size: %d", len(data))
+	if int64(len(data)) != n {
+		return nil, fmt.Errorf("unexpected score tensor size: %d (expected %d)", len(data), n)
 	}
 
-	// If your ONNX head outputs logits, apply sigmoid here.
-	logit := float64(data[0])
-	score := 1.0 / (1.0 + math.Exp(-logit))
-
-	c.logger.Debug().
-		Str("clip", clip.ID).
-		Float64("clip_clip_logit", logit).
-		Float64("clip_score", score).
-		Msg("CLIP virality scoring complete")
+	// The head outputs logits
+	scores := make([]float64, n)
+	for i, logit := range data {
+		scores[i] = sigmoid(float64(logit))
+	}
+	return scores, nil
+}
 
-	clip.Metadata["clip_score"] = score
-	return score, nil
+func sigmoid(x float64) float64 {
+	return 1.0 / (1.0 + math.Exp(-x))
 }
 
-// preprocessImage -> pixel_values (float32[1,3,224,224]) with CLIP normalization.
-func (c *CLIPScorer) preprocessImage(imagePath string) (ort.ArbitraryTensor, error) {
+// loadPixelValues decodes an image into dst as CLIP-normalized
+// pixel_values (3*size*size floats, channel-major)
+func loadPixelValues(imagePath string, size uint, dst []float32) error {
 	f, err := os.Open(imagePath)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer f.Close()
 
 	img, _, err := image.Decode(f)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	resized := resize.Resize(224, 224, img, resize.Bilinear)
+	pixelValues(img, size, dst)
+	return nil
+}
+
+// pixelValues center-crops img to size x size and writes normalized values
+// to dst
+func pixelValues(img image.Image, size uint, dst []float32) {
+	resized := centerCrop(img, size)
 
-	data := make([]float32, 3*224*224)
 	mean := []float32{0.48145466, 0.4578275, 0.40821073}
 	std := []float32{0.26862954, 0.26130258, 0.27577711}
 
@@ -200,13 +333,30 @@ func (c *CLIPScorer) preprocessImage(imagePath string) (ort.ArbitraryTensor, err
 				case 2:
 					v = float32(b>>8) / 255.0
 				}
-				data[idx] = (v - mean[ch]) / std[ch]
+				dst[idx] = (v - mean[ch]) / std[ch]
 				idx++
 			}
 		}
 	}
+}
+
+// centerCrop matches CLIP's reference preprocessing: resize the short side
+// to size keeping the aspect ratio, then crop the central size x size square.
+// Squashing the whole frame instead distorts what the model sees.
+func centerCrop(img image.Image, size uint) image.Image {
+	bounds := img.Bounds()
+	width, height := uint(0), size
+	if bounds.Dx() < bounds.Dy() {
+		width, height = size, 0
+	}
+	scaled := resize.Resize(width, height, img, resize.Bilinear)
 
-	return ort.NewTensor(c.inputShape, data)
+	sb := scaled.Bounds()
+	x0 := sb.Min.X + (sb.Dx()-int(size))/2
+	y0 := sb.Min.Y + (sb.Dy()-int(size))/2
+	crop := image.NewRGBA(image.Rect(0, 0, int(size), int(size)))
+	draw.Draw(crop, crop.Bounds(), scaled, image.Point{X: x0, Y: y0}, draw.Src)
+	return crop
 }
 
 // Close releases ONNX sessions and environment.
@@ -0,0 +1,110 @@
+package ai
+
+import (
+	"image"
+	"image/color"
+	"image/jpeg"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nfnt/resize"
+	"github.com/rs/zerolog"
+)
+
+// BenchmarkCLIPScoreFrame compares per-frame inference throughput across
+// execution providers. Set SLOPCANNON_MODEL_DIR to a directory holding
+// clip_image_encoder.onnx and virality_head.onnx to run it.
+func BenchmarkCLIPScoreFrame(b *testing.B) {
+	modelDir := os.Getenv("SLOPCANNON_MODEL_DIR")
+	if modelDir == "" {
+		b.Skip("SLOPCANNON_MODEL_DIR not set")
+	}
+
+	frame := filepath.Join(b.TempDir(), "frame.jpg")
+	writeTestFrame(b, frame, 640, 360)
+
+	for _, provider := range ExecutionProviders {
+		b.Run(string(provider), func(b *testing.B) {
+			scorer, err := NewCLIPScorerWithProvider(zerolog.Nop(), nil,
+				filepath.Join(modelDir, "clip_image_encoder.onnx"),
+				filepath.Join(modelDir, "virality_head.onnx"),
+				provider)
+			if err != nil {
+				b.Skip(err)
+			}
+			defer scorer.Close()
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := scorer.scoreFrames([]string{frame}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// writeTestFrame writes a w x h JPEG with a horizontal gradient
+func writeTestFrame(tb testing.TB, path string, w, h int) {
+	tb.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x * 255 / w), G: 128, B: uint8(y * 255 / h), A: 255})
+		}
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	defer f.Close()
+	if err := jpeg.Encode(f, img, nil); err != nil {
+		tb.Fatal(err)
+	}
+}
+
+func TestPixelValuesCenterCrop(t *testing.T) {
+	// 2:1 frame: red and blue bars at the edges, green in the middle
+	img := image.NewRGBA(image.Rect(0, 0, 448, 224))
+	for y := 0; y < 224; y++ {
+		for x := 0; x < 448; x++ {
+			c := color.RGBA{G: 255, A: 255}
+			if x < 80 {
+				c = color.RGBA{R: 255, A: 255}
+			} else if x >= 368 {
+				c = color.RGBA{B: 255, A: 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+
+	const size = 32
+	cropped := make([]float32, 3*size*size)
+	pixelValues(img, size, cropped)
+
+	// Only the green centre survives the crop: the red channel stays at 0
+	redZero := (0 - 0.48145466) / 0.26862954
+	for i := 0; i < size*size; i++ {
+		if d := float64(cropped[i]) - redZero; d > 1e-3 || d < -1e-3 {
+			t.Fatalf("red leaked into the centre crop at %d: %v", i, cropped[i])
+		}
+	}
+
+	// Squashing the whole frame would keep the red bar
+	squashed := make([]float32, 3*size*size)
+	resized := resize.Resize(size, size, img, resize.Bilinear)
+	pixelValues(resized, size, squashed)
+	differs := false
+	for i := range squashed {
+		if squashed[i] != cropped[i] {
+			differs = true
+			break
+		}
+	}
+	if !differs {
+		t.Error("expected squashed and cropped inputs to differ")
+	}
+}
@@ -0,0 +1,82 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"math/bits"
+	"os"
+	"strconv"
+
+	"github.com/keagan/slopcannon/internal/clips"
+	"github.com/keagan/slopcannon/internal/ffmpeg"
+	"github.com/nfnt/resize"
+)
+
+// MetaPHash is the clip metadata key holding the keyframe's dHash (16 hex digits)
+const MetaPHash = "phash"
+
+// DHash computes a 64-bit difference hash: the image is shrunk to 9x8
+// grayscale and each bit records whether a pixel is brighter than its
+// right neighbour. Similar images differ in few bits.
+func DHash(img image.Image) uint64 {
+	small := resize.Resize(9, 8, img, resize.Bilinear)
+	bounds := small.Bounds()
+
+	var hash uint64
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			left := color.GrayModel.Convert(small.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.Gray).Y
+			right := color.GrayModel.Convert(small.At(bounds.Min.X+x+1, bounds.Min.Y+y)).(color.Gray).Y
+			hash <<= 1
+			if left > right {
+				hash |= 1
+			}
+		}
+	}
+	return hash
+}
+
+// HammingDistance counts the bits in which two hashes differ
+func HammingDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// HashClip computes the dHash of the clip's middle frame and records it
+// in the clip's metadata
+func HashClip(ctx context.Context, exec *ffmpeg.Executor, clip *clips.Clip) (uint64, error) {
+	frames, cleanup, err := extractKeyframes(ctx, exec, clip, 1, ffmpeg.FrameOptions{}, "phash")
+	defer cleanup()
+	if err != nil {
+		return 0, err
+	}
+
+	f, err := os.Open(frames[0])
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return 0, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	hash := DHash(img)
+	clip.SetMeta(MetaPHash, fmt.Sprintf("%016x", hash))
+	return hash, nil
+}
+
+// ClipHash returns the dHash stored in the clip's metadata by HashClip
+func ClipHash(clip *clips.Clip) (uint64, bool) {
+	s, ok := clip.MetaString(MetaPHash)
+	if !ok {
+		return 0, false
+	}
+	hash, err := strconv.ParseUint(s, 16, 64)
+	if err != nil {
+		return 0, false
+	}
+	return hash, true
+}
@@ -0,0 +1,62 @@
+package ai
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/keagan/slopcannon/internal/clips"
+)
+
+// gradientImage draws a horizontal gradient, optionally reversed and brightened
+func gradientImage(w, h int, reversed bool, brighten uint8) image.Image {
+	img := image.NewGray(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			v := x * 200 / w
+			if reversed {
+				v = 200 - v
+			}
+			// Vertical bands keep rows distinct
+			v += (y * 7 / h) % 3 * 10
+			img.SetGray(x, y, color.Gray{Y: uint8(v) + brighten})
+		}
+	}
+	return img
+}
+
+func TestDHash(t *testing.T) {
+	base := DHash(gradientImage(320, 180, false, 0))
+
+	if d := HammingDistance(base, DHash(gradientImage(640, 360, false, 0))); d > 4 {
+		t.Errorf("rescaled image should hash nearly the same, distance %d", d)
+	}
+	if d := HammingDistance(base, DHash(gradientImage(320, 180, false, 20))); d > 4 {
+		t.Errorf("brightened image should hash nearly the same, distance %d", d)
+	}
+	if d := HammingDistance(base, DHash(gradientImage(320, 180, true, 0))); d < 32 {
+		t.Errorf("mirrored gradient should hash differently, distance %d", d)
+	}
+}
+
+func TestHammingDistance(t *testing.T) {
+	if d := HammingDistance(0, 0); d != 0 {
+		t.Errorf("expected 0, got %d", d)
+	}
+	if d := HammingDistance(0xFF, 0x0F); d != 4 {
+		t.Errorf("expected 4, got %d", d)
+	}
+}
+
+func TestClipHash(t *testing.T) {
+	clip := &clips.Clip{ID: "a"}
+	if _, ok := ClipHash(clip); ok {
+		t.Error("expected no hash on a fresh clip")
+	}
+
+	clip.SetMeta(MetaPHash, fmt.Sprintf("%016x", uint64(0xDEADBEEFCAFEF00D)))
+	if hash, ok := ClipHash(clip); !ok || hash != 0xDEADBEEFCAFEF00D {
+		t.Errorf("got %x, %v", hash, ok)
+	}
+}
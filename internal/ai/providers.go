@@ -0,0 +1,66 @@
+package ai
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog"
+	ort "github.com/yalue/onnxruntime_go"
+)
+
+// ExecutionProvider selects the ONNX Runtime backend used for inference
+type ExecutionProvider string
+
+const (
+	ProviderCPU      ExecutionProvider = "cpu"
+	ProviderCUDA     ExecutionProvider = "cuda"
+	ProviderCoreML   ExecutionProvider = "coreml"
+	ProviderDirectML ExecutionProvider = "directml"
+)
+
+// ExecutionProviders lists the supported providers
+var ExecutionProviders = []ExecutionProvider{ProviderCPU, ProviderCUDA, ProviderCoreML, ProviderDirectML}
+
+// newSessionOptions returns session options registering provider, or nil
+// for the default CPU provider. A provider the installed runtime can't
+// register falls back to CPU with a warning rather than failing.
+func newSessionOptions(logger zerolog.Logger, provider ExecutionProvider) (*ort.SessionOptions, error) {
+	if provider == "" || provider == ProviderCPU {
+		return nil, nil
+	}
+
+	options, err := ort.NewSessionOptions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session options: %w", err)
+	}
+
+	if err := appendProvider(options, provider); err != nil {
+		options.Destroy()
+		logger.Warn().
+			Err(err).
+			Str("provider", string(provider)).
+			Msg("execution provider unavailable; falling back to CPU")
+		return nil, nil
+	}
+
+	logger.Info().Str("provider", string(provider)).Msg("execution provider enabled")
+	return options, nil
+}
+
+// appendProvider registers provider on options
+func appendProvider(options *ort.SessionOptions, provider ExecutionProvider) error {
+	switch provider {
+	case ProviderCUDA:
+		cudaOptions, err := ort.NewCUDAProviderOptions()
+		if err != nil {
+			return err
+		}
+		defer cudaOptions.Destroy()
+		return options.AppendExecutionProviderCUDA(cudaOptions)
+	case ProviderCoreML:
+		return options.AppendExecutionProviderCoreML(0)
+	case ProviderDirectML:
+		return options.AppendExecutionProviderDirectML(0)
+	default:
+		return fmt.Errorf("unknown execution provider %q", provider)
+	}
+}
@@ -0,0 +1,226 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/keagan/slopcannon/internal/clips"
+	"github.com/keagan/slopcannon/internal/ffmpeg"
+	"github.com/rs/zerolog"
+	ort "github.com/yalue/onnxruntime_go"
+)
+
+// ViralityModelFile is the single-model scorer's file inside the model dir
+const ViralityModelFile = "virality_model.onnx"
+
+// ModelScorer runs one fine-tuned ONNX regression model that maps a
+// keyframe ([N,3,S,S] CLIP-normalized pixels) straight to a virality score,
+// without the CLIPScorer's encoder/head split. Like the other ONNX scorers
+// it shares one session between concurrent Score calls.
+type ModelScorer struct {
+	logger  zerolog.Logger
+	ffmpeg  *ffmpeg.Executor
+	session *ort.DynamicAdvancedSession
+	model   string
+
+	input      string
+	output     string
+	imageSize  int64
+	fixedBatch bool
+	scoreRank  int
+
+	samples     int
+	aggregation Aggregation
+	frameOpts   ffmpeg.FrameOptions
+	// logits applies a sigmoid to the model output; disable for models
+	// that already output a probability
+	logits bool
+}
+
+// NewModelScorer loads a single-model virality scorer
+func NewModelScorer(logger zerolog.Logger, ffmpegExec *ffmpeg.Executor, modelPath string, provider ExecutionProvider) (*ModelScorer, error) {
+	if _, err := os.Stat(modelPath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("model file not found: %s", modelPath)
+	}
+
+	if err := initONNX(); err != nil {
+		return nil, err
+	}
+
+	inputs, outputs, err := ort.GetInputOutputInfo(modelPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read model inputs/outputs: %w", err)
+	}
+	input, err := pickTensor(inputs, clipPixelInput, "model input")
+	if err != nil {
+		return nil, err
+	}
+	output, err := pickTensor(outputs, "score", "model output")
+	if err != nil {
+		return nil, err
+	}
+	imageSize, fixedBatch, err := imageInputShape(input, "model input")
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := newSessionOptions(logger, provider)
+	if err != nil {
+		return nil, err
+	}
+	if options != nil {
+		defer options.Destroy()
+	}
+
+	session, err := ort.NewDynamicAdvancedSession(modelPath, []string{input.Name}, []string{output.Name}, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create model session: %w", err)
+	}
+
+	logger.Info().
+		Str("model", modelPath).
+		Str("provider", string(provider)).
+		Int64("image_size", imageSize).
+		Msg("virality model loaded")
+
+	scoreRank := 2
+	if len(output.Dimensions) == 1 {
+		scoreRank = 1
+	}
+
+	return &ModelScorer{
+		logger:      logger.With().Str("scorer", "model").Logger(),
+		ffmpeg:      ffmpegExec,
+		session:     session,
+		model:       modelPath,
+		input:       input.Name,
+		output:      output.Name,
+		imageSize:   imageSize,
+		fixedBatch:  fixedBatch,
+		scoreRank:   scoreRank,
+		samples:     DefaultFrameSamples,
+		aggregation: AggregateMean,
+		logits:      true,
+	}, nil
+}
+
+// SetFrameSampling sets how many keyframes are scored and how they combine
+func (m *ModelScorer) SetFrameSampling(samples int, agg Aggregation) {
+	m.samples = samples
+	m.aggregation = agg
+}
+
+// SetFrameOptions sets the format, size and quality of extracted keyframes
+func (m *ModelScorer) SetFrameOptions(opts ffmpeg.FrameOptions) {
+	m.frameOpts = opts
+}
+
+// SetLogits controls whether the model output is passed through a sigmoid
+func (m *ModelScorer) SetLogits(logits bool) {
+	m.logits = logits
+}
+
+// Name returns "model"
+func (m *ModelScorer) Name() string {
+	return "model"
+}
+
+// Fingerprint identifies the model file and whether its output is a logit
+func (m *ModelScorer) Fingerprint() string {
+	return fmt.Sprintf("%s|logits=%t", fileFingerprint(m.model), m.logits)
+}
+
+// Score runs the model on sampled keyframes of the clip
+func (m *ModelScorer) Score(ctx context.Context, clip *clips.Clip) (float64, error) {
+	frames, cleanup, err := extractKeyframes(ctx, m.ffmpeg, clip, m.samples, m.frameOpts, "model_keyframe")
+	defer cleanup()
+	if err != nil {
+		m.logger.Warn().Err(err).Str("clip", clip.ID).Msg("keyframe extraction failed")
+		return 0.0, err
+	}
+
+	batches := [][]string{frames}
+	if m.fixedBatch {
+		batches = make([][]string, len(frames))
+		for i, frame := range frames {
+			batches[i] = []string{frame}
+		}
+	}
+
+	var scores []float64
+	for _, batch := range batches {
+		batchScores, err := m.run(batch)
+		if err != nil {
+			return 0.0, err
+		}
+		scores = append(scores, batchScores...)
+	}
+
+	score := aggregateScores(scores, m.aggregation)
+	clip.SetMeta("model_score", score)
+
+	m.logger.Debug().
+		Str("clip", clip.ID).
+		Float64("model_score", score).
+		Msg("model virality scoring complete")
+
+	return score, nil
+}
+
+// run scores one batch of keyframes
+func (m *ModelScorer) run(paths []string) ([]float64, error) {
+	n := int64(len(paths))
+	stride := 3 * m.imageSize * m.imageSize
+
+	pixels := make([]float32, n*stride)
+	for i, path := range paths {
+		if err := loadPixelValues(path, uint(m.imageSize), pixels[int64(i)*stride:(int64(i)+1)*stride]); err != nil {
+			return nil, fmt.Errorf("image preprocessing failed: %w", err)
+		}
+	}
+	inputTensor, err := ort.NewTensor(ort.NewShape(n, 3, m.imageSize, m.imageSize), pixels)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create input tensor: %w", err)
+	}
+	defer inputTensor.Destroy()
+
+	outputShape := ort.NewShape(n, 1)
+	if m.scoreRank == 1 {
+		outputShape = ort.NewShape(n)
+	}
+	outputTensor, err := ort.NewEmptyTensor[float32](outputShape)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output tensor: %w", err)
+	}
+	defer outputTensor.Destroy()
+
+	if err := m.session.Run(
+		[]ort.ArbitraryTensor{inputTensor},
+		[]ort.ArbitraryTensor{outputTensor},
+	); err != nil {
+		return nil, fmt.Errorf("virality model inference failed: %w", err)
+	}
+
+	data := outputTensor.GetData()
+	if int64(len(data)) != n {
+		return nil, fmt.Errorf("unexpected output tensor size: %d (expected %d)", len(data), n)
+	}
+
+	scores := make([]float64, n)
+	for i, v := range data {
+		scores[i] = float64(v)
+		if m.logits {
+			scores[i] = sigmoid(scores[i])
+		}
+	}
+	return scores, nil
+}
+
+// Close releases the model session
+func (m *ModelScorer) Close() error {
+	if m.session != nil {
+		return m.session.Destroy()
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/keagan/slopcannon/internal/clips"
+	"github.com/keagan/slopcannon/internal/ffmpeg"
+	"github.com/rs/zerolog"
+)
+
+type runCacheKey struct{}
+
+// RunCache memoizes probes and extracted keyframes for one analysis run so
+// that the pipeline, detector and each visual scorer share the same work.
+// It travels in the context; see WithRunCache. It is safe for concurrent
+// use; different frames are extracted in parallel.
+type RunCache struct {
+	logger zerolog.Logger
+	dir    string
+
+	mu     sync.Mutex
+	probes map[string]*ffmpeg.VideoInfo
+	frames map[string]string
+	// pending holds frames being extracted; closed when extraction ends
+	pending map[string]chan struct{}
+	// seq numbers frame files
+	seq int
+
+	probeRequests int
+	frameRequests int
+	extracted     int
+}
+
+// NewRunCache creates an empty per-run cache
+func NewRunCache(logger zerolog.Logger) *RunCache {
+	return &RunCache{
+		logger:  logger.With().Str("component", "run_cache").Logger(),
+		probes:  make(map[string]*ffmpeg.VideoInfo),
+		frames:  make(map[string]string),
+		pending: make(map[string]chan struct{}),
+	}
+}
+
+// WithRunCache attaches rc to ctx
+func WithRunCache(ctx context.Context, rc *RunCache) context.Context {
+	return context.WithValue(ctx, runCacheKey{}, rc)
+}
+
+// runCacheFrom returns the run cache carried by ctx, or nil
+func runCacheFrom(ctx context.Context) *RunCache {
+	rc, _ := ctx.Value(runCacheKey{}).(*RunCache)
+	return rc
+}
+
+// ProbeVideo probes path once per run
+func (rc *RunCache) ProbeVideo(ctx context.Context, exec *ffmpeg.Executor, path string) (*ffmpeg.VideoInfo, error) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.probeRequests++
+	if info, ok := rc.probes[path]; ok {
+		return info, nil
+	}
+
+	info, err := exec.ProbeVideoCached(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	rc.probes[path] = info
+	return info, nil
+}
+
+// ProbeMedia probes path with Executor.ProbeMedia; later ProbeVideo calls
+// for path reuse the result
+func (rc *RunCache) ProbeMedia(ctx context.Context, exec *ffmpeg.Executor, path string) (*ffmpeg.MediaInfo, error) {
+	media, err := exec.ProbeMedia(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.probeRequests++
+	rc.probes[path] = &media.VideoInfo
+	return media, nil
+}
+
+// keyframe returns the frame of clip at ts, extracting it on first use.
+// The file belongs to the cache and is removed by Close.
+func (rc *RunCache) keyframe(ctx context.Context, exec *ffmpeg.Executor, clip *clips.Clip, ts time.Duration, opts ffmpeg.FrameOptions) (string, error) {
+	key := frameKey(clip, ts, opts)
+
+	rc.mu.Lock()
+	rc.frameRequests++
+	for {
+		if path, ok := rc.frames[key]; ok {
+			rc.mu.Unlock()
+			return path, nil
+		}
+		wait, busy := rc.pending[key]
+		if !busy {
+			break
+		}
+
+		// Another goroutine is extracting this frame; recheck once it's done
+		rc.mu.Unlock()
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+		rc.mu.Lock()
+	}
+
+	if rc.dir == "" {
+		dir, err := os.MkdirTemp("", "keyframes-*")
+		if err != nil {
+			rc.mu.Unlock()
+			return "", fmt.Errorf("failed to create keyframe dir: %w", err)
+		}
+		rc.dir = dir
+	}
+
+	rc.seq++
+	path := filepath.Join(rc.dir, fmt.Sprintf("%s_%d%s", clip.ID, rc.seq, frameExt(opts)))
+	done := make(chan struct{})
+	rc.pending[key] = done
+	rc.mu.Unlock()
+
+	// Extract without holding the lock so other frames proceed in parallel
+	err := exec.ExtractFrameOpts(ctx, clip.SourceURL, ts, path, opts)
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	delete(rc.pending, key)
+	close(done)
+
+	if err != nil {
+		_ = os.Remove(path)
+		return "", err
+	}
+	rc.extracted++
+	rc.frames[key] = path
+	return path, nil
+}
+
+// frameKey identifies one sampled frame of a clip in a given image format
+func frameKey(clip *clips.Clip, ts time.Duration, opts ffmpeg.FrameOptions) string {
+	if opts == (ffmpeg.FrameOptions{}) {
+		return fmt.Sprintf("%s@%d", clip.ID, ts)
+	}
+	return fmt.Sprintf("%s@%d/%s:%d:%d", clip.ID, ts, opts.Format, opts.Width, opts.Quality)
+}
+
+// keyframes is the cached counterpart of extractKeyframes
+func (rc *RunCache) keyframes(ctx context.Context, exec *ffmpeg.Executor, clip *clips.Clip, n int, opts ffmpeg.FrameOptions) ([]string, func(), error) {
+	noop := func() {}
+
+	var paths []string
+	var lastErr error
+	for _, ts := range sampleTimestamps(clip, n) {
+		path, err := rc.keyframe(ctx, exec, clip, ts, opts)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		paths = append(paths, path)
+	}
+
+	if len(paths) == 0 {
+		return nil, noop, fmt.Errorf("keyframe extraction failed: %w", lastErr)
+	}
+	return paths, noop, nil
+}
+
+// Close removes cached keyframes and logs how much work was saved
+func (rc *RunCache) Close() error {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.logger.Info().
+		Int("probe_requests", rc.probeRequests).
+		Int("probes", len(rc.probes)).
+		Int("frame_requests", rc.frameRequests).
+		Int("frames_extracted", rc.extracted).
+		Msg("run cache stats")
+
+	rc.probes = make(map[string]*ffmpeg.VideoInfo)
+	rc.frames = make(map[string]string)
+	if rc.dir == "" {
+		return nil
+	}
+	dir := rc.dir
+	rc.dir = ""
+	return os.RemoveAll(dir)
+}
@@ -0,0 +1,35 @@
+package ai
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/keagan/slopcannon/internal/clips"
+	"github.com/keagan/slopcannon/internal/ffmpeg"
+	"github.com/rs/zerolog"
+)
+
+func TestRunCacheReusesKeyframes(t *testing.T) {
+	rc := NewRunCache(zerolog.Nop())
+	clip := &clips.Clip{ID: "clip_0", Start: 0, End: 8 * time.Second}
+
+	// Seed the cache as if an earlier scorer had extracted these frames;
+	// a nil executor would panic if anything were extracted again
+	for i, ts := range sampleTimestamps(clip, 3) {
+		rc.frames[frameKey(clip, ts, ffmpeg.FrameOptions{})] = []string{"a.jpg", "b.jpg", "c.jpg"}[i]
+	}
+
+	ctx := WithRunCache(context.Background(), rc)
+	paths, cleanup, err := extractKeyframes(ctx, nil, clip, 3, ffmpeg.FrameOptions{}, "keyframe")
+	cleanup()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 3 || paths[0] != "a.jpg" || paths[2] != "c.jpg" {
+		t.Errorf("expected cached frames, got %v", paths)
+	}
+	if rc.frameRequests != 3 || rc.extracted != 0 {
+		t.Errorf("expected 3 requests and 0 extractions, got %d/%d", rc.frameRequests, rc.extracted)
+	}
+}
@@ -2,18 +2,45 @@ package ai
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"math"
 
 	"github.com/keagan/slopcannon/internal/clips"
+	"github.com/rs/zerolog"
 )
 
-// Scorer evaluates clips for viral potential
+// Scorer evaluates clips for viral potential. The detector may call Score
+// for different clips from several goroutines at once, so implementations
+// must be safe for concurrent use; a clip is only ever scored by one
+// goroutine at a time.
 type Scorer interface {
+	// Name identifies the scorer, e.g. in score breakdowns
+	Name() string
 	Score(ctx context.Context, clip *clips.Clip) (float64, error)
 	Close() error
 }
 
-// HeuristicScorer uses rule-based heuristics
+// MetaScores is the clip metadata key holding the composite score's
+// per-scorer breakdown (map[string]float64 keyed by scorer name)
+const MetaScores = "scores"
+
+// Fingerprinter is implemented by scorers whose scores depend on more than
+// their name, such as a model file or keyword list. The fingerprint is part
+// of the analysis cache key, so changing any of it invalidates old scores.
+type Fingerprinter interface {
+	Fingerprint() string
+}
+
+// BatchPreparer is implemented by scorers that are cheaper when given many
+// clips at once. The detector calls Prepare with every candidate before
+// scoring them one by one; Score then returns the precomputed results.
+type BatchPreparer interface {
+	Prepare(ctx context.Context, clips []*clips.Clip) error
+}
+
+// HeuristicScorer uses rule-based heuristics. It is stateless and safe for
+// concurrent use.
 type HeuristicScorer struct {
 	weights Weights
 }
@@ -24,20 +51,29 @@ type Weights struct {
 	ShotChanges   float64
 	AudioPeaks    float64
 	DialogDensity float64
+	Motion        float64
+	CutStrength   float64
 }
 
 // NewHeuristicScorer creates a new heuristic scorer
 func NewHeuristicScorer() *HeuristicScorer {
 	return &HeuristicScorer{
 		weights: Weights{
-			Duration:      0.2,
-			ShotChanges:   0.3,
-			AudioPeaks:    0.3,
+			Duration:      0.15,
+			ShotChanges:   0.2,
+			AudioPeaks:    0.25,
 			DialogDensity: 0.2,
+			Motion:        0.1,
+			CutStrength:   0.1,
 		},
 	}
 }
 
+// Name returns "heuristic"
+func (h *HeuristicScorer) Name() string {
+	return "heuristic"
+}
+
 // Score calculates a heuristic score
 func (h *HeuristicScorer) Score(ctx context.Context, clip *clips.Clip) (float64, error) {
 	var totalScore float64
@@ -47,23 +83,33 @@ func (h *HeuristicScorer) Score(ctx context.Context, clip *clips.Clip) (float64,
 	totalScore += h.weights.Duration * durationScore
 
 	// Shot changes scoring (from metadata)
-	if sceneChanges, ok := clip.Metadata["scene_changes"].(int); ok {
+	if sceneChanges, ok := clip.MetaInt("scene_changes"); ok {
 		shotScore := h.scoreShotChanges(sceneChanges, clip.Duration.Seconds())
 		totalScore += h.weights.ShotChanges * shotScore
 	}
 
 	// Audio peaks scoring (from metadata)
-	if peakVolume, ok := clip.Metadata["peak_volume"].(float64); ok {
+	if peakVolume, ok := clip.MetaFloat("peak_volume"); ok {
 		audioScore := h.scoreAudioPeaks(peakVolume)
 		totalScore += h.weights.AudioPeaks * audioScore
 	}
 
 	// Dialog density scoring (inverse of silence ratio)
-	if silenceRatio, ok := clip.Metadata["silence_ratio"].(float64); ok {
+	if silenceRatio, ok := clip.MetaFloat("silence_ratio"); ok {
 		dialogScore := 1.0 - math.Min(1.0, silenceRatio)
 		totalScore += h.weights.DialogDensity * dialogScore
 	}
 
+	// Motion scoring (already normalized 0-1)
+	if motion, ok := clip.MetaFloat("motion_intensity"); ok {
+		totalScore += h.weights.Motion * math.Max(0.0, math.Min(1.0, motion))
+	}
+
+	// Clips opening on a strong cut stand apart from what came before
+	if strength, ok := clip.MetaFloat("cut_strength"); ok {
+		totalScore += h.weights.CutStrength * math.Max(0.0, math.Min(1.0, strength))
+	}
+
 	return math.Max(0.0, math.Min(1.0, totalScore)), nil
 }
 
@@ -101,47 +147,61 @@ func (h *HeuristicScorer) Close() error {
 	return nil
 }
 
-// ModelScorer uses AI models for scoring
-type ModelScorer struct {
-	modelPath string
-	// TODO: add model-specific fields
-}
-
-// NewModelScorer creates a new AI model scorer
-func NewModelScorer(modelPath string) (*ModelScorer, error) {
-	// TODO: load model
-	return &ModelScorer{
-		modelPath: modelPath,
-	}, nil
-}
+// FailureMode controls how CompositeScorer handles a failing child scorer
+type FailureMode string
 
-// Score calculates an AI-based score
-func (m *ModelScorer) Score(ctx context.Context, clip *clips.Clip) (float64, error) {
-	// TODO: implement model inference
-	return 0.0, nil
-}
+const (
+	// FailStrict fails the whole clip when any scorer fails
+	FailStrict FailureMode = "strict"
+	// FailSkip leaves a failing scorer out and reweights the rest; the clip
+	// only fails when every scorer does
+	FailSkip FailureMode = "skip"
+)
 
-// Close releases model resources
-func (m *ModelScorer) Close() error {
-	// TODO: cleanup model
-	return nil
-}
+// ErrPartialScore marks a composite score computed without the scorers that
+// failed in FailSkip mode. The score is still usable, but it shouldn't be
+// cached: the failures may be transient.
+var ErrPartialScore = errors.New("partial score")
 
-// CompositeScorer combines multiple scorers
+// CompositeScorer combines multiple scorers. It runs its scorers one after
+// another per clip and is as safe for concurrent use as they are; configure
+// it before scoring starts.
 type CompositeScorer struct {
-	scorers []Scorer
-	weights []float64
+	scorers     []Scorer
+	weights     []float64
+	failureMode FailureMode
+	logger      zerolog.Logger
 }
 
 // NewCompositeScorer creates a scorer that combines multiple scorers
 func NewCompositeScorer(scorers []Scorer, weights []float64) *CompositeScorer {
 	return &CompositeScorer{
-		scorers: scorers,
-		weights: weights,
+		scorers:     scorers,
+		weights:     weights,
+		failureMode: FailSkip,
+		logger:      zerolog.Nop(),
 	}
 }
 
-// Score calculates a weighted average of all scorers
+// SetFailureMode sets how a failing scorer is handled (default FailSkip)
+func (c *CompositeScorer) SetFailureMode(mode FailureMode) {
+	c.failureMode = mode
+}
+
+// SetLogger sets where skipped scorer failures are logged
+func (c *CompositeScorer) SetLogger(logger zerolog.Logger) {
+	c.logger = logger.With().Str("scorer", "composite").Logger()
+}
+
+// Name returns "composite"
+func (c *CompositeScorer) Name() string {
+	return "composite"
+}
+
+// Score calculates a weighted average of all scorers and records each
+// scorer's contribution under MetaScores; the contributions sum to the
+// returned score. In FailSkip mode failed scorers are left out of both and
+// the score comes with an error wrapping ErrPartialScore.
 func (c *CompositeScorer) Score(ctx context.Context, clip *clips.Clip) (float64, error) {
 	if len(c.scorers) == 0 {
 		return 0.0, nil
@@ -149,11 +209,23 @@ func (c *CompositeScorer) Score(ctx context.Context, clip *clips.Clip) (float64,
 
 	var totalScore float64
 	var totalWeight float64
+	weighted := make(map[string]float64, len(c.scorers))
+	var failures []error
 
 	for i, scorer := range c.scorers {
 		score, err := scorer.Score(ctx, clip)
 		if err != nil {
-			return 0.0, err
+			err = fmt.Errorf("%s scorer failed: %w", scorer.Name(), err)
+			// Cancellation isn't a scorer fault; don't score on without it
+			if c.failureMode == FailStrict || ctx.Err() != nil {
+				return 0.0, err
+			}
+			c.logger.Warn().Err(err).
+				Str("clip", clip.ID).
+				Str("failed_scorer", scorer.Name()).
+				Msg("scorer failed; reweighting the others")
+			failures = append(failures, err)
+			continue
 		}
 
 		weight := 1.0
@@ -163,20 +235,46 @@ func (c *CompositeScorer) Score(ctx context.Context, clip *clips.Clip) (float64,
 
 		totalScore += score * weight
 		totalWeight += weight
+		weighted[scorer.Name()] += score * weight
 	}
 
+	if len(failures) == len(c.scorers) {
+		return 0.0, errors.Join(failures...)
+	}
+	var partial error
+	if len(failures) > 0 {
+		partial = fmt.Errorf("%w: %w", ErrPartialScore, errors.Join(failures...))
+	}
 	if totalWeight == 0 {
-		return 0.0, nil
+		return 0.0, partial
 	}
 
-	return totalScore / totalWeight, nil
+	breakdown := make(map[string]float64, len(weighted))
+	for name, w := range weighted {
+		breakdown[name] = w / totalWeight
+	}
+	clip.SetMeta(MetaScores, breakdown)
+
+	return totalScore / totalWeight, partial
+}
+
+// Prepare forwards to every underlying scorer that batches
+func (c *CompositeScorer) Prepare(ctx context.Context, list []*clips.Clip) error {
+	for _, scorer := range c.scorers {
+		if preparer, ok := scorer.(BatchPreparer); ok {
+			if err := preparer.Prepare(ctx, list); err != nil {
+				return fmt.Errorf("%s scorer prepare failed: %w", scorer.Name(), err)
+			}
+		}
+	}
+	return nil
 }
 
 // Close closes all underlying scorers
 func (c *CompositeScorer) Close() error {
 	for _, scorer := range c.scorers {
 		if err := scorer.Close(); err != nil {
-			return err
+			return fmt.Errorf("failed to close %s scorer: %w", scorer.Name(), err)
 		}
 	}
 	return nil
@@ -0,0 +1,112 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/keagan/slopcannon/internal/clips"
+)
+
+// fixedScorer returns the same score for every clip
+type fixedScorer struct {
+	name  string
+	score float64
+}
+
+func (f fixedScorer) Name() string { return f.name }
+
+func (f fixedScorer) Score(context.Context, *clips.Clip) (float64, error) { return f.score, nil }
+
+func (f fixedScorer) Close() error { return nil }
+
+func TestCompositeScorerRecordsBreakdown(t *testing.T) {
+	composite := NewCompositeScorer(
+		[]Scorer{fixedScorer{"heuristic", 0.4}, fixedScorer{"clip", 0.9}},
+		[]float64{0.25, 0.75},
+	)
+
+	clip := &clips.Clip{ID: "c"}
+	score, err := composite.Score(context.Background(), clip)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	breakdown, ok := clip.MetaScores(MetaScores)
+	if !ok {
+		t.Fatalf("expected a score breakdown, got %v", clip.Metadata)
+	}
+	want := map[string]float64{"heuristic": 0.1, "clip": 0.675}
+	var sum float64
+	for name, contribution := range want {
+		if math.Abs(breakdown[name]-contribution) > 1e-9 {
+			t.Errorf("%s: expected %v, got %v", name, contribution, breakdown[name])
+		}
+		sum += breakdown[name]
+	}
+	if math.Abs(sum-score) > 1e-9 {
+		t.Errorf("contributions sum to %v, score is %v", sum, score)
+	}
+}
+
+// failingScorer always errors
+type failingScorer struct{}
+
+func (failingScorer) Name() string { return "flaky" }
+
+func (failingScorer) Score(context.Context, *clips.Clip) (float64, error) {
+	return 0, errors.New("boom")
+}
+
+func (failingScorer) Close() error { return nil }
+
+func TestCompositeScorerNamesFailingScorer(t *testing.T) {
+	composite := NewCompositeScorer([]Scorer{fixedScorer{"heuristic", 0.5}, failingScorer{}}, nil)
+	composite.SetFailureMode(FailStrict)
+
+	_, err := composite.Score(context.Background(), &clips.Clip{ID: "c"})
+	if err == nil || !strings.Contains(err.Error(), "flaky scorer") {
+		t.Fatalf("expected the error to name the failing scorer, got %v", err)
+	}
+}
+
+func TestCompositeScorerSkipsFailingScorer(t *testing.T) {
+	composite := NewCompositeScorer(
+		[]Scorer{fixedScorer{"heuristic", 0.4}, failingScorer{}, fixedScorer{"clip", 0.8}},
+		[]float64{0.25, 0.5, 0.25},
+	)
+
+	clip := &clips.Clip{ID: "c"}
+	score, err := composite.Score(context.Background(), clip)
+	if !errors.Is(err, ErrPartialScore) {
+		t.Fatalf("expected a partial score from skipping the failing scorer, got %v", err)
+	}
+	// The remaining weights are equal, so the score is their plain mean
+	if math.Abs(score-0.6) > 1e-9 {
+		t.Errorf("expected 0.6 from the remaining scorers, got %v", score)
+	}
+
+	breakdown, _ := clip.MetaScores(MetaScores)
+	if _, ok := breakdown["flaky"]; ok || len(breakdown) != 2 {
+		t.Errorf("expected only the working scorers in the breakdown, got %v", breakdown)
+	}
+
+	allFailing := NewCompositeScorer([]Scorer{failingScorer{}}, nil)
+	if _, err := allFailing.Score(context.Background(), clip); err == nil || errors.Is(err, ErrPartialScore) {
+		t.Errorf("expected a hard error when every scorer fails, got %v", err)
+	}
+}
+
+func TestScorerFingerprintUsesNames(t *testing.T) {
+	composite := NewCompositeScorer([]Scorer{NewHeuristicScorer(), fixedScorer{"clip", 0}}, []float64{0.4, 0.6})
+	if got, want := scorerFingerprint(composite), "composite[skip](heuristic:0.4,clip:0.6)"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	composite.SetFailureMode(FailStrict)
+	if got, want := scorerFingerprint(composite), "composite[strict](heuristic:0.4,clip:0.6)"; got != want {
+		t.Errorf("expected the failure mode in the fingerprint, got %q", got)
+	}
+}
@@ -0,0 +1,87 @@
+package ai
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+)
+
+// readWAVFile decodes a 16-bit PCM WAV file, see readWAV
+func readWAVFile(path string) ([]float32, int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer f.Close()
+	return readWAV(f)
+}
+
+// readWAV decodes 16-bit PCM WAV into samples in [-1,1], averaging
+// channels to mono, and returns them with the sample rate
+func readWAV(r io.Reader) ([]float32, int, error) {
+	var header [12]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, 0, fmt.Errorf("failed to read WAV header: %w", err)
+	}
+	if string(header[0:4]) != "RIFF" || string(header[8:12]) != "WAVE" {
+		return nil, 0, fmt.Errorf("not a WAV file")
+	}
+
+	var channels, bits, sampleRate int
+	for {
+		var chunk [8]byte
+		if _, err := io.ReadFull(r, chunk[:]); err != nil {
+			return nil, 0, fmt.Errorf("WAV file has no data chunk: %w", err)
+		}
+		id := string(chunk[0:4])
+		size := int64(binary.LittleEndian.Uint32(chunk[4:8]))
+
+		switch id {
+		case "fmt ":
+			fmtChunk := make([]byte, size)
+			if _, err := io.ReadFull(r, fmtChunk); err != nil || size < 16 {
+				return nil, 0, fmt.Errorf("invalid WAV fmt chunk")
+			}
+			if format := binary.LittleEndian.Uint16(fmtChunk[0:2]); format != 1 {
+				return nil, 0, fmt.Errorf("unsupported WAV encoding %d (want 16-bit PCM)", format)
+			}
+			channels = int(binary.LittleEndian.Uint16(fmtChunk[2:4]))
+			sampleRate = int(binary.LittleEndian.Uint32(fmtChunk[4:8]))
+			bits = int(binary.LittleEndian.Uint16(fmtChunk[14:16]))
+		case "data":
+			if channels == 0 {
+				return nil, 0, fmt.Errorf("WAV data chunk precedes fmt chunk")
+			}
+			if bits != 16 {
+				return nil, 0, fmt.Errorf("unsupported WAV sample size %d bits (want 16)", bits)
+			}
+			// Streamed WAVs may not know their size up front; read what's there
+			data, err := io.ReadAll(io.LimitReader(r, size))
+			if err != nil {
+				return nil, 0, fmt.Errorf("failed to read WAV data: %w", err)
+			}
+			return pcm16Mono(data, channels), sampleRate, nil
+		default:
+			// Chunks are padded to an even size
+			if _, err := io.CopyN(io.Discard, r, size+size%2); err != nil {
+				return nil, 0, fmt.Errorf("failed to skip WAV %q chunk: %w", id, err)
+			}
+		}
+	}
+}
+
+// pcm16Mono converts interleaved little-endian 16-bit frames to mono floats
+func pcm16Mono(data []byte, channels int) []float32 {
+	frameSize := 2 * channels
+	samples := make([]float32, len(data)/frameSize)
+	for i := range samples {
+		var sum float32
+		for ch := 0; ch < channels; ch++ {
+			off := i*frameSize + 2*ch
+			sum += float32(int16(binary.LittleEndian.Uint16(data[off:off+2]))) / 32768
+		}
+		samples[i] = sum / float32(channels)
+	}
+	return samples
+}
@@ -2,6 +2,7 @@ package clips
 
 import (
 	"context"
+	"sort"
 	"time"
 )
 
@@ -16,6 +17,38 @@ type Clip struct {
 	Metadata  map[string]interface{}
 }
 
+// TimeRange returns the clip's bounds. Clips cover the half-open range
+// [Start, End): End is the first instant not in the clip.
+func (c *Clip) TimeRange() (start, end time.Duration) {
+	return c.Start, c.End
+}
+
+// Contains reports whether t falls inside [Start, End)
+func (c *Clip) Contains(t time.Duration) bool {
+	return t >= c.Start && t < c.End
+}
+
+// Overlaps reports whether the clips share any time; clips that merely
+// touch (one ends where the other starts) do not overlap
+func (c *Clip) Overlaps(other *Clip) bool {
+	return other != nil && c.Start < other.End && other.Start < c.End
+}
+
+// OverlapDuration returns how long the clips overlap (0 if disjoint)
+func (c *Clip) OverlapDuration(other *Clip) time.Duration {
+	if !c.Overlaps(other) {
+		return 0
+	}
+	start, end := c.Start, c.End
+	if other.Start > start {
+		start = other.Start
+	}
+	if other.End < end {
+		end = other.End
+	}
+	return end - start
+}
+
 // Detector finds clips within a video
 type Detector interface {
 	Detect(ctx context.Context, videoPath string) ([]*Clip, error)
@@ -47,10 +80,8 @@ func (m *Manager) Add(clip *Clip) {
 
 // Get retrieves a clip by ID
 func (m *Manager) Get(id string) *Clip {
-	for _, clip := range m.clips {
-		if clip.ID == id {
-			return clip
-		}
+	if i := m.index(id); i >= 0 {
+		return m.clips[i]
 	}
 	return nil
 }
@@ -59,3 +90,45 @@ func (m *Manager) Get(id string) *Clip {
 func (m *Manager) All() []*Clip {
 	return m.clips
 }
+
+// Remove deletes the clip with the given ID, reporting whether it existed
+func (m *Manager) Remove(id string) bool {
+	i := m.index(id)
+	if i < 0 {
+		return false
+	}
+	m.clips = append(m.clips[:i], m.clips[i+1:]...)
+	return true
+}
+
+// ReplaceByID swaps the clip with the given ID for clip, keeping its
+// position, and reports whether it existed
+func (m *Manager) ReplaceByID(id string, clip *Clip) bool {
+	i := m.index(id)
+	if i < 0 || clip == nil {
+		return false
+	}
+	m.clips[i] = clip
+	return true
+}
+
+// SortByScore orders clips from highest to lowest score; ties keep their
+// current order
+func (m *Manager) SortByScore() {
+	sort.SliceStable(m.clips, func(i, j int) bool { return m.clips[i].Score > m.clips[j].Score })
+}
+
+// SortByStart orders clips by start time; ties keep their current order
+func (m *Manager) SortByStart() {
+	sort.SliceStable(m.clips, func(i, j int) bool { return m.clips[i].Start < m.clips[j].Start })
+}
+
+// index returns the position of the clip with the given ID, or -1
+func (m *Manager) index(id string) int {
+	for i, clip := range m.clips {
+		if clip.ID == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,145 @@
+package clips
+
+import (
+	"testing"
+	"time"
+)
+
+func managerWith(clips ...*Clip) *Manager {
+	m := NewManager()
+	for _, c := range clips {
+		m.Add(c)
+	}
+	return m
+}
+
+func clipIDs(clips []*Clip) []string {
+	ids := make([]string, len(clips))
+	for i, c := range clips {
+		ids[i] = c.ID
+	}
+	return ids
+}
+
+func TestManagerRemoveMiddle(t *testing.T) {
+	m := managerWith(
+		newClip("a", 0, 10*time.Second),
+		newClip("b", 10*time.Second, 20*time.Second),
+		newClip("c", 20*time.Second, 30*time.Second),
+	)
+
+	if !m.Remove("b") {
+		t.Fatal("expected Remove to find clip b")
+	}
+	if got := clipIDs(m.All()); len(got) != 2 || got[0] != "a" || got[1] != "c" {
+		t.Errorf("expected [a c], got %v", got)
+	}
+	for i, c := range m.All() {
+		if c == nil {
+			t.Errorf("nil clip at %d", i)
+		}
+	}
+	if m.Get("b") != nil {
+		t.Error("removed clip is still retrievable")
+	}
+	if m.Remove("b") {
+		t.Error("expected second Remove to report false")
+	}
+}
+
+func TestManagerReplaceByID(t *testing.T) {
+	m := managerWith(
+		newClip("a", 0, 10*time.Second),
+		newClip("b", 10*time.Second, 20*time.Second),
+	)
+
+	if !m.ReplaceByID("b", newClip("b", 12*time.Second, 25*time.Second)) {
+		t.Fatal("expected ReplaceByID to find clip b")
+	}
+
+	got := m.Get("b")
+	if got.Start != 12*time.Second || got.End != 25*time.Second {
+		t.Errorf("expected 12s-25s, got %v-%v", got.Start, got.End)
+	}
+	if ids := clipIDs(m.All()); ids[1] != "b" {
+		t.Errorf("replacement moved position: %v", ids)
+	}
+
+	if m.ReplaceByID("missing", newClip("x", 0, time.Second)) {
+		t.Error("expected ReplaceByID to report false for an unknown ID")
+	}
+	if m.ReplaceByID("a", nil) {
+		t.Error("expected ReplaceByID to reject a nil clip")
+	}
+}
+
+func TestManagerSort(t *testing.T) {
+	a := newClip("a", 20*time.Second, 30*time.Second)
+	b := newClip("b", 0, 10*time.Second)
+	c := newClip("c", 10*time.Second, 20*time.Second)
+	a.Score, b.Score, c.Score = 0.4, 0.9, 0.4
+	m := managerWith(a, b, c)
+
+	m.SortByScore()
+	if got := clipIDs(m.All()); got[0] != "b" || got[1] != "a" || got[2] != "c" {
+		t.Errorf("SortByScore: expected [b a c], got %v", got)
+	}
+
+	m.SortByStart()
+	if got := clipIDs(m.All()); got[0] != "b" || got[1] != "c" || got[2] != "a" {
+		t.Errorf("SortByStart: expected [b c a], got %v", got)
+	}
+}
+
+func TestClipOverlaps(t *testing.T) {
+	a := newClip("a", 10*time.Second, 20*time.Second)
+
+	tests := []struct {
+		name    string
+		other   *Clip
+		want    bool
+		overlap time.Duration
+	}{
+		{"touching after", newClip("b", 20*time.Second, 30*time.Second), false, 0},
+		{"touching before", newClip("b", 0, 10*time.Second), false, 0},
+		{"partial", newClip("b", 15*time.Second, 25*time.Second), true, 5 * time.Second},
+		{"inside", newClip("b", 12*time.Second, 14*time.Second), true, 2 * time.Second},
+		{"disjoint", newClip("b", 40*time.Second, 50*time.Second), false, 0},
+		{"nil", nil, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := a.Overlaps(tt.other); got != tt.want {
+				t.Errorf("Overlaps = %v, want %v", got, tt.want)
+			}
+			if tt.other != nil && tt.other.Overlaps(a) != tt.want {
+				t.Errorf("Overlaps is not symmetric")
+			}
+			if got := a.OverlapDuration(tt.other); got != tt.overlap {
+				t.Errorf("OverlapDuration = %v, want %v", got, tt.overlap)
+			}
+		})
+	}
+}
+
+func TestClipContains(t *testing.T) {
+	c := newClip("a", 10*time.Second, 20*time.Second)
+
+	if !c.Contains(10 * time.Second) {
+		t.Error("expected start to be inside the clip")
+	}
+	if !c.Contains(19999 * time.Millisecond) {
+		t.Error("expected 19.999s to be inside the clip")
+	}
+	if c.Contains(20 * time.Second) {
+		t.Error("expected end to be outside the clip")
+	}
+	if c.Contains(5 * time.Second) {
+		t.Error("expected 5s to be outside the clip")
+	}
+
+	if start, end := c.TimeRange(); start != 10*time.Second || end != 20*time.Second {
+		t.Errorf("TimeRange = %v-%v, want 10s-20s", start, end)
+	}
+}
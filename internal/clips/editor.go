@@ -0,0 +1,112 @@
+package clips
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// editor implements Editor purely on clip metadata, without touching ffmpeg
+type editor struct{}
+
+// NewEditor creates a metadata-only clip editor for timeline editing
+func NewEditor() Editor {
+	return editor{}
+}
+
+// Trim returns a new clip covering [start, end] clamped to the clip's bounds
+func (editor) Trim(clip *Clip, start, end time.Duration) (*Clip, error) {
+	if clip == nil {
+		return nil, fmt.Errorf("clip cannot be nil")
+	}
+
+	if start < clip.Start {
+		start = clip.Start
+	}
+	if end > clip.End {
+		end = clip.End
+	}
+	if end <= start {
+		return nil, fmt.Errorf("trim range %v-%v is empty within clip %s", start, end, clip.ID)
+	}
+
+	return derive(clip, clip.ID+"_trim", start, end), nil
+}
+
+// Split cuts a clip at the given timestamp into two adjacent clips
+func (editor) Split(clip *Clip, at time.Duration) ([]*Clip, error) {
+	if clip == nil {
+		return nil, fmt.Errorf("clip cannot be nil")
+	}
+	if at <= clip.Start || at >= clip.End {
+		return nil, fmt.Errorf("split point %v must be inside clip %s (%v-%v)", at, clip.ID, clip.Start, clip.End)
+	}
+
+	return []*Clip{
+		derive(clip, clip.ID+"_part1", clip.Start, at),
+		derive(clip, clip.ID+"_part2", at, clip.End),
+	}, nil
+}
+
+// Merge combines contiguous or overlapping clips from the same source into
+// one clip spanning the earliest start to the latest end
+func (editor) Merge(clips []*Clip) (*Clip, error) {
+	if len(clips) == 0 {
+		return nil, fmt.Errorf("no clips to merge")
+	}
+
+	for i, clip := range clips {
+		if clip == nil {
+			return nil, fmt.Errorf("clip %d is nil", i)
+		}
+	}
+
+	sorted := make([]*Clip, len(clips))
+	copy(sorted, clips)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start < sorted[j].Start })
+
+	first := sorted[0]
+	end := first.End
+	var weightedScore, totalLength float64
+
+	for _, clip := range sorted {
+		if clip.SourceURL != first.SourceURL {
+			return nil, fmt.Errorf("cannot merge clips from different sources: %s and %s", first.SourceURL, clip.SourceURL)
+		}
+		if clip.Start > end {
+			return nil, fmt.Errorf("clips are not contiguous: gap between %v and %v", end, clip.Start)
+		}
+		if clip.End > end {
+			end = clip.End
+		}
+		length := float64(clip.End - clip.Start)
+		weightedScore += clip.Score * length
+		totalLength += length
+	}
+
+	// Score is the length-weighted mean of the merged clips
+	merged := derive(first, "merged_"+first.ID, first.Start, end)
+	if totalLength > 0 {
+		merged.Score = weightedScore / totalLength
+	}
+
+	return merged, nil
+}
+
+// derive copies a clip with new bounds and a shallow copy of its metadata
+func derive(src *Clip, id string, start, end time.Duration) *Clip {
+	metadata := make(map[string]interface{}, len(src.Metadata))
+	for k, v := range src.Metadata {
+		metadata[k] = v
+	}
+
+	return &Clip{
+		ID:        id,
+		Start:     start,
+		End:       end,
+		Duration:  end - start,
+		Score:     src.Score,
+		SourceURL: src.SourceURL,
+		Metadata:  metadata,
+	}
+}
@@ -0,0 +1,76 @@
+package clips
+
+import (
+	"testing"
+	"time"
+)
+
+func newClip(id string, start, end time.Duration) *Clip {
+	return &Clip{ID: id, Start: start, End: end, Duration: end - start, SourceURL: "video.mp4", Score: 0.5}
+}
+
+func TestEditorTrimClamps(t *testing.T) {
+	clip := newClip("a", 10*time.Second, 40*time.Second)
+
+	trimmed, err := NewEditor().Trim(clip, 5*time.Second, 20*time.Second)
+	if err != nil {
+		t.Fatalf("Trim failed: %v", err)
+	}
+	if trimmed.Start != 10*time.Second || trimmed.End != 20*time.Second {
+		t.Errorf("expected 10s-20s, got %v-%v", trimmed.Start, trimmed.End)
+	}
+	if trimmed.Duration != 10*time.Second {
+		t.Errorf("expected duration 10s, got %v", trimmed.Duration)
+	}
+
+	if _, err := NewEditor().Trim(clip, 50*time.Second, 60*time.Second); err == nil {
+		t.Error("expected error for trim outside clip")
+	}
+}
+
+func TestEditorSplit(t *testing.T) {
+	clip := newClip("a", 10*time.Second, 40*time.Second)
+
+	parts, err := NewEditor().Split(clip, 25*time.Second)
+	if err != nil {
+		t.Fatalf("Split failed: %v", err)
+	}
+	if len(parts) != 2 {
+		t.Fatalf("expected 2 parts, got %d", len(parts))
+	}
+	if parts[0].Duration+parts[1].Duration != clip.Duration {
+		t.Errorf("part durations %v + %v != %v", parts[0].Duration, parts[1].Duration, clip.Duration)
+	}
+	if parts[0].End != parts[1].Start {
+		t.Error("parts should be adjacent")
+	}
+
+	if _, err := NewEditor().Split(clip, 10*time.Second); err == nil {
+		t.Error("expected error when splitting at the clip boundary")
+	}
+}
+
+func TestEditorMerge(t *testing.T) {
+	a := newClip("a", 0, 10*time.Second)
+	b := newClip("b", 10*time.Second, 25*time.Second)
+	c := newClip("c", 20*time.Second, 30*time.Second)
+
+	merged, err := NewEditor().Merge([]*Clip{c, a, b})
+	if err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if merged.Start != 0 || merged.End != 30*time.Second {
+		t.Errorf("expected 0-30s, got %v-%v", merged.Start, merged.End)
+	}
+
+	gap := newClip("gap", 40*time.Second, 50*time.Second)
+	if _, err := NewEditor().Merge([]*Clip{a, gap}); err == nil {
+		t.Error("expected error for non-contiguous clips")
+	}
+
+	other := newClip("other", 10*time.Second, 20*time.Second)
+	other.SourceURL = "other.mp4"
+	if _, err := NewEditor().Merge([]*Clip{a, other}); err == nil {
+		t.Error("expected error for clips from different sources")
+	}
+}
@@ -0,0 +1,89 @@
+package clips
+
+import (
+	"encoding/json"
+	"math"
+)
+
+// MetaFloat returns a numeric metadata value as float64. Any numeric type
+// is accepted, since values decoded from JSON are always float64 (or
+// json.Number) whatever they were when set.
+func (c *Clip) MetaFloat(key string) (float64, bool) {
+	return toFloat(c.Metadata[key])
+}
+
+// toFloat converts any numeric metadata value to float64
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// MetaInt returns an integer metadata value, accepting whole-valued floats
+// so that counts survive a JSON round trip
+func (c *Clip) MetaInt(key string) (int, bool) {
+	switch v := c.Metadata[key].(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case int32:
+		return int(v), true
+	case json.Number:
+		n, err := v.Int64()
+		return int(n), err == nil
+	}
+
+	f, ok := c.MetaFloat(key)
+	if !ok || f != math.Trunc(f) {
+		return 0, false
+	}
+	return int(f), true
+}
+
+// MetaString returns a string metadata value
+func (c *Clip) MetaString(key string) (string, bool) {
+	s, ok := c.Metadata[key].(string)
+	return s, ok
+}
+
+// MetaScores returns a map of named scores, e.g. the per-scorer breakdown.
+// Maps decoded from JSON (map[string]interface{}) are converted.
+func (c *Clip) MetaScores(key string) (map[string]float64, bool) {
+	switch v := c.Metadata[key].(type) {
+	case map[string]float64:
+		return v, true
+	case map[string]interface{}:
+		scores := make(map[string]float64, len(v))
+		for name, raw := range v {
+			f, ok := toFloat(raw)
+			if !ok {
+				return nil, false
+			}
+			scores[name] = f
+		}
+		return scores, true
+	}
+	return nil, false
+}
+
+// SetMeta sets a metadata value, creating the map if needed
+func (c *Clip) SetMeta(key string, value interface{}) {
+	if c.Metadata == nil {
+		c.Metadata = make(map[string]interface{})
+	}
+	c.Metadata[key] = value
+}
@@ -0,0 +1,95 @@
+package clips
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMetaAccessors(t *testing.T) {
+	c := &Clip{}
+	c.SetMeta("scene_changes", 4)
+	c.SetMeta("peak_volume", -3.5)
+	c.SetMeta("label", "funny")
+
+	if n, ok := c.MetaInt("scene_changes"); !ok || n != 4 {
+		t.Errorf("MetaInt(scene_changes) = %d, %v", n, ok)
+	}
+	if f, ok := c.MetaFloat("scene_changes"); !ok || f != 4 {
+		t.Errorf("MetaFloat(scene_changes) = %g, %v", f, ok)
+	}
+	if f, ok := c.MetaFloat("peak_volume"); !ok || f != -3.5 {
+		t.Errorf("MetaFloat(peak_volume) = %g, %v", f, ok)
+	}
+	if _, ok := c.MetaInt("peak_volume"); ok {
+		t.Error("MetaInt should reject a fractional value")
+	}
+	if s, ok := c.MetaString("label"); !ok || s != "funny" {
+		t.Errorf("MetaString(label) = %q, %v", s, ok)
+	}
+	if _, ok := c.MetaFloat("label"); ok {
+		t.Error("MetaFloat should reject a string")
+	}
+	if _, ok := c.MetaFloat("missing"); ok {
+		t.Error("MetaFloat should report a missing key")
+	}
+}
+
+func TestMetaAccessorsAfterJSON(t *testing.T) {
+	c := &Clip{}
+	c.SetMeta("scene_changes", 4)
+	c.SetMeta("motion_intensity", 0.25)
+
+	data, err := json.Marshal(c.Metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded := &Clip{}
+	if err := json.Unmarshal(data, &loaded.Metadata); err != nil {
+		t.Fatal(err)
+	}
+
+	// The int came back as float64
+	if _, ok := loaded.Metadata["scene_changes"].(int); ok {
+		t.Fatal("expected JSON to decode the count as float64")
+	}
+	if n, ok := loaded.MetaInt("scene_changes"); !ok || n != 4 {
+		t.Errorf("MetaInt(scene_changes) = %d, %v", n, ok)
+	}
+	if f, ok := loaded.MetaFloat("motion_intensity"); !ok || f != 0.25 {
+		t.Errorf("MetaFloat(motion_intensity) = %g, %v", f, ok)
+	}
+}
+
+func TestMetaNilMap(t *testing.T) {
+	var c Clip
+	if _, ok := c.MetaFloat("x"); ok {
+		t.Error("expected no value from a nil map")
+	}
+}
+
+func TestMetaScoresAfterJSON(t *testing.T) {
+	c := &Clip{}
+	c.SetMeta("scores", map[string]float64{"heuristic": 0.2, "clip": 0.5})
+
+	if scores, ok := c.MetaScores("scores"); !ok || scores["clip"] != 0.5 {
+		t.Errorf("MetaScores = %v, %v", scores, ok)
+	}
+
+	data, err := json.Marshal(c.Metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded := &Clip{}
+	if err := json.Unmarshal(data, &loaded.Metadata); err != nil {
+		t.Fatal(err)
+	}
+	scores, ok := loaded.MetaScores("scores")
+	if !ok || len(scores) != 2 || scores["heuristic"] != 0.2 {
+		t.Errorf("MetaScores after JSON = %v, %v", scores, ok)
+	}
+
+	loaded.SetMeta("bad", map[string]interface{}{"x": "y"})
+	if _, ok := loaded.MetaScores("bad"); ok {
+		t.Error("MetaScores should reject non-numeric scores")
+	}
+}
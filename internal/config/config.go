@@ -2,6 +2,10 @@ package config
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 
@@ -33,16 +37,37 @@ type Config struct {
 }
 
 type AIConfig struct {
-	ModelPath      string  `yaml:"model_path" env:"AI_MODEL_PATH"`
+	ModelPath string `yaml:"model_path" env:"AI_MODEL_PATH"`
+	// Base URL `models download` fetches <file> and <file>.sha256 from
+	ModelBaseURL   string  `yaml:"model_base_url" env:"AI_MODEL_BASE_URL"`
 	UseModel       bool    `yaml:"use_model" env:"AI_USE_MODEL"`
 	WhisperModel   string  `yaml:"whisper_model"`
 	ScoreThreshold float64 `yaml:"score_threshold"`
+	// Relative weight per scorer (heuristic, aesthetic, clip, keyword, model, face, audio_event); normalized
+	// over the scorers that are actually available
+	ScoringWeights map[string]float64 `yaml:"scoring_weights"`
+	// Transcript phrases and their weights for keyword scoring
+	Keywords map[string]float64 `yaml:"keywords"`
+	// Where candidate clips are cut: scene, silence, hybrid or window
+	CandidateStrategy string `yaml:"candidate_strategy" env:"AI_CANDIDATE_STRATEGY"`
+	// ONNX Runtime backend: cpu, cuda, coreml or directml
+	ExecutionProvider string `yaml:"execution_provider" env:"AI_EXECUTION_PROVIDER"`
+	// Keyframes per CLIP inference run (1 = one at a time, least memory)
+	BatchSize int `yaml:"batch_size" env:"AI_BATCH_SIZE"`
+	// ONNX face detector for face scoring ("" = face_detector.onnx in the model dir)
+	FaceModel string `yaml:"face_model" env:"AI_FACE_MODEL"`
+	// ONNX audio classifier for crowd-reaction scoring ("" = audio_events.onnx in the model dir)
+	AudioEventModel string `yaml:"audio_event_model" env:"AI_AUDIO_EVENT_MODEL"`
+	// What a failing scorer does to a clip: skip (score without it) or strict (fail the clip)
+	ScorerFailures string `yaml:"scorer_failures" env:"AI_SCORER_FAILURES"`
 }
 
 type FFmpegConfig struct {
 	BinaryPath string `yaml:"binary_path"`
 	Threads    int    `yaml:"threads"`
 	Preset     string `yaml:"preset"`
+	// Retries after transient failures (network or device I/O errors)
+	MaxRetries int `yaml:"max_retries"`
 }
 
 type SubtitleConfig struct {
@@ -53,35 +78,58 @@ type SubtitleConfig struct {
 }
 
 type OverlayConfig struct {
-	DefaultOverlay string            `yaml:"default_overlay"`
-	Overlays       map[string]string `yaml:"overlays"`
+	// Directory holding preset overlay clips (<dir>/<preset>.mp4)
+	Dir            string `yaml:"dir"`
+	DefaultOverlay string `yaml:"default_overlay"`
+	// Seed for random overlay selection (0 = different every run)
+	Seed     int64             `yaml:"seed"`
+	Overlays map[string]string `yaml:"overlays"`
 }
 
-// Load reads configuration from file or returns defaults
+// Load reads configuration from file or returns defaults.
+// Environment variables named by `env` tags override file values.
 func Load(path string) (*Config, error) {
+	cfg, err := Read(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// Read is Load without validation, for callers that override values
+// (e.g. from flags) and validate the result themselves
+func Read(path string) (*Config, error) {
 	cfg := defaultConfig()
 
-	if path == "" {
-		path = findConfigFile()
+	if path = ResolvePath(path); path != "" {
+		if err := loadFile(path, cfg); err != nil {
+			return nil, err
+		}
 	}
 
-	if path == "" {
-		return cfg, nil
+	if err := applyEnv(cfg); err != nil {
+		return nil, err
 	}
 
+	return cfg, nil
+}
+
+// loadFile merges a YAML file into cfg; a missing file is not an error
+func loadFile(path string, cfg *Config) error {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return cfg, nil
+			return nil
 		}
-		return nil, err
-	}
-
-	if err := yaml.Unmarshal(data, cfg); err != nil {
-		return nil, err
+		return err
 	}
 
-	return cfg, nil
+	return yaml.Unmarshal(data, cfg)
 }
 
 // Save writes configuration to file
@@ -104,11 +152,32 @@ func defaultConfig() *Config {
 			UseModel:       true,
 			WhisperModel:   "base",
 			ScoreThreshold: 0.7,
+			ScoringWeights: map[string]float64{
+				"heuristic": 0.3,
+				"aesthetic": 0.2,
+				"clip":      0.5,
+				"keyword":   0.2,
+			},
+			Keywords: map[string]float64{
+				"[laughter]":   1.0,
+				"haha":         0.8,
+				"wait for it":  1.0,
+				"no way":       0.8,
+				"oh my god":    0.8,
+				"what the":     0.6,
+				"wtf":          0.6,
+				"did you know": 0.6,
+			},
+			CandidateStrategy: "scene",
+			ExecutionProvider: "cpu",
+			BatchSize:         16,
+			ScorerFailures:    "skip",
 		},
 		FFmpeg: FFmpegConfig{
 			BinaryPath: "ffmpeg",
 			Threads:    0,
 			Preset:     "medium",
+			MaxRetries: 2,
 		},
 		Subtitles: SubtitleConfig{
 			FontName:     "Arial",
@@ -117,12 +186,22 @@ func defaultConfig() *Config {
 			OutlineWidth: 2,
 		},
 		Overlays: OverlayConfig{
+			Dir:            "./assets/overlays",
 			DefaultOverlay: "none",
 			Overlays:       make(map[string]string),
 		},
 	}
 }
 
+// ResolvePath returns the file Load reads for path: path itself when set,
+// otherwise the first existing candidate ("" means defaults only)
+func ResolvePath(path string) string {
+	if path != "" {
+		return path
+	}
+	return findConfigFile()
+}
+
 func findConfigFile() string {
 	candidates := []string{
 		"./config.yaml",
@@ -151,3 +230,29 @@ func FromContext(ctx context.Context) *Config {
 	}
 	return defaultConfig()
 }
+
+// Map returns the config as nested maps keyed by its YAML field names, so
+// other encoders (e.g. JSON) use the names users write in config files
+func (c *Config) Map() (map[string]interface{}, error) {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Hash returns a short fingerprint of the config's values, for telling
+// apart outputs produced with different settings
+func (c *Config) Hash() string {
+	// Map keys are sorted by encoding/json, so equal configs hash equally
+	data, err := json.Marshal(c)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,143 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeConfig(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadEnvOverridesFile(t *testing.T) {
+	path := writeConfig(t, "ai:\n  model_path: ./from-file\n  use_model: true\n")
+
+	t.Setenv("AI_MODEL_PATH", "/from/env")
+	t.Setenv("AI_USE_MODEL", "false")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if cfg.AI.ModelPath != "/from/env" {
+		t.Errorf("expected env model path, got %q", cfg.AI.ModelPath)
+	}
+	if cfg.AI.UseModel {
+		t.Error("expected AI_USE_MODEL=false to override file value")
+	}
+}
+
+func TestLoadWithoutEnvKeepsFileValue(t *testing.T) {
+	path := writeConfig(t, "ai:\n  model_path: ./from-file\n")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.AI.ModelPath != "./from-file" {
+		t.Errorf("expected file model path, got %q", cfg.AI.ModelPath)
+	}
+}
+
+func TestLoadInvalidEnvValue(t *testing.T) {
+	path := writeConfig(t, "")
+	t.Setenv("AI_USE_MODEL", "maybe")
+
+	if _, err := Load(path); err == nil {
+		t.Error("expected error for unparseable bool")
+	}
+}
+
+func TestValidateDefaults(t *testing.T) {
+	if err := defaultConfig().Validate(); err != nil {
+		t.Errorf("default config should be valid: %v", err)
+	}
+}
+
+func TestValidateReportsAllErrors(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Concurrency = -1
+	cfg.FFmpeg.Threads = -2
+	cfg.FFmpeg.Preset = "meduim"
+	cfg.AI.ScoreThreshold = 1.5
+	cfg.Subtitles.FontSize = 0
+	cfg.AI.CandidateStrategy = "vibes"
+	cfg.AI.ExecutionProvider = "tpu"
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+
+	for _, want := range []string{"concurrency", "ffmpeg.threads", "meduim", "score_threshold", "font_size", "vibes", "tpu"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q should mention %q", err, want)
+		}
+	}
+}
+
+func TestResolvePath(t *testing.T) {
+	if got := ResolvePath("/explicit.yaml"); got != "/explicit.yaml" {
+		t.Errorf("explicit path: got %q", got)
+	}
+
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	wd, _ := os.Getwd()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	if got := ResolvePath(""); got != "" {
+		t.Errorf("no candidates: got %q", got)
+	}
+
+	if err := os.WriteFile("config.yml", nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := ResolvePath(""); got != "./config.yml" {
+		t.Errorf("expected ./config.yml, got %q", got)
+	}
+}
+
+func TestHash(t *testing.T) {
+	a, b := defaultConfig(), defaultConfig()
+	if a.Hash() == "" || a.Hash() != b.Hash() {
+		t.Fatalf("equal configs should hash equally: %q vs %q", a.Hash(), b.Hash())
+	}
+
+	b.AI.Keywords["new phrase"] = 0.5
+	if a.Hash() == b.Hash() {
+		t.Error("expected different hash after changing a keyword")
+	}
+}
+
+func TestMapUsesYAMLNames(t *testing.T) {
+	m, err := defaultConfig().Map()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{`"work_dir"`, `"ffmpeg"`, `"max_retries"`, `"score_threshold"`} {
+		if !strings.Contains(string(data), key) {
+			t.Errorf("expected key %s in %s", key, data)
+		}
+	}
+	if strings.Contains(string(data), `"WorkDir"`) {
+		t.Errorf("expected no Go field names in %s", data)
+	}
+}
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// fieldComments explains each config key in the starter file, keyed by
+// dotted YAML path
+var fieldComments = map[string]string{
+	"work_dir":    "Where intermediate project files live",
+	"temp_dir":    "Scratch space for ffmpeg, etc.",
+	"concurrency": "Number of concurrent workers",
+
+	"ai":                    "AI scoring settings",
+	"ai.model_path":         "Directory (or file) holding the ONNX models",
+	"ai.model_base_url":     "Where `slopcannon models download` fetches model files from;\neach file needs a \"<file>.sha256\" checksum next to it",
+	"ai.use_model":          "Whether to use AI model-based scoring (false = heuristic and aesthetic only)",
+	"ai.whisper_model":      "Whisper STT model name",
+	"ai.score_threshold":    "Minimum score to keep a clip (0-1)",
+	"ai.scoring_weights":    "Relative weight of each scorer; normalized over the scorers that load",
+	"ai.keywords":           "Transcript phrases that make a clip more shareable, with weights.\nOnly used when analyze is given --transcript",
+	"ai.candidate_strategy": "Where candidate clips are cut: scene, silence, hybrid or window",
+	"ai.execution_provider": "ONNX Runtime backend: cpu, cuda, coreml or directml",
+	"ai.batch_size":         "Keyframes scored per CLIP inference run (1 = one at a time, least memory)",
+	"ai.face_model":         "ONNX face detector used for face scoring (empty = face_detector.onnx in model_path)",
+	"ai.scorer_failures":    "What a failing scorer does to a clip: skip (score it with the others)\nor strict (the clip scores 0)",
+	"ai.audio_event_model":  "ONNX audio classifier that finds laughter, applause and cheering\n(empty = audio_events.onnx in model_path)",
+
+	"ffmpeg":             "FFmpeg settings",
+	"ffmpeg.binary_path": "ffmpeg binary name, full path, or directory holding a pinned build.\nffprobe must sit next to it",
+	"ffmpeg.threads":     "Number of threads to use (0 = ffmpeg decides)",
+	"ffmpeg.preset":      "Default encoding preset for renders that don't set one",
+	"ffmpeg.max_retries": "Times to re-run ffmpeg after a transient (network or I/O) failure",
+
+	"subtitles":               "Caption styling",
+	"subtitles.font_name":     "Font family",
+	"subtitles.font_size":     "Font size in points",
+	"subtitles.font_color":    "Text color (#RRGGBB)",
+	"subtitles.outline_width": "Outline thickness in pixels",
+
+	"overlays":                 "Gameplay and graphic overlays",
+	"overlays.dir":             "Directory with preset gameplay clips (<dir>/<name>.mp4)",
+	"overlays.default_overlay": "Default overlay name to use (or \"none\")",
+	"overlays.seed":            "Seed for --overlay-strategy random (0 = different each run)",
+	"overlays.overlays":        "Named overlays you can reference by key, e.g. watermark: ./assets/overlays/watermark.png",
+}
+
+// DefaultYAML returns the default config as YAML with a comment on every field
+func DefaultYAML() ([]byte, error) {
+	var doc yaml.Node
+	if err := doc.Encode(defaultConfig()); err != nil {
+		return nil, err
+	}
+	commentMapping(&doc, "")
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteDefault writes the commented default config to path. An existing
+// file is only replaced when force is set.
+func WriteDefault(path string, force bool) error {
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if force {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+
+	data, err := DefaultYAML()
+	if err != nil {
+		return fmt.Errorf("failed to encode default config: %w", err)
+	}
+
+	f, err := os.OpenFile(path, flags, 0644)
+	if err != nil {
+		if os.IsExist(err) {
+			return fmt.Errorf("%s already exists (use --force to overwrite)", path)
+		}
+		return err
+	}
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// commentMapping attaches fieldComments to the keys of a mapping node,
+// recursing into nested structs
+func commentMapping(node *yaml.Node, prefix string) {
+	if node.Kind == yaml.DocumentNode {
+		for _, child := range node.Content {
+			commentMapping(child, prefix)
+		}
+		return
+	}
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		path := key.Value
+		if prefix != "" {
+			path = prefix + "." + key.Value
+		}
+
+		if comment, ok := fieldComments[path]; ok {
+			key.HeadComment = comment
+		}
+		// Only recurse into config sections, not user maps like keywords
+		if prefix == "" {
+			commentMapping(value, path)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWriteDefaultRoundTrips(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := WriteDefault(path, false); err != nil {
+		t.Fatalf("WriteDefault failed: %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !reflect.DeepEqual(cfg, defaultConfig()) {
+		t.Errorf("loaded config differs from defaults:\n%+v\nwant\n%+v", cfg, defaultConfig())
+	}
+}
+
+func TestWriteDefaultRefusesOverwrite(t *testing.T) {
+	path := writeConfig(t, "concurrency: 2\n")
+
+	if err := WriteDefault(path, false); err == nil {
+		t.Fatal("expected error for existing file")
+	}
+	if data, _ := os.ReadFile(path); string(data) != "concurrency: 2\n" {
+		t.Errorf("existing file was modified: %q", data)
+	}
+
+	if err := WriteDefault(path, true); err != nil {
+		t.Fatalf("WriteDefault with force failed: %v", err)
+	}
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), "concurrency: 4") {
+		t.Errorf("expected defaults after force, got:\n%s", data)
+	}
+}
+
+func TestDefaultYAMLCommentsEveryField(t *testing.T) {
+	data, err := DefaultYAML()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for path := range fieldComments {
+		first := strings.SplitN(fieldComments[path], "\n", 2)[0]
+		if !strings.Contains(string(data), "# "+first) {
+			t.Errorf("missing comment for %s", path)
+		}
+	}
+}
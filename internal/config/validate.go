@@ -0,0 +1,95 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// validPresets lists the x264/x265 encoding presets ffmpeg accepts
+var validPresets = []string{
+	"ultrafast", "superfast", "veryfast", "faster", "fast",
+	"medium", "slow", "slower", "veryslow", "placebo",
+}
+
+// validCandidateStrategies lists the detector's candidate strategies
+var validCandidateStrategies = []string{"scene", "silence", "hybrid", "window"}
+
+// validScorerFailureModes lists how the composite scorer may handle failures
+var validScorerFailureModes = []string{"skip", "strict"}
+
+// validExecutionProviders lists the ONNX Runtime providers the scorers support
+var validExecutionProviders = []string{"cpu", "cuda", "coreml", "directml"}
+
+// Validate checks the config for values that would misbehave later.
+// All problems are reported together.
+func (c *Config) Validate() error {
+	var errs []error
+
+	if c.Concurrency <= 0 {
+		errs = append(errs, fmt.Errorf("concurrency must be greater than 0 (got %d)", c.Concurrency))
+	}
+
+	if c.FFmpeg.Threads < 0 {
+		errs = append(errs, fmt.Errorf("ffmpeg.threads must be 0 (auto) or positive (got %d)", c.FFmpeg.Threads))
+	}
+
+	if c.FFmpeg.MaxRetries < 0 {
+		errs = append(errs, fmt.Errorf("ffmpeg.max_retries must not be negative (got %d)", c.FFmpeg.MaxRetries))
+	}
+
+	if c.FFmpeg.Preset != "" && !contains(validPresets, c.FFmpeg.Preset) {
+		errs = append(errs, fmt.Errorf("ffmpeg.preset %q is not a valid preset (one of: %s)",
+			c.FFmpeg.Preset, strings.Join(validPresets, ", ")))
+	}
+
+	if c.AI.ScoreThreshold < 0 || c.AI.ScoreThreshold > 1 {
+		errs = append(errs, fmt.Errorf("ai.score_threshold must be between 0 and 1 (got %g)", c.AI.ScoreThreshold))
+	}
+
+	for name, weight := range c.AI.ScoringWeights {
+		if weight < 0 {
+			errs = append(errs, fmt.Errorf("ai.scoring_weights.%s must not be negative (got %g)", name, weight))
+		}
+	}
+
+	for keyword, weight := range c.AI.Keywords {
+		if weight < 0 {
+			errs = append(errs, fmt.Errorf("ai.keywords[%q] must not be negative (got %g)", keyword, weight))
+		}
+	}
+
+	if c.AI.CandidateStrategy != "" && !contains(validCandidateStrategies, c.AI.CandidateStrategy) {
+		errs = append(errs, fmt.Errorf("ai.candidate_strategy %q is not valid (one of: %s)",
+			c.AI.CandidateStrategy, strings.Join(validCandidateStrategies, ", ")))
+	}
+
+	if c.AI.ExecutionProvider != "" && !contains(validExecutionProviders, c.AI.ExecutionProvider) {
+		errs = append(errs, fmt.Errorf("ai.execution_provider %q is not valid (one of: %s)",
+			c.AI.ExecutionProvider, strings.Join(validExecutionProviders, ", ")))
+	}
+
+	if c.AI.ScorerFailures != "" && !contains(validScorerFailureModes, c.AI.ScorerFailures) {
+		errs = append(errs, fmt.Errorf("ai.scorer_failures %q is not valid (one of: %s)",
+			c.AI.ScorerFailures, strings.Join(validScorerFailureModes, ", ")))
+	}
+
+	if c.AI.BatchSize < 0 {
+		errs = append(errs, fmt.Errorf("ai.batch_size must be 0 (default) or positive (got %d)", c.AI.BatchSize))
+	}
+
+	if c.Subtitles.FontSize <= 0 {
+		errs = append(errs, fmt.Errorf("subtitles.font_size must be greater than 0 (got %d)", c.Subtitles.FontSize))
+	}
+
+	return errors.Join(errs...)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
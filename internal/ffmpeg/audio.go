@@ -7,6 +7,9 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
+
+	"github.com/keagan/slopcannon/pkg/util"
 )
 
 // AudioFormat defines audio extraction format options
@@ -36,29 +39,61 @@ func (e *Executor) ExtractAudio(ctx context.Context, input, output string, forma
 		Int("sample_rate", format.SampleRate).
 		Msg("extracting audio")
 
-	args := []string{
+	opts := RunOptions{
+		Args:            audioArgs([]string{"-i", input}, output, format),
+		ProgressHandler: progressFunc,
+		TotalDuration:   e.probeDuration(ctx, input, progressFunc),
+		LogHandler: func(line string) {
+			e.logger.Debug().Str("ffmpeg", line).Msg("audio extraction")
+		},
+	}
+
+	return e.Run(ctx, opts)
+}
+
+// ExtractAudioRange extracts the audio between start and end, e.g. to
+// classify a single clip without decoding the whole input
+func (e *Executor) ExtractAudioRange(ctx context.Context, input, output string, start, end time.Duration, format AudioFormat) error {
+	if end <= start {
+		return fmt.Errorf("invalid audio range: end must be after start")
+	}
+
+	e.logger.Debug().
+		Str("input", input).
+		Str("output", output).
+		Dur("start", start).
+		Dur("end", end).
+		Msg("extracting audio range")
+
+	// Seeking before -i is fast and still sample-accurate for audio
+	inputArgs := []string{
+		"-ss", util.FormatDuration(start),
+		"-t", util.FormatDuration(end - start),
 		"-i", input,
+	}
+
+	return e.Run(ctx, RunOptions{
+		Args: audioArgs(inputArgs, output, format),
+		LogHandler: func(line string) {
+			e.logger.Debug().Str("ffmpeg", line).Msg("audio range extraction")
+		},
+	})
+}
+
+// audioArgs appends audio-only encoding arguments and output to inputArgs
+func audioArgs(inputArgs []string, output string, format AudioFormat) []string {
+	args := append(inputArgs,
 		"-vn", // no video
 		"-acodec", format.Codec,
 		"-ar", fmt.Sprintf("%d", format.SampleRate),
 		"-ac", fmt.Sprintf("%d", format.Channels),
-	}
+	)
 
 	if format.Bitrate != "" {
 		args = append(args, "-b:a", format.Bitrate)
 	}
 
-	args = append(args, output)
-
-	opts := RunOptions{
-		Args:            args,
-		ProgressHandler: progressFunc,
-		LogHandler: func(line string) {
-			e.logger.Debug().Str("ffmpeg", line).Msg("audio extraction")
-		},
-	}
-
-	return e.Run(ctx, opts)
+	return append(args, output)
 }
 
 // SilenceSegment represents a period of silence in audio
@@ -86,6 +121,7 @@ func (e *Executor) DetectSilence(ctx context.Context, input string, noiseThresho
 			"-f", "null",
 			"-",
 		},
+		MinLogLevel: LogLevelInfo,
 		LogHandler: func(line string) {
 			mu.Lock()
 			stderrBuf.WriteString(line + "\n")
@@ -110,9 +146,7 @@ func (e *Executor) DetectSilence(ctx context.Context, input string, noiseThresho
 			return nil, ctx.Err()
 		}
 		// Only ignore the specific null output errors
-		if !strings.Contains(err.Error(), "Conversion failed") &&
-			!strings.Contains(err.Error(), "Invalid return value") &&
-			!strings.Contains(err.Error(), "Output file is empty") {
+		if ErrorCategoryOf(err) != CategoryEmptyOutput {
 			return nil, fmt.Errorf("silence detection failed: %w", err)
 		}
 	}
@@ -184,6 +218,7 @@ func (e *Executor) AnalyzeVolume(ctx context.Context, input string) (*VolumeStat
 			"-f", "null",
 			"-",
 		},
+		MinLogLevel: LogLevelInfo,
 		LogHandler: func(line string) {
 			mu.Lock()
 			stderrBuf.WriteString(line + "\n")
@@ -204,9 +239,7 @@ func (e *Executor) AnalyzeVolume(ctx context.Context, input string) (*VolumeStat
 		if ctx.Err() != nil {
 			return nil, ctx.Err()
 		}
-		if !strings.Contains(err.Error(), "Conversion failed") &&
-			!strings.Contains(err.Error(), "Invalid return value") &&
-			!strings.Contains(err.Error(), "Output file is empty") {
+		if ErrorCategoryOf(err) != CategoryEmptyOutput {
 			return nil, fmt.Errorf("volume analysis failed: %w", err)
 		}
 	}
@@ -242,30 +275,11 @@ func (e *Executor) parseVolumeOutput(output string) (*VolumeStats, error) {
 	return stats, nil
 }
 
-// NormalizeAudio applies audio normalization to a file
+// NormalizeAudio applies single-pass loudness normalization to a file.
+// See NormalizeAudioWithOptions for the more accurate two-pass mode.
 func (e *Executor) NormalizeAudio(ctx context.Context, input, output string, targetLevel float64, progressFunc ProgressFunc) error {
-	e.logger.Info().
-		Str("input", input).
-		Str("output", output).
-		Float64("target_level", targetLevel).
-		Msg("normalizing audio")
-
-	filter := fmt.Sprintf("loudnorm=I=%f:TP=-1.5:LRA=11", targetLevel)
-
-	args := []string{
-		"-i", input,
-		"-af", filter,
-		"-c:v", "copy", // copy video stream
-		output,
-	}
-
-	opts := RunOptions{
-		Args:            args,
-		ProgressHandler: progressFunc,
-		LogHandler: func(line string) {
-			e.logger.Debug().Str("ffmpeg", line).Msg("audio normalization")
-		},
-	}
-
-	return e.Run(ctx, opts)
+	return e.NormalizeAudioWithOptions(ctx, input, output, NormalizeOptions{
+		TargetLevel:  targetLevel,
+		ProgressFunc: progressFunc,
+	})
 }
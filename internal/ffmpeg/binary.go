@@ -0,0 +1,109 @@
+package ffmpeg
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/keagan/slopcannon/internal/config"
+	"github.com/rs/zerolog"
+)
+
+// BundledDir is where a pinned ffmpeg build may ship, relative to the
+// working directory or the slopcannon executable
+const BundledDir = "assets/ffmpeg"
+
+// NewWithConfig creates an executor from the ffmpeg config: BinaryPath
+// picks the ffmpeg build (ffprobe is expected next to it) and Preset is
+// the default for encodes that don't set one. An unset BinaryPath uses PATH.
+func NewWithConfig(logger zerolog.Logger, cfg config.FFmpegConfig) (*Executor, error) {
+	ffmpegPath, ffprobePath, err := resolveBinaries(cfg.BinaryPath, bundledDirs())
+	if err != nil {
+		return nil, err
+	}
+
+	e := newExecutor(logger, ffmpegPath, ffprobePath, cfg.Threads)
+	e.preset = cfg.Preset
+	e.maxRetries = cfg.MaxRetries
+	e.logger.Debug().
+		Str("ffmpeg", ffmpegPath).
+		Str("ffprobe", ffprobePath).
+		Msg("using ffmpeg binaries")
+	return e, nil
+}
+
+// resolveBinaries finds ffmpeg and the matching ffprobe. A bare name (the
+// default "ffmpeg") prefers a bundled build in one of bundled and falls
+// back to PATH; a path (file or directory) is used as given.
+func resolveBinaries(binaryPath string, bundled []string) (string, string, error) {
+	if binaryPath == "" {
+		binaryPath = "ffmpeg"
+	}
+
+	if !strings.ContainsAny(binaryPath, `/\`) {
+		for _, dir := range bundled {
+			candidate := filepath.Join(dir, binaryPath)
+			if ffmpegPath, err := exec.LookPath(candidate); err == nil {
+				return probeBeside(ffmpegPath)
+			}
+		}
+
+		ffmpegPath, err := exec.LookPath(binaryPath)
+		if err != nil {
+			return "", "", fmt.Errorf("%s not found in PATH: %w", binaryPath, err)
+		}
+		ffprobePath, err := exec.LookPath(ffprobeName(binaryPath))
+		if err != nil {
+			return "", "", fmt.Errorf("%s not found in PATH: %w", ffprobeName(binaryPath), err)
+		}
+		return ffmpegPath, ffprobePath, nil
+	}
+
+	path := binaryPath
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, "ffmpeg")
+	}
+	ffmpegPath, err := exec.LookPath(path)
+	if err != nil {
+		return "", "", fmt.Errorf("ffmpeg binary %q not usable: %w", binaryPath, err)
+	}
+	return probeBeside(ffmpegPath)
+}
+
+// probeBeside returns ffmpegPath with the ffprobe from the same directory
+func probeBeside(ffmpegPath string) (string, string, error) {
+	dir, name := filepath.Split(ffmpegPath)
+	ffprobePath, err := exec.LookPath(filepath.Join(dir, ffprobeName(name)))
+	if err != nil {
+		return "", "", fmt.Errorf("ffprobe not found next to %s: %w", ffmpegPath, err)
+	}
+	return ffmpegPath, ffprobePath, nil
+}
+
+// ffprobeName derives the ffprobe file name from an ffmpeg one, keeping
+// any version suffix or extension (ffmpeg-6.1.exe -> ffprobe-6.1.exe)
+func ffprobeName(ffmpegName string) string {
+	if strings.Contains(ffmpegName, "ffmpeg") {
+		return strings.Replace(ffmpegName, "ffmpeg", "ffprobe", 1)
+	}
+	return "ffprobe" + filepath.Ext(ffmpegName)
+}
+
+// bundledDirs lists where a bundled ffmpeg may live
+func bundledDirs() []string {
+	dirs := []string{BundledDir}
+	if self, err := os.Executable(); err == nil {
+		dirs = append(dirs, filepath.Join(filepath.Dir(self), BundledDir))
+	}
+	return dirs
+}
+
+// defaultPreset is the encoding preset for renders that don't set one
+func (e *Executor) defaultPreset() string {
+	if e.preset != "" {
+		return e.preset
+	}
+	return DefaultPreset
+}
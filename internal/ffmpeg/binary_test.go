@@ -0,0 +1,93 @@
+package ffmpeg
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// fakeBinaries writes empty executables named names into a temp dir
+func fakeBinaries(t *testing.T, names ...string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake executables need a unix exec bit")
+	}
+
+	dir := t.TempDir()
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestResolveBinaries(t *testing.T) {
+	dir := fakeBinaries(t, "ffmpeg", "ffprobe", "ffmpeg-6.1", "ffprobe-6.1")
+
+	tests := []struct {
+		name        string
+		binaryPath  string
+		bundled     []string
+		wantFFmpeg  string
+		wantFFprobe string
+	}{
+		{"file path", filepath.Join(dir, "ffmpeg"), nil, "ffmpeg", "ffprobe"},
+		{"versioned file", filepath.Join(dir, "ffmpeg-6.1"), nil, "ffmpeg-6.1", "ffprobe-6.1"},
+		{"directory", dir, nil, "ffmpeg", "ffprobe"},
+		{"bundled", "ffmpeg", []string{filepath.Join(dir, "missing"), dir}, "ffmpeg", "ffprobe"},
+		{"bundled default", "", []string{dir}, "ffmpeg", "ffprobe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ffmpegPath, ffprobePath, err := resolveBinaries(tt.binaryPath, tt.bundled)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if want := filepath.Join(dir, tt.wantFFmpeg); ffmpegPath != want {
+				t.Errorf("ffmpeg = %q, want %q", ffmpegPath, want)
+			}
+			if want := filepath.Join(dir, tt.wantFFprobe); ffprobePath != want {
+				t.Errorf("ffprobe = %q, want %q", ffprobePath, want)
+			}
+		})
+	}
+}
+
+func TestResolveBinariesMissingProbe(t *testing.T) {
+	dir := fakeBinaries(t, "ffmpeg")
+
+	if _, _, err := resolveBinaries(filepath.Join(dir, "ffmpeg"), nil); err == nil {
+		t.Error("expected error when ffprobe is not next to ffmpeg")
+	}
+	if _, _, err := resolveBinaries(filepath.Join(dir, "nope"), nil); err == nil {
+		t.Error("expected error for a missing binary")
+	}
+}
+
+func TestFFprobeName(t *testing.T) {
+	tests := map[string]string{
+		"ffmpeg":         "ffprobe",
+		"ffmpeg.exe":     "ffprobe.exe",
+		"ffmpeg-6.1":     "ffprobe-6.1",
+		"custom-encoder": "ffprobe",
+	}
+	for in, want := range tests {
+		if got := ffprobeName(in); got != want {
+			t.Errorf("ffprobeName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestDefaultPreset(t *testing.T) {
+	e := &Executor{}
+	if got := e.defaultPreset(); got != DefaultPreset {
+		t.Errorf("defaultPreset() = %q, want %q", got, DefaultPreset)
+	}
+	e.preset = "veryfast"
+	if got := e.defaultPreset(); got != "veryfast" {
+		t.Errorf("defaultPreset() = %q, want veryfast", got)
+	}
+}
@@ -0,0 +1,104 @@
+package ffmpeg
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ErrUnsupported matches (via errors.Is) any MissingCapabilityError
+var ErrUnsupported = errors.New("not supported by this ffmpeg build")
+
+// MissingCapabilityError reports an encoder or filter the ffmpeg build lacks
+type MissingCapabilityError struct {
+	Kind string // "encoder" or "filter"
+	Name string
+}
+
+func (e *MissingCapabilityError) Error() string {
+	return fmt.Sprintf("this ffmpeg build lacks the %s %s", e.Name, e.Kind)
+}
+
+// Is lets errors.Is(err, ErrUnsupported) match
+func (e *MissingCapabilityError) Is(target error) bool {
+	return target == ErrUnsupported
+}
+
+// HasEncoder reports whether the ffmpeg build has the named encoder. When
+// encoder detection failed it optimistically returns true.
+func (e *Executor) HasEncoder(name string) bool {
+	if e.encoders == nil {
+		return true
+	}
+	return e.encoders[name]
+}
+
+// HasFilter reports whether the ffmpeg build has the named filter. The
+// filter list is probed on first use; when probing fails it optimistically
+// returns true.
+func (e *Executor) HasFilter(name string) bool {
+	e.filtersOnce.Do(func() {
+		filters, err := probeFilters(context.Background(), e.ffmpegPath)
+		if err != nil {
+			e.logger.Warn().Err(err).Msg("filter detection failed; assuming all filters are available")
+			return
+		}
+		e.filters = filters
+	})
+
+	if e.filters == nil {
+		return true
+	}
+	return e.filters[name]
+}
+
+// requireEncoders returns a MissingCapabilityError for the first missing
+// encoder; "copy" (stream copy) needs none
+func (e *Executor) requireEncoders(names ...string) error {
+	for _, name := range names {
+		if name != "copy" && !e.HasEncoder(name) {
+			return &MissingCapabilityError{Kind: "encoder", Name: name}
+		}
+	}
+	return nil
+}
+
+// requireFilters returns a MissingCapabilityError for the first missing filter
+func (e *Executor) requireFilters(names ...string) error {
+	for _, name := range names {
+		if !e.HasFilter(name) {
+			return &MissingCapabilityError{Kind: "filter", Name: name}
+		}
+	}
+	return nil
+}
+
+// probeFilters runs `ffmpeg -filters` and returns the available filter names
+func probeFilters(ctx context.Context, ffmpegPath string) (map[string]bool, error) {
+	out, err := exec.CommandContext(ctx, ffmpegPath, "-hide_banner", "-filters").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list filters: %w", err)
+	}
+	return parseFilters(string(out)), nil
+}
+
+// parseFilters extracts filter names from `ffmpeg -filters` output. Filter
+// lines look like " TSC xfade  VV->V  Cross fade ..."; the legend above
+// them has no "->" column.
+func parseFilters(output string) map[string]bool {
+	filters := make(map[string]bool)
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || len(fields[0]) != 3 || !strings.Contains(fields[2], "->") {
+			continue
+		}
+		filters[fields[1]] = true
+	}
+
+	return filters
+}
@@ -0,0 +1,82 @@
+package ffmpeg
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+const cannedFilters = `Filters:
+  T.. = Timeline support
+  .S. = Slice threading
+  ..C = Command support
+  A = Audio input/output
+  V = Video input/output
+  N = Dynamic number and/or type of input/output
+  | = Source or sink filter
+ ... acrossfade        AA->A      Cross fade two input audio streams.
+ T.C afade             A->A       Fade in/out input audio.
+ TSC fade              V->V       Fade in/out input video.
+ ... nullsrc           |->V       Null video source, return unprocessed video frames.
+`
+
+// cannedExecutor returns an executor whose capabilities come from canned output
+func cannedExecutor(encoders, filters string) *Executor {
+	e := &Executor{logger: zerolog.Nop(), encoders: parseEncoders(encoders)}
+	e.filtersOnce.Do(func() {})
+	e.filters = parseFilters(filters)
+	return e
+}
+
+func TestParseFilters(t *testing.T) {
+	filters := parseFilters(cannedFilters)
+
+	for _, name := range []string{"acrossfade", "afade", "fade", "nullsrc"} {
+		if !filters[name] {
+			t.Errorf("expected filter %q to be detected", name)
+		}
+	}
+	if filters["="] || filters["Timeline"] || len(filters) != 4 {
+		t.Errorf("legend lines should not be parsed as filters: %v", filters)
+	}
+}
+
+func TestCapabilityChecks(t *testing.T) {
+	e := cannedExecutor(cannedEncoders, cannedFilters)
+
+	if !e.HasEncoder("libx264") || e.HasEncoder("libx265") {
+		t.Error("unexpected encoder detection")
+	}
+	if !e.HasFilter("fade") || e.HasFilter("xfade") {
+		t.Error("unexpected filter detection")
+	}
+
+	if err := e.requireFilters(transitionFilters(TransitionFade)...); err != nil {
+		t.Errorf("fade should be supported: %v", err)
+	}
+
+	err := e.requireFilters(transitionFilters(TransitionCrossfade)...)
+	if !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("expected ErrUnsupported, got %v", err)
+	}
+	if err.Error() != "this ffmpeg build lacks the xfade filter" {
+		t.Errorf("unexpected message: %v", err)
+	}
+
+	if err := e.requireEncoders("libx264", "aac"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := e.requireEncoders("libx265"); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("expected ErrUnsupported for libx265, got %v", err)
+	}
+}
+
+func TestCapabilitiesUnknownAreAllowed(t *testing.T) {
+	e := &Executor{logger: zerolog.Nop()}
+	e.filtersOnce.Do(func() {})
+
+	if !e.HasEncoder("libx264") || !e.HasFilter("xfade") {
+		t.Error("expected unknown capabilities to be assumed present")
+	}
+}
@@ -0,0 +1,114 @@
+package ffmpeg
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Chroma key defaults: a standard green screen with a soft edge
+const (
+	DefaultKeyColor      = "#00FF00"
+	DefaultKeySimilarity = 0.3
+	DefaultKeyBlend      = 0.1
+)
+
+// ChromaKeyOptions configures green-screen compositing. Position and timing
+// come from the embedded OverlayOptions (Opacity is ignored).
+type ChromaKeyOptions struct {
+	OverlayOptions
+
+	// Color to key out as hex ("#00FF00", "0x00FF00" or "00FF00");
+	// default DefaultKeyColor
+	Color string
+	// Similarity (0-1] widens the range of colors removed; Blend [0-1]
+	// softens the edge. Zero values use the defaults.
+	Similarity float64
+	Blend      float64
+
+	ProgressFunc ProgressFunc
+}
+
+// ChromaKeyOverlay keys the background color out of overlay (e.g. a
+// green-screen webcam clip) and composites it onto base
+func (e *Executor) ChromaKeyOverlay(ctx context.Context, base, overlay, output string, opts ChromaKeyOptions) error {
+	if base == "" {
+		return fmt.Errorf("base path is required")
+	}
+	if overlay == "" {
+		return fmt.Errorf("overlay path is required")
+	}
+	if output == "" {
+		return fmt.Errorf("output path is required")
+	}
+
+	graph, err := buildChromaKeyGraph(opts)
+	if err != nil {
+		return err
+	}
+
+	e.logger.Info().
+		Str("base", base).
+		Str("overlay", overlay).
+		Str("output", output).
+		Msg("applying chroma key overlay")
+
+	return e.runOverlayGraph(ctx, base, []string{overlay}, graph, output, opts.ProgressFunc)
+}
+
+// buildChromaKeyGraph keys input 1 and overlays it on input 0 as [vout]
+func buildChromaKeyGraph(opts ChromaKeyOptions) (string, error) {
+	color := opts.Color
+	if color == "" {
+		color = DefaultKeyColor
+	}
+	key, err := parseHexColor(color)
+	if err != nil {
+		return "", err
+	}
+
+	similarity := opts.Similarity
+	if similarity == 0 {
+		similarity = DefaultKeySimilarity
+	}
+	blend := opts.Blend
+	if blend == 0 {
+		blend = DefaultKeyBlend
+	}
+	if similarity < 0 || similarity > 1 {
+		return "", fmt.Errorf("chroma key similarity must be between 0 and 1 (got %g)", similarity)
+	}
+	if blend < 0 || blend > 1 {
+		return "", fmt.Errorf("chroma key blend must be between 0 and 1 (got %g)", blend)
+	}
+	if opts.End > 0 && opts.End <= opts.Start {
+		return "", fmt.Errorf("chroma key overlay end must be after start")
+	}
+
+	filter := fmt.Sprintf("[0:v][keyed]overlay=%d:%d", opts.X, opts.Y)
+	if enable := overlayEnable(opts.OverlayOptions); enable != "" {
+		filter += ":enable='" + enable + "'"
+	}
+
+	return strings.Join([]string{
+		fmt.Sprintf("[1:v]chromakey=%s:%.2f:%.2f[keyed]", key, similarity, blend),
+		filter + "[vout]",
+	}, ";"), nil
+}
+
+// parseHexColor validates an RGB hex color and returns it in ffmpeg's
+// 0xRRGGBB form
+func parseHexColor(color string) (string, error) {
+	hex := strings.TrimPrefix(color, "#")
+	if len(hex) == len(color) {
+		hex = strings.TrimPrefix(strings.TrimPrefix(color, "0x"), "0X")
+	}
+	if len(hex) != 6 {
+		return "", fmt.Errorf("invalid hex color %q: want 6 hex digits like #00FF00", color)
+	}
+	if _, err := strconv.ParseUint(hex, 16, 32); err != nil {
+		return "", fmt.Errorf("invalid hex color %q: want 6 hex digits like #00FF00", color)
+	}
+	return "0x" + strings.ToUpper(hex), nil
+}
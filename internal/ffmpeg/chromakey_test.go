@@ -0,0 +1,59 @@
+package ffmpeg
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildChromaKeyGraph(t *testing.T) {
+	graph, err := buildChromaKeyGraph(ChromaKeyOptions{
+		OverlayOptions: OverlayOptions{X: 40, Y: 800, Start: 2 * time.Second},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "[1:v]chromakey=0x00FF00:0.30:0.10[keyed];[0:v][keyed]overlay=40:800:enable='gte(t,2.00)'[vout]"
+	if graph != want {
+		t.Errorf("graph = %q, want %q", graph, want)
+	}
+
+	graph, err = buildChromaKeyGraph(ChromaKeyOptions{Color: "#0047bb", Similarity: 0.15, Blend: 0.05})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(graph, "[1:v]chromakey=0x0047BB:0.15:0.05[keyed]") {
+		t.Errorf("unexpected key filter in %q", graph)
+	}
+}
+
+func TestBuildChromaKeyGraphInvalid(t *testing.T) {
+	tests := []ChromaKeyOptions{
+		{Color: "green"},
+		{Color: "#00FF0"},
+		{Color: "#GGFF00"},
+		{Similarity: 1.5},
+		{Blend: -0.1},
+		{OverlayOptions: OverlayOptions{Start: 5 * time.Second, End: 2 * time.Second}},
+	}
+
+	for _, opts := range tests {
+		if _, err := buildChromaKeyGraph(opts); err == nil {
+			t.Errorf("expected error for %+v", opts)
+		}
+	}
+}
+
+func TestParseHexColor(t *testing.T) {
+	for _, in := range []string{"#00ff00", "0x00FF00", "00FF00"} {
+		got, err := parseHexColor(in)
+		if err != nil {
+			t.Errorf("parseHexColor(%q): %v", in, err)
+			continue
+		}
+		if got != "0x00FF00" {
+			t.Errorf("parseHexColor(%q) = %q, want 0x00FF00", in, got)
+		}
+	}
+}
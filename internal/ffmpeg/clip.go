@@ -16,7 +16,8 @@ type ClipOptions struct {
 	CopyCodec    bool // If true, use -c copy for fast extraction
 	VideoCodec   string
 	AudioCodec   string
-	CRF          int // Quality (0-51, lower = better)
+	CRF          int    // Quality (0-51, lower = better)
+	Preset       string // Encoder speed preset (empty = encoder default)
 	ProgressFunc ProgressFunc
 }
 
@@ -35,6 +36,18 @@ func (e *Executor) ExtractClip(ctx context.Context, input string, opts ClipOptio
 		Bool("copy_codec", opts.CopyCodec).
 		Msg("extracting clip")
 
+	// Stream copy can only cut on keyframes, so the clip really starts at
+	// the keyframe preceding Start
+	if opts.CopyCodec {
+		if keyframe, ok := e.keyframeDrift(ctx, input, opts.Start); ok && keyframe < opts.Start {
+			e.logger.Warn().
+				Dur("requested_start", opts.Start).
+				Dur("actual_start", keyframe).
+				Dur("drift", opts.Start-keyframe).
+				Msg("copy-codec cut snaps to the preceding keyframe; re-encode for a frame-accurate start")
+		}
+	}
+
 	args := []string{
 		"-i", input,
 		"-ss", util.FormatDuration(opts.Start),
@@ -61,6 +74,9 @@ func (e *Executor) ExtractClip(ctx context.Context, input string, opts ClipOptio
 			crf = DefaultCRF
 		}
 		args = append(args, "-crf", fmt.Sprintf("%d", crf))
+		if opts.Preset != "" {
+			args = append(args, "-preset", opts.Preset)
+		}
 	}
 
 	args = append(args, opts.Output)
@@ -68,6 +84,7 @@ func (e *Executor) ExtractClip(ctx context.Context, input string, opts ClipOptio
 	runOpts := RunOptions{
 		Args:            args,
 		ProgressHandler: opts.ProgressFunc,
+		TotalDuration:   duration,
 		LogHandler: func(line string) {
 			e.logger.Debug().Str("ffmpeg", line).Msg("clip extraction")
 		},
@@ -2,9 +2,14 @@ package ffmpeg
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
 )
 
 // ConcatOptions defines concatenation parameters
@@ -16,6 +21,12 @@ type ConcatOptions struct {
 	AudioCodec   string
 	CRF          int
 	ProgressFunc ProgressFunc
+
+	// Transition between clips (default none). Transitions go through a
+	// filter graph instead of the concat demuxer, so they always re-encode
+	// regardless of ReEncode, and every input needs an audio stream.
+	Transition         Transition
+	TransitionDuration time.Duration // default DefaultTransitionDuration
 }
 
 // Concat merges multiple video files into one
@@ -26,12 +37,26 @@ func (e *Executor) Concat(ctx context.Context, opts ConcatOptions) error {
 	if opts.Output == "" {
 		return fmt.Errorf("output path is required")
 	}
+	if err := validateConcatInputs(opts.Inputs); err != nil {
+		return err
+	}
 
 	e.logger.Info().
 		Int("inputs", len(opts.Inputs)).
 		Str("output", opts.Output).
+		Str("transition", string(opts.Transition)).
 		Msg("concatenating videos")
 
+	if opts.Transition != "" && opts.Transition != TransitionNone && len(opts.Inputs) > 1 {
+		return e.concatWithTransitions(ctx, opts)
+	}
+
+	// The concat demuxer stream-copies blindly; mismatched inputs produce
+	// broken output instead of an error
+	if !opts.ReEncode && len(opts.Inputs) > 1 {
+		e.warnConcatMismatch(ctx, opts.Inputs)
+	}
+
 	// Create temporary concat file list
 	concatFile, err := e.createConcatFile(opts.Inputs)
 	if err != nil {
@@ -46,23 +71,7 @@ func (e *Executor) Concat(ctx context.Context, opts ConcatOptions) error {
 	}
 
 	if opts.ReEncode {
-		codec := opts.VideoCodec
-		if codec == "" {
-			codec = DefaultVideoCodec
-		}
-		args = append(args, "-c:v", codec)
-
-		audioCodec := opts.AudioCodec
-		if audioCodec == "" {
-			audioCodec = DefaultAudioCodec
-		}
-		args = append(args, "-c:a", audioCodec)
-
-		crf := opts.CRF
-		if crf == 0 {
-			crf = DefaultCRF
-		}
-		args = append(args, "-crf", fmt.Sprintf("%d", crf))
+		args = append(args, concatEncodeArgs(opts)...)
 	} else {
 		args = append(args, "-c", "copy")
 	}
@@ -80,23 +89,126 @@ func (e *Executor) Concat(ctx context.Context, opts ConcatOptions) error {
 	return e.Run(ctx, runOpts)
 }
 
+// validateConcatInputs reports every local input that does not exist
+func validateConcatInputs(inputs []string) error {
+	var errs []error
+	for i, input := range inputs {
+		if IsURLInput(input) {
+			continue
+		}
+		if _, err := os.Stat(input); err != nil {
+			errs = append(errs, fmt.Errorf("input %d not found: %s", i+1, input))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// warnConcatMismatch probes the inputs and warns when they cannot be
+// stream-copied into one file
+func (e *Executor) warnConcatMismatch(ctx context.Context, inputs []string) {
+	infos := make([]*VideoInfo, 0, len(inputs))
+	for _, input := range inputs {
+		info, err := e.ProbeVideo(ctx, input)
+		if err != nil {
+			e.logger.Debug().Err(err).Str("input", input).Msg("could not probe concat input")
+			return
+		}
+		infos = append(infos, info)
+	}
+
+	for _, mismatch := range concatMismatches(infos) {
+		e.logger.Warn().
+			Str("mismatch", mismatch).
+			Msg("concat inputs are not compatible for stream copy; output will likely be broken (set ReEncode)")
+	}
+}
+
+// concatMismatches describes how each input differs from the first in
+// codec or resolution
+func concatMismatches(infos []*VideoInfo) []string {
+	if len(infos) < 2 {
+		return nil
+	}
+
+	var mismatches []string
+	first := infos[0]
+	for i, info := range infos[1:] {
+		if info.VideoCodec != first.VideoCodec {
+			mismatches = append(mismatches, fmt.Sprintf("input %d video codec %s differs from %s", i+2, info.VideoCodec, first.VideoCodec))
+		}
+		if info.Width != first.Width || info.Height != first.Height {
+			mismatches = append(mismatches, fmt.Sprintf("input %d resolution %dx%d differs from %dx%d", i+2, info.Width, info.Height, first.Width, first.Height))
+		}
+		if info.HasAudio != first.HasAudio || info.AudioCodec != first.AudioCodec {
+			mismatches = append(mismatches, fmt.Sprintf("input %d audio codec %q differs from %q", i+2, info.AudioCodec, first.AudioCodec))
+		}
+	}
+	return mismatches
+}
+
+// concatEncodeArgs returns codec arguments for a re-encoding concat
+func concatEncodeArgs(opts ConcatOptions) []string {
+	codec := opts.VideoCodec
+	if codec == "" {
+		codec = DefaultVideoCodec
+	}
+
+	audioCodec := opts.AudioCodec
+	if audioCodec == "" {
+		audioCodec = DefaultAudioCodec
+	}
+
+	crf := opts.CRF
+	if crf == 0 {
+		crf = DefaultCRF
+	}
+
+	return []string{"-c:v", codec, "-c:a", audioCodec, "-crf", fmt.Sprintf("%d", crf)}
+}
+
 // createConcatFile generates a temporary file list for ffmpeg concat
 func (e *Executor) createConcatFile(inputs []string) (string, error) {
 	tmpFile, err := os.CreateTemp("", "slopcannon-concat-*.txt")
 	if err != nil {
 		return "", err
 	}
-	defer tmpFile.Close()
 
+	if err := writeConcatList(tmpFile, inputs); err != nil {
+		tmpFile.Close()
+		os.Remove(tmpFile.Name())
+		return "", err
+	}
+	if err := tmpFile.Close(); err != nil {
+		os.Remove(tmpFile.Name())
+		return "", err
+	}
+
+	return tmpFile.Name(), nil
+}
+
+// writeConcatList writes one concat demuxer line per input
+func writeConcatList(w io.Writer, inputs []string) error {
 	for _, input := range inputs {
-		absPath, err := filepath.Abs(input)
-		if err != nil {
-			return "", err
+		absPath := input
+		if !IsURLInput(input) {
+			var err error
+			absPath, err = filepath.Abs(input)
+			if err != nil {
+				return err
+			}
 		}
-		if _, err := fmt.Fprintf(tmpFile, "file '%s'\n", absPath); err != nil {
-			return "", err
+		if _, err := fmt.Fprintln(w, concatFileLine(absPath)); err != nil {
+			return err
 		}
 	}
+	return nil
+}
 
-	return tmpFile.Name(), nil
+// concatFileLine formats a concat demuxer "file" directive. Quoted text is
+// literal, so a single quote closes the quote, is escaped, and reopens it.
+func concatFileLine(path string) string {
+	if runtime.GOOS == "windows" {
+		path = strings.ReplaceAll(path, "\\", "/")
+	}
+	return "file '" + strings.ReplaceAll(path, "'", `'\''`) + "'"
 }
@@ -0,0 +1,90 @@
+package ffmpeg
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateConcatInputs(t *testing.T) {
+	existing := filepath.Join(t.TempDir(), "a.mp4")
+	if err := os.WriteFile(existing, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := validateConcatInputs([]string{existing}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err := validateConcatInputs([]string{existing, "foo.mp4", "bar.mp4"})
+	if err == nil {
+		t.Fatal("expected error for missing inputs")
+	}
+	for _, want := range []string{"input 2 not found: foo.mp4", "input 3 not found: bar.mp4"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in %q", want, err)
+		}
+	}
+}
+
+func TestConcatMismatches(t *testing.T) {
+	base := &VideoInfo{VideoCodec: "h264", Width: 1920, Height: 1080, HasAudio: true, AudioCodec: "aac"}
+	same := *base
+	other := &VideoInfo{VideoCodec: "hevc", Width: 1080, Height: 1920, HasAudio: true, AudioCodec: "aac"}
+
+	if got := concatMismatches([]*VideoInfo{base, &same}); len(got) != 0 {
+		t.Errorf("expected no mismatches, got %v", got)
+	}
+
+	got := concatMismatches([]*VideoInfo{base, &same, other})
+	if len(got) != 2 {
+		t.Fatalf("expected codec and resolution mismatches, got %v", got)
+	}
+	if !strings.HasPrefix(got[0], "input 3 video codec") || !strings.HasPrefix(got[1], "input 3 resolution") {
+		t.Errorf("unexpected mismatches %v", got)
+	}
+}
+
+func TestConcatFileLine(t *testing.T) {
+	path := "/Users/me/My Kid's Video.mp4"
+
+	line := concatFileLine(path)
+	if line != `file '/Users/me/My Kid'\''s Video.mp4'` {
+		t.Errorf("unexpected line %q", line)
+	}
+
+	directive, arg := parseConcatLine(t, line)
+	if directive != "file" || arg != path {
+		t.Errorf("line parsed as %q %q, expected file %q", directive, arg, path)
+	}
+}
+
+// parseConcatLine tokenizes a concat list line the way ffmpeg does:
+// quotes group literal text and a backslash escapes the next character
+func parseConcatLine(t *testing.T, line string) (string, string) {
+	t.Helper()
+
+	directive, rest, ok := strings.Cut(line, " ")
+	if !ok {
+		t.Fatalf("no argument in %q", line)
+	}
+
+	var arg strings.Builder
+	quoted := false
+	for i := 0; i < len(rest); i++ {
+		switch c := rest[i]; {
+		case c == '\'':
+			quoted = !quoted
+		case c == '\\' && !quoted && i+1 < len(rest):
+			i++
+			arg.WriteByte(rest[i])
+		default:
+			arg.WriteByte(c)
+		}
+	}
+	if quoted {
+		t.Fatalf("unterminated quote in %q", line)
+	}
+	return directive, arg.String()
+}
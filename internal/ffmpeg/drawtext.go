@@ -0,0 +1,127 @@
+package ffmpeg
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Default caption placement: centered horizontally, in the top fifth
+const (
+	DefaultCaptionX = "(w-text_w)/2"
+	DefaultCaptionY = "h*0.15"
+)
+
+// DrawTextOptions styles and positions a drawtext caption.
+// Zero values fall back to ffmpeg's defaults or the caption defaults.
+type DrawTextOptions struct {
+	FontFile    string // path to a .ttf/.otf; takes precedence over FontName
+	FontName    string // fontconfig family name
+	FontSize    int
+	FontColor   string
+	BorderWidth int // text outline
+	BorderColor string
+	BoxColor    string // background box; "" = no box
+	BoxBorder   int    // box padding in pixels
+	X           string // x expression (default DefaultCaptionX)
+	Y           string // y expression (default DefaultCaptionY)
+	Start       time.Duration
+	End         time.Duration // 0 = until the end
+}
+
+// Caption is a timed text overlay, e.g. a "Wait for it..." hook
+type Caption struct {
+	Text    string
+	Options DrawTextOptions
+}
+
+// WithDefaults fills unset style fields from defaults; position and timing
+// are left alone
+func (o DrawTextOptions) WithDefaults(defaults DrawTextOptions) DrawTextOptions {
+	if o.FontFile == "" && o.FontName == "" {
+		o.FontFile, o.FontName = defaults.FontFile, defaults.FontName
+	}
+	if o.FontSize == 0 {
+		o.FontSize = defaults.FontSize
+	}
+	if o.FontColor == "" {
+		o.FontColor = defaults.FontColor
+	}
+	if o.BorderWidth == 0 {
+		o.BorderWidth = defaults.BorderWidth
+	}
+	if o.BorderColor == "" {
+		o.BorderColor = defaults.BorderColor
+	}
+	if o.BoxColor == "" {
+		o.BoxColor, o.BoxBorder = defaults.BoxColor, defaults.BoxBorder
+	}
+	return o
+}
+
+// DrawText adds a drawtext filter rendering text
+func (fb *FilterBuilder) DrawText(text string, opts DrawTextOptions) *FilterBuilder {
+	if text == "" {
+		return fb
+	}
+	fb.filters = append(fb.filters, drawTextFilter(text, opts))
+	return fb
+}
+
+// drawTextFilter builds a drawtext filter. Text expansion is disabled so
+// '%' in captions is literal.
+func drawTextFilter(text string, opts DrawTextOptions) string {
+	x, y := opts.X, opts.Y
+	if x == "" {
+		x = DefaultCaptionX
+	}
+	if y == "" {
+		y = DefaultCaptionY
+	}
+
+	parts := []string{
+		"expansion=none",
+		"text=" + escapeDrawText(text),
+	}
+	if opts.FontFile != "" {
+		parts = append(parts, "fontfile="+escapeDrawText(opts.FontFile))
+	} else if opts.FontName != "" {
+		parts = append(parts, "font="+escapeDrawText(opts.FontName))
+	}
+	if opts.FontSize > 0 {
+		parts = append(parts, fmt.Sprintf("fontsize=%d", opts.FontSize))
+	}
+	if opts.FontColor != "" {
+		parts = append(parts, "fontcolor="+opts.FontColor)
+	}
+	if opts.BorderWidth > 0 {
+		color := opts.BorderColor
+		if color == "" {
+			color = "black"
+		}
+		parts = append(parts, fmt.Sprintf("borderw=%d", opts.BorderWidth), "bordercolor="+color)
+	}
+	if opts.BoxColor != "" {
+		parts = append(parts, "box=1", "boxcolor="+opts.BoxColor, fmt.Sprintf("boxborderw=%d", opts.BoxBorder))
+	}
+	parts = append(parts, "x='"+x+"'", "y='"+y+"'")
+
+	if enable := overlayEnable(OverlayOptions{Start: opts.Start, End: opts.End}); enable != "" {
+		parts = append(parts, "enable='"+enable+"'")
+	}
+
+	return "drawtext=" + strings.Join(parts, ":")
+}
+
+var (
+	// drawtextValueEscaper escapes an option value for the filter parser
+	drawtextValueEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`, `:`, `\:`)
+	// filtergraphEscaper escapes the result again for the graph parser
+	filtergraphEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`, `[`, `\[`, `]`, `\]`, `,`, `\,`, `;`, `\;`)
+)
+
+// escapeDrawText escapes text for use as a drawtext option inside -vf or
+// -filter_complex (ffmpeg parses filter graphs with two levels of escaping)
+func escapeDrawText(text string) string {
+	return filtergraphEscaper.Replace(drawtextValueEscaper.Replace(text))
+}
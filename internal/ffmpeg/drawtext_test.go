@@ -0,0 +1,55 @@
+package ffmpeg
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDrawTextFilter(t *testing.T) {
+	filter := NewFilterBuilder().DrawText("Wait for it...", DrawTextOptions{
+		FontFile:    "/fonts/Impact.ttf",
+		FontSize:    72,
+		FontColor:   "white",
+		BorderWidth: 4,
+		BoxColor:    "black@0.5",
+		BoxBorder:   12,
+		Start:       0,
+		End:         3 * time.Second,
+	}).Build()
+
+	for _, want := range []string{
+		"drawtext=expansion=none",
+		"text=Wait for it...",
+		"fontfile=/fonts/Impact.ttf",
+		"fontsize=72",
+		"borderw=4:bordercolor=black",
+		"box=1:boxcolor=black@0.5:boxborderw=12",
+		"x='(w-text_w)/2'",
+		"enable='lte(t,3.00)'",
+	} {
+		if !strings.Contains(filter, want) {
+			t.Errorf("filter %q should contain %q", filter, want)
+		}
+	}
+}
+
+func TestEscapeDrawText(t *testing.T) {
+	got := escapeDrawText(`it's 50% off: [now], ok; C:\x`)
+	want := `it\\\'s 50% off\\: \[now\]\, ok\; C\\:\\\\x`
+	if got != want {
+		t.Errorf("escapeDrawText:\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestDrawTextWithDefaults(t *testing.T) {
+	opts := DrawTextOptions{FontSize: 96}.WithDefaults(DrawTextOptions{
+		FontName:    "Arial",
+		FontSize:    24,
+		FontColor:   "#FFFFFF",
+		BorderWidth: 2,
+	})
+	if opts.FontSize != 96 || opts.FontName != "Arial" || opts.FontColor != "#FFFFFF" || opts.BorderWidth != 2 {
+		t.Errorf("unexpected merged options %+v", opts)
+	}
+}
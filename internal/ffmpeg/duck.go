@@ -0,0 +1,110 @@
+package ffmpeg
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DuckOptions tunes how far and how fast background audio drops under the
+// main track. Zero values use the defaults noted on each field.
+type DuckOptions struct {
+	Threshold        float64       // main-track level that triggers ducking, 0-1 (default 0.05)
+	Ratio            float64       // compression ratio, 1-20 (default 8)
+	Attack           time.Duration // how fast the background drops (default 20ms)
+	Release          time.Duration // how fast it comes back (default 300ms)
+	BackgroundVolume float64       // background gain before ducking (default 0.6)
+	ProgressFunc     ProgressFunc
+}
+
+// withDefaults fills unset duck options
+func (o DuckOptions) withDefaults() DuckOptions {
+	if o.Threshold == 0 {
+		o.Threshold = 0.05
+	}
+	if o.Ratio == 0 {
+		o.Ratio = 8
+	}
+	if o.Attack == 0 {
+		o.Attack = 20 * time.Millisecond
+	}
+	if o.Release == 0 {
+		o.Release = 300 * time.Millisecond
+	}
+	if o.BackgroundVolume == 0 {
+		o.BackgroundVolume = 0.6
+	}
+	return o
+}
+
+// MixAudioWithDucking mixes bgInput's audio under mainInput's, compressing
+// the background whenever the main track is loud (e.g. someone is talking).
+// Video is copied from mainInput; the background loops to cover it.
+func (e *Executor) MixAudioWithDucking(ctx context.Context, mainInput, bgInput, output string, opts DuckOptions) error {
+	if mainInput == "" || bgInput == "" {
+		return fmt.Errorf("main and background inputs are required")
+	}
+	if output == "" {
+		return fmt.Errorf("output path is required")
+	}
+
+	graph, err := buildDuckGraph(opts)
+	if err != nil {
+		return err
+	}
+
+	e.logger.Info().
+		Str("main", mainInput).
+		Str("background", bgInput).
+		Str("output", output).
+		Msg("mixing audio with ducking")
+
+	args := []string{
+		"-i", mainInput,
+		"-stream_loop", "-1", "-i", bgInput,
+		"-filter_complex", graph,
+		"-map", "0:v?",
+		"-map", "[aout]",
+		"-c:v", "copy",
+		"-c:a", DefaultAudioCodec,
+		output,
+	}
+
+	runOpts := RunOptions{
+		Args:            args,
+		ProgressHandler: opts.ProgressFunc,
+		TotalDuration:   e.probeDuration(ctx, mainInput, opts.ProgressFunc),
+		LogHandler: func(line string) {
+			e.logger.Debug().Str("ffmpeg", line).Msg("audio ducking")
+		},
+	}
+
+	if err := e.Run(ctx, runOpts); err != nil {
+		return fmt.Errorf("audio ducking failed: %w", err)
+	}
+	return nil
+}
+
+// buildDuckGraph keys a sidechain compressor on input 0's audio to duck
+// input 1's; the mix is labelled [aout] and lasts as long as input 0
+func buildDuckGraph(opts DuckOptions) (string, error) {
+	opts = opts.withDefaults()
+
+	if opts.Threshold < 0 || opts.Threshold > 1 {
+		return "", fmt.Errorf("duck threshold must be between 0 and 1")
+	}
+	if opts.Ratio < 1 || opts.Ratio > 20 {
+		return "", fmt.Errorf("duck ratio must be between 1 and 20")
+	}
+
+	return fmt.Sprintf(
+		"[1:a]volume=%.2f[bg];"+
+			"[0:a]asplit=2[main][sc];"+
+			"[bg][sc]sidechaincompress=threshold=%g:ratio=%g:attack=%g:release=%g[ducked];"+
+			"[main][ducked]amix=inputs=2:duration=first:normalize=0[aout]",
+		opts.BackgroundVolume,
+		opts.Threshold, opts.Ratio,
+		float64(opts.Attack)/float64(time.Millisecond),
+		float64(opts.Release)/float64(time.Millisecond),
+	), nil
+}
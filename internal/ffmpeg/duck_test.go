@@ -0,0 +1,28 @@
+package ffmpeg
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildDuckGraph(t *testing.T) {
+	graph, err := buildDuckGraph(DuckOptions{Ratio: 4, Release: 500 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"[1:a]volume=0.60[bg]",
+		"sidechaincompress=threshold=0.05:ratio=4:attack=20:release=500[ducked]",
+		"amix=inputs=2:duration=first",
+	} {
+		if !strings.Contains(graph, want) {
+			t.Errorf("graph %q should contain %q", graph, want)
+		}
+	}
+
+	if _, err := buildDuckGraph(DuckOptions{Ratio: 50}); err == nil {
+		t.Error("expected error for out-of-range ratio")
+	}
+}
@@ -0,0 +1,108 @@
+package ffmpeg
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrorCategory classifies why an ffmpeg run failed
+type ErrorCategory int
+
+const (
+	CategoryUnknown ErrorCategory = iota
+	CategoryCancelled
+	CategoryTimeout
+	CategoryNotFound
+	CategoryInvalidArgs
+	CategoryUnsupportedCodec
+	CategoryInvalidInput
+	// CategoryTransient covers network and device I/O failures worth retrying
+	CategoryTransient
+	// CategoryEmptyOutput is expected from analysis runs writing to the null muxer
+	CategoryEmptyOutput
+)
+
+// String returns the category name
+func (c ErrorCategory) String() string {
+	switch c {
+	case CategoryCancelled:
+		return "cancelled"
+	case CategoryTimeout:
+		return "timeout"
+	case CategoryNotFound:
+		return "not_found"
+	case CategoryInvalidArgs:
+		return "invalid_args"
+	case CategoryUnsupportedCodec:
+		return "unsupported_codec"
+	case CategoryInvalidInput:
+		return "invalid_input"
+	case CategoryTransient:
+		return "transient"
+	case CategoryEmptyOutput:
+		return "empty_output"
+	default:
+		return "unknown"
+	}
+}
+
+// FFmpegError is returned by Run when ffmpeg fails
+type FFmpegError struct {
+	// ExitCode of the ffmpeg process (-1 when it was killed or never exited)
+	ExitCode int
+	// Stderr holds the last lines ffmpeg logged before failing
+	Stderr   []string
+	Category ErrorCategory
+	Err      error
+}
+
+// Error includes the stderr tail, except for cancellations where it is noise
+func (e *FFmpegError) Error() string {
+	if len(e.Stderr) == 0 || e.Category == CategoryCancelled {
+		return e.Err.Error()
+	}
+	return e.Err.Error() + "\nffmpeg stderr:\n  " + strings.Join(e.Stderr, "\n  ")
+}
+
+func (e *FFmpegError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorCategoryOf returns the category of an ffmpeg error, or
+// CategoryUnknown when err is not an FFmpegError
+func ErrorCategoryOf(err error) ErrorCategory {
+	var ffErr *FFmpegError
+	if errors.As(err, &ffErr) {
+		return ffErr.Category
+	}
+	return CategoryUnknown
+}
+
+// categoryPatterns maps stderr fragments to categories, checked in order.
+// Matching is case-insensitive.
+var categoryPatterns = []struct {
+	category ErrorCategory
+	patterns []string
+}{
+	{CategoryNotFound, []string{"no such file or directory", "server returned 404"}},
+	{CategoryInvalidArgs, []string{"unrecognized option", "option not found", "missing argument", "no such filter", "error parsing", "invalid argument"}},
+	{CategoryUnsupportedCodec, []string{"unknown encoder", "unknown decoder", "encoder not found", "decoder not found"}},
+	{CategoryTransient, transientPatterns},
+	{CategoryInvalidInput, []string{"invalid data found when processing input", "moov atom not found"}},
+	{CategoryEmptyOutput, []string{"output file is empty", "invalid return value"}},
+}
+
+// classifyStderr picks the category of a failed run from its log output
+func classifyStderr(lines []string) ErrorCategory {
+	for _, entry := range categoryPatterns {
+		for _, line := range lines {
+			lower := strings.ToLower(line)
+			for _, pattern := range entry.patterns {
+				if strings.Contains(lower, pattern) {
+					return entry.category
+				}
+			}
+		}
+	}
+	return CategoryUnknown
+}
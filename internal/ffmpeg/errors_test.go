@@ -0,0 +1,47 @@
+package ffmpeg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestClassifyStderr(t *testing.T) {
+	tests := []struct {
+		line string
+		want ErrorCategory
+	}{
+		{"[tcp @ 0x55] Connection reset by peer", CategoryTransient},
+		{"http://example.com/a.mp4: Input/output error", CategoryTransient},
+		{"[h264_nvenc @ 0x1] OpenEncodeSessionEx failed: out of memory (10)", CategoryTransient},
+		{"Unrecognized option 'foo'.", CategoryInvalidArgs},
+		{"missing.mp4: No such file or directory", CategoryNotFound},
+		{"Unknown encoder 'libfoo'", CategoryUnsupportedCodec},
+		{"in.txt: Invalid data found when processing input", CategoryInvalidInput},
+		{"Output file is empty, nothing was encoded", CategoryEmptyOutput},
+		{"Conversion failed!", CategoryUnknown},
+	}
+
+	for _, tt := range tests {
+		lines := []string{"ffmpeg version 6.0", tt.line, "Conversion failed!"}
+		if got := classifyStderr(lines); got != tt.want {
+			t.Errorf("classifyStderr(%q) = %s, expected %s", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestErrorCategoryOf(t *testing.T) {
+	ffErr := &FFmpegError{ExitCode: 255, Category: CategoryCancelled, Err: context.Canceled}
+	wrapped := fmt.Errorf("render failed: %w", ffErr)
+
+	if got := ErrorCategoryOf(wrapped); got != CategoryCancelled {
+		t.Errorf("expected cancelled, got %s", got)
+	}
+	if !errors.Is(wrapped, context.Canceled) {
+		t.Error("expected FFmpegError to unwrap to context.Canceled")
+	}
+	if got := ErrorCategoryOf(errors.New("boom")); got != CategoryUnknown {
+		t.Errorf("expected unknown for plain errors, got %s", got)
+	}
+}
@@ -3,13 +3,17 @@ package ffmpeg
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/keagan/slopcannon/pkg/util"
 	"github.com/rs/zerolog"
 )
 
@@ -19,9 +23,28 @@ type Executor struct {
 	ffmpegPath  string
 	ffprobePath string
 	threads     int
+	logLevel    string
+	// preset is the default encoding preset (empty = DefaultPreset)
+	preset string
+	// maxRetries applies to runs that don't set RunOptions.MaxRetries
+	maxRetries int
+	encoders   map[string]bool
+	// filters is probed on first HasFilter call (nil = unknown)
+	filtersOnce sync.Once
+	filters     map[string]bool
+	// progress is the fallback for runs without their own ProgressHandler
+	progress ProgressFunc
+
+	// version caches the first successful Version probe
+	versionMu sync.Mutex
+	version   *FFmpegVersion
+
+	// probeCache backs ProbeVideoCached; created on first use
+	probeOnce  sync.Once
+	probeCache *probeCache
 }
 
-// New creates a new ffmpeg executor
+// New creates a new ffmpeg executor using ffmpeg and ffprobe from PATH
 func New(logger zerolog.Logger, threads int) (*Executor, error) {
 	ffmpegPath, err := exec.LookPath("ffmpeg")
 	if err != nil {
@@ -33,12 +56,39 @@ func New(logger zerolog.Logger, threads int) (*Executor, error) {
 		return nil, fmt.Errorf("ffprobe not found in PATH: %w", err)
 	}
 
-	return &Executor{
+	return newExecutor(logger, ffmpegPath, ffprobePath, threads), nil
+}
+
+// newExecutor builds an executor for the given binaries
+func newExecutor(logger zerolog.Logger, ffmpegPath, ffprobePath string, threads int) *Executor {
+	e := &Executor{
 		logger:      logger.With().Str("component", "ffmpeg").Logger(),
 		ffmpegPath:  ffmpegPath,
 		ffprobePath: ffprobePath,
 		threads:     threads,
-	}, nil
+		logLevel:    LogLevelFor(zerolog.GlobalLevel()),
+	}
+
+	// Probe available encoders once so hardware acceleration can be resolved
+	var err error
+	e.encoders, err = probeEncoders(context.Background(), ffmpegPath)
+	if err != nil {
+		e.logger.Warn().Err(err).Msg("encoder detection failed; hardware acceleration disabled")
+	}
+
+	if v, err := e.Version(context.Background()); err != nil {
+		e.logger.Debug().Err(err).Msg("ffmpeg version unknown")
+	} else {
+		e.logger.Debug().Str("version", v.Version).Str("path", ffmpegPath).Msg("using ffmpeg")
+	}
+
+	return e
+}
+
+// SetProgressFunc sets a progress callback for every run that doesn't
+// supply its own (e.g. a CLI progress bar)
+func (e *Executor) SetProgressFunc(fn ProgressFunc) {
+	e.progress = fn
 }
 
 // Run executes ffmpeg with the given arguments and streams progress
@@ -47,8 +97,9 @@ func (e *Executor) Run(ctx context.Context, opts RunOptions) error {
 		return fmt.Errorf("no arguments provided")
 	}
 
-	// Build args with threads BEFORE other arguments
-	baseArgs := []string{"-y", "-hide_banner", "-loglevel", "info"}
+	// Build args with threads BEFORE other arguments; -y is set here for
+	// every run, so callers don't pass it
+	baseArgs := []string{"-y", "-hide_banner", "-loglevel", runLogLevel(e.logLevel, opts.MinLogLevel)}
 
 	if e.threads > 0 {
 		baseArgs = append(baseArgs, "-threads", fmt.Sprintf("%d", e.threads))
@@ -62,7 +113,47 @@ func (e *Executor) Run(ctx context.Context, opts RunOptions) error {
 		Strs("args", args).
 		Msg("executing ffmpeg")
 
-	cmd := exec.CommandContext(ctx, e.ffmpegPath, args...)
+	maxRetries := retryLimit(opts.MaxRetries, e.maxRetries)
+	for attempt := 1; ; attempt++ {
+		err := e.runOnce(ctx, args, opts)
+		if err == nil {
+			break
+		}
+		if ErrorCategoryOf(err) != CategoryTransient || attempt > maxRetries {
+			return err
+		}
+
+		delay := retryDelay(opts.RetryBackoff, attempt)
+		e.logger.Warn().
+			Err(err).
+			Int("attempt", attempt).
+			Int("max_retries", maxRetries).
+			Dur("backoff", delay).
+			Msg("transient ffmpeg failure; retrying")
+
+		removePartialOutput(args)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+
+	e.logger.Debug().Msg("ffmpeg execution completed")
+	return nil
+}
+
+// runOnce runs a single ffmpeg invocation; failures are *FFmpegError
+func (e *Executor) runOnce(ctx context.Context, args []string, opts RunOptions) error {
+	runCtx := ctx
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	cmd := exec.CommandContext(runCtx, e.ffmpegPath, args...)
+	configureProcessGroup(cmd)
 
 	stderr, err := cmd.StderrPipe()
 	if err != nil {
@@ -75,16 +166,41 @@ func (e *Executor) Run(ctx context.Context, opts RunOptions) error {
 	}
 
 	if err := cmd.Start(); err != nil {
-		return fmt.Errorf("failed to start ffmpeg: %w", err)
+		return &FFmpegError{
+			ExitCode: -1,
+			Category: CategoryNotFound,
+			Err:      fmt.Errorf("failed to start ffmpeg: %w", err),
+		}
 	}
 
 	var wg sync.WaitGroup
 	wg.Add(2)
 
-	// Stream stderr (progress + logs)
+	progressHandler := opts.ProgressHandler
+	if progressHandler == nil {
+		progressHandler = e.progress
+	}
+	if handler := progressHandler; handler != nil {
+		input := runInput(args)
+		progressHandler = func(p *Progress) {
+			p.Input = input
+			handler(p)
+		}
+	}
+
+	// Stream stderr (progress + logs), keeping the tail for error reports
+	tail := newLineRing(opts.StderrLines)
+	logHandler := func(line string) {
+		if !isProgressLine(line) {
+			tail.add(line)
+		}
+		if opts.LogHandler != nil {
+			opts.LogHandler(line)
+		}
+	}
 	go func() {
 		defer wg.Done()
-		e.streamOutput(stderr, opts.ProgressHandler, opts.LogHandler)
+		e.streamOutput(stderr, opts.TotalDuration, progressHandler, logHandler)
 	}()
 
 	// Stream stdout
@@ -101,18 +217,60 @@ func (e *Executor) Run(ctx context.Context, opts RunOptions) error {
 	wg.Wait()
 
 	if err := cmd.Wait(); err != nil {
-		if ctx.Err() == context.Canceled {
-			return ctx.Err()
+		ffErr := &FFmpegError{ExitCode: -1, Stderr: tail.lines()}
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			ffErr.ExitCode = exitErr.ExitCode()
+		}
+
+		switch {
+		case ctx.Err() != nil:
+			removePartialOutput(args)
+			ffErr.Category = CategoryCancelled
+			ffErr.Err = ctx.Err()
+		case errors.Is(runCtx.Err(), context.DeadlineExceeded):
+			removePartialOutput(args)
+			ffErr.Category = CategoryTimeout
+			ffErr.Err = fmt.Errorf("ffmpeg timed out after %s: %w", opts.Timeout, context.DeadlineExceeded)
+		default:
+			ffErr.Category = classifyStderr(ffErr.Stderr)
+			ffErr.Err = fmt.Errorf("ffmpeg execution failed: %w", err)
 		}
-		return fmt.Errorf("ffmpeg execution failed: %w", err)
+		return ffErr
 	}
 
-	e.logger.Debug().Msg("ffmpeg execution completed")
 	return nil
 }
 
+// runInput returns the first -i argument of an ffmpeg command line
+func runInput(args []string) string {
+	for i := 0; i < len(args)-1; i++ {
+		if args[i] == "-i" {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+// removePartialOutput deletes the (last-argument) output file of an aborted run
+func removePartialOutput(args []string) {
+	if len(args) == 0 {
+		return
+	}
+	output := args[len(args)-1]
+	if output == "-" || strings.HasPrefix(output, "-") || (strings.Contains(output, ":") && !filepath.IsAbs(output)) {
+		// stdout, a flag, or a protocol URL (pipe:, http:) - nothing on disk to clean
+		return
+	}
+	if info, err := os.Lstat(output); err != nil || !info.Mode().IsRegular() {
+		// Never remove devices (/dev/null), directories or symlinks
+		return
+	}
+	_ = os.Remove(output)
+}
+
 // streamOutput parses ffmpeg output and calls handlers
-func (e *Executor) streamOutput(r io.Reader, progressHandler func(*Progress), logHandler func(string)) {
+func (e *Executor) streamOutput(r io.Reader, total time.Duration, progressHandler func(*Progress), logHandler func(string)) {
 	scanner := bufio.NewScanner(r)
 	progressData := &Progress{}
 
@@ -133,10 +291,11 @@ func (e *Executor) streamOutput(r io.Reader, progressHandler func(*Progress), lo
 			if len(parts) == 2 {
 				progressData.Bitrate = strings.TrimSpace(parts[1])
 			}
-		} else if strings.HasPrefix(line, "time=") {
+		} else if strings.HasPrefix(line, "time=") || strings.HasPrefix(line, "out_time=") {
 			parts := strings.SplitN(line, "=", 2)
 			if len(parts) == 2 {
 				progressData.Time = strings.TrimSpace(parts[1])
+				progressData.Percentage = progressPercentage(progressData.Time, total)
 			}
 		} else if strings.HasPrefix(line, "speed=") {
 			parts := strings.SplitN(line, "=", 2)
@@ -145,7 +304,7 @@ func (e *Executor) streamOutput(r io.Reader, progressHandler func(*Progress), lo
 			}
 		} else if strings.HasPrefix(line, "progress=") {
 			// End of progress block
-			if progressHandler != nil && progressData.Frame > 0 {
+			if progressHandler != nil && (progressData.Frame > 0 || progressData.Percentage > 0) {
 				progressHandler(progressData)
 			}
 			progressData = &Progress{}
@@ -153,28 +312,36 @@ func (e *Executor) streamOutput(r io.Reader, progressHandler func(*Progress), lo
 	}
 }
 
-// ExtractFrame extracts a single frame at the specified time
-func (e *Executor) ExtractFrame(ctx context.Context, videoPath string, timestamp time.Duration, outputPath string) error {
-	args := []string{
-		"-ss", fmt.Sprintf("%.3f", timestamp.Seconds()),
-		"-i", videoPath,
-		"-vframes", "1",
-		"-q:v", "2",
-		"-y",
-		outputPath,
+// progressPercentage converts an ffmpeg time value into a percentage of total.
+// Returns 0 when the total is unknown or the time can't be parsed.
+func progressPercentage(timeValue string, total time.Duration) float64 {
+	if total <= 0 {
+		return 0
 	}
 
-	e.logger.Debug().
-		Str("video", videoPath).
-		Dur("timestamp", timestamp).
-		Str("output", outputPath).
-		Msg("extracting frame")
+	current, err := util.ParseTimestamp(timeValue)
+	if err != nil || current < 0 {
+		return 0
+	}
 
-	cmd := exec.CommandContext(ctx, e.ffmpegPath, args...)
+	pct := float64(current) / float64(total) * 100
+	if pct > 100 {
+		pct = 100
+	}
+	return pct
+}
 
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("frame extraction failed: %w", err)
+// probeDuration returns the input duration for progress reporting.
+// Probing only happens when a progress callback is set; failures yield 0.
+func (e *Executor) probeDuration(ctx context.Context, input string, progressFunc ProgressFunc) time.Duration {
+	if progressFunc == nil && e.progress == nil {
+		return 0
 	}
 
-	return nil
+	info, err := e.ProbeVideoCached(ctx, input)
+	if err != nil {
+		e.logger.Debug().Err(err).Str("input", input).Msg("could not probe duration for progress")
+		return 0
+	}
+	return info.Duration
 }
@@ -2,6 +2,7 @@ package ffmpeg
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -323,6 +324,9 @@ func TestConcatValidation(t *testing.T) {
 	}
 
 	err = exec.Concat(ctx, opts)
+	if err == nil || !strings.Contains(err.Error(), "input 2 not found: nonexistent2.mp4") {
+		t.Errorf("expected aggregated not-found error, got %v", err)
+	}
 	t.Logf("Concat with non-existent files returned: %v", err)
 }
 
@@ -427,3 +431,98 @@ func printTestSummary() {
 	fmt.Println(strings.Repeat("=", 80))
 	fmt.Println()
 }
+
+func TestStreamOutputPercentage(t *testing.T) {
+	e := &Executor{logger: zerolog.Nop()}
+	output := strings.Join([]string{
+		"frame=30",
+		"fps=30.0",
+		"out_time=00:00:05.000000",
+		"speed=1.0x",
+		"progress=continue",
+	}, "\n")
+
+	var got *Progress
+	e.streamOutput(strings.NewReader(output), 10*time.Second, func(p *Progress) { got = p }, nil)
+
+	if got == nil {
+		t.Fatal("progress handler was not called")
+	}
+	if got.Percentage != 50 {
+		t.Errorf("expected 50%%, got %.2f", got.Percentage)
+	}
+
+	// Unknown duration leaves percentage at zero
+	got = nil
+	e.streamOutput(strings.NewReader(output), 0, func(p *Progress) { got = p }, nil)
+	if got == nil || got.Percentage != 0 {
+		t.Errorf("expected 0%% for unknown duration, got %+v", got)
+	}
+}
+
+func TestRunTimeout(t *testing.T) {
+	skipIfNoFFmpeg(t)
+
+	logger := zerolog.New(os.Stderr)
+	exec, err := New(logger, 1)
+	if err != nil {
+		t.Fatalf("failed to create executor: %v", err)
+	}
+
+	outputPath := filepath.Join(t.TempDir(), "endless.mp4")
+	err = exec.Run(context.Background(), RunOptions{
+		Args: []string{
+			"-re", "-f", "lavfi", "-i", "testsrc=size=320x240:rate=30",
+			outputPath,
+		},
+		Timeout: 500 * time.Millisecond,
+	})
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if _, err := os.Stat(outputPath); !os.IsNotExist(err) {
+		t.Error("partial output should be removed after timeout")
+	}
+}
+
+func TestRunInput(t *testing.T) {
+	if got := runInput([]string{"-y", "-ss", "5", "-i", "a.mp4", "-i", "b.mp4", "out.mp4"}); got != "a.mp4" {
+		t.Errorf("expected the first input, got %q", got)
+	}
+	if got := runInput([]string{"-f", "lavfi", "out.mp4", "-i"}); got != "" {
+		t.Errorf("expected no input, got %q", got)
+	}
+}
+
+func TestRemovePartialOutputRegularFilesOnly(t *testing.T) {
+	dir := t.TempDir()
+	partial := filepath.Join(dir, "partial.mp4")
+	if err := os.WriteFile(partial, []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	removePartialOutput([]string{"-i", "in.mp4", partial})
+	if _, err := os.Stat(partial); !os.IsNotExist(err) {
+		t.Error("partial output should be removed")
+	}
+
+	removePartialOutput([]string{"-i", "in.mp4", dir})
+	if _, err := os.Stat(dir); err != nil {
+		t.Errorf("directories must be left alone: %v", err)
+	}
+}
+
+func TestParseMotionOutput(t *testing.T) {
+	output := `[Parsed_metadata_3 @ 0x1] frame:0    pts:0       pts_time:0
+[Parsed_metadata_3 @ 0x1] lavfi.signalstats.YDIF=0.000000
+[Parsed_metadata_3 @ 0x1] frame:1    pts:1       pts_time:0.25
+[Parsed_metadata_3 @ 0x1] lavfi.signalstats.YDIF=12.500000
+`
+	samples := parseMotionOutput(output)
+	if len(samples) != 2 {
+		t.Fatalf("expected 2 samples, got %d", len(samples))
+	}
+	if samples[1].Time != 250*time.Millisecond || samples[1].YDiff != 12.5 {
+		t.Errorf("unexpected sample: %+v", samples[1])
+	}
+}
@@ -7,9 +7,13 @@ import (
 
 // FilterBuilder helps construct complex ffmpeg filter chains
 type FilterBuilder struct {
-	filters []string
+	filters  []string
+	padColor string
 }
 
+// DefaultPadColor fills the bars added by ScaleFit when no color is set
+const DefaultPadColor = "black"
+
 // NewFilterBuilder creates a new filter builder
 func NewFilterBuilder() *FilterBuilder {
 	return &FilterBuilder{
@@ -27,6 +31,31 @@ func (fb *FilterBuilder) Scale(width, height int) *FilterBuilder {
 	return fb
 }
 
+// ScaleFit scales to fit within width x height without distorting. With
+// pad, the result is centered on a width x height canvas (letterbox or
+// pillarbox bars) in the PadColor background.
+func (fb *FilterBuilder) ScaleFit(width, height int, pad bool) *FilterBuilder {
+	if width <= 0 || height <= 0 {
+		return fb
+	}
+	fb.filters = append(fb.filters, fmt.Sprintf("scale=%d:%d:force_original_aspect_ratio=decrease", width, height))
+	if pad {
+		color := fb.padColor
+		if color == "" {
+			color = DefaultPadColor
+		}
+		fb.filters = append(fb.filters, fmt.Sprintf("pad=%d:%d:(ow-iw)/2:(oh-ih)/2:color=%s", width, height, color))
+	}
+	return fb
+}
+
+// PadColor sets the background color for later ScaleFit padding
+// (an ffmpeg color name or hex like "#101010")
+func (fb *FilterBuilder) PadColor(color string) *FilterBuilder {
+	fb.padColor = color
+	return fb
+}
+
 // FPS adds an fps filter
 func (fb *FilterBuilder) FPS(fps float64) *FilterBuilder {
 	if fps <= 0 {
@@ -56,6 +85,15 @@ func (fb *FilterBuilder) Fade(fadeIn, fadeOut bool, duration int) *FilterBuilder
 	return fb
 }
 
+// Blur adds a gaussian blur of the given strength
+func (fb *FilterBuilder) Blur(sigma float64) *FilterBuilder {
+	if sigma <= 0 {
+		return fb
+	}
+	fb.filters = append(fb.filters, fmt.Sprintf("gblur=sigma=%g", sigma))
+	return fb
+}
+
 // AudioVolume adjusts audio volume
 func (fb *FilterBuilder) AudioVolume(volumeDB float64) *FilterBuilder {
 	fb.filters = append(fb.filters, fmt.Sprintf("volume=%fdB", volumeDB))
@@ -80,3 +118,13 @@ func (fb *FilterBuilder) Build() string {
 func (fb *FilterBuilder) BuildAll() []string {
 	return fb.filters
 }
+
+// ScaleWidth adds a scale filter to the given width, keeping the aspect
+// ratio (height is rounded to an even number for encoders that need it)
+func (fb *FilterBuilder) ScaleWidth(width int) *FilterBuilder {
+	if width <= 0 {
+		return fb
+	}
+	fb.filters = append(fb.filters, fmt.Sprintf("scale=%d:-2", width))
+	return fb
+}
@@ -0,0 +1,55 @@
+package ffmpeg
+
+import "testing"
+
+func TestScaleFit(t *testing.T) {
+	tests := []struct {
+		name string
+		fb   *FilterBuilder
+		want string
+	}{
+		{
+			// Vertical source into a landscape frame: bars left and right
+			name: "pillarbox",
+			fb:   NewFilterBuilder().ScaleFit(1920, 1080, true),
+			want: "scale=1920:1080:force_original_aspect_ratio=decrease,pad=1920:1080:(ow-iw)/2:(oh-ih)/2:color=black",
+		},
+		{
+			// Landscape source into a vertical frame: bars top and bottom
+			name: "letterbox",
+			fb:   NewFilterBuilder().PadColor("#101010").ScaleFit(1080, 1920, true),
+			want: "scale=1080:1920:force_original_aspect_ratio=decrease,pad=1080:1920:(ow-iw)/2:(oh-ih)/2:color=#101010",
+		},
+		{
+			name: "no pad",
+			fb:   NewFilterBuilder().ScaleFit(1080, 1920, false),
+			want: "scale=1080:1920:force_original_aspect_ratio=decrease",
+		},
+		{
+			name: "invalid size",
+			fb:   NewFilterBuilder().ScaleFit(0, 1920, true),
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.fb.Build(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildFilterChainFit(t *testing.T) {
+	stretched := buildFilterChain(RenderOptions{Width: 1280, Height: 720})
+	if len(stretched) != 1 || stretched[0] != "scale=1280:720" {
+		t.Errorf("expected a plain scale, got %v", stretched)
+	}
+
+	fitted := buildFilterChain(RenderOptions{Width: 1280, Height: 720, Fit: true})
+	want := []string{"scale=1280:720:force_original_aspect_ratio=decrease", "pad=1280:720:(ow-iw)/2:(oh-ih)/2:color=black"}
+	if len(fitted) != len(want) || fitted[0] != want[0] || fitted[1] != want[1] {
+		t.Errorf("expected %v, got %v", want, fitted)
+	}
+}
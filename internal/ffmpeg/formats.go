@@ -0,0 +1,87 @@
+package ffmpeg
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ContainerFormat is an output container and the codecs it defaults to
+type ContainerFormat struct {
+	Name string
+	// Extensions accepted for the container; the first is the default
+	Extensions []string
+	VideoCodec string
+	AudioCodec string
+}
+
+// codecVP9 is libvpx's VP9 encoder, which takes its own rate control flags
+const codecVP9 = "libvpx-vp9"
+
+// containerFormats are the formats a render can target by name
+var containerFormats = map[string]ContainerFormat{
+	"mp4":  {Name: "mp4", Extensions: []string{".mp4", ".m4v"}, VideoCodec: DefaultVideoCodec, AudioCodec: DefaultAudioCodec},
+	"mov":  {Name: "mov", Extensions: []string{".mov"}, VideoCodec: DefaultVideoCodec, AudioCodec: DefaultAudioCodec},
+	"mkv":  {Name: "mkv", Extensions: []string{".mkv"}, VideoCodec: DefaultVideoCodec, AudioCodec: DefaultAudioCodec},
+	"webm": {Name: "webm", Extensions: []string{".webm"}, VideoCodec: codecVP9, AudioCodec: "libopus"},
+}
+
+// LookupFormat returns the named container format (case-insensitive, a
+// leading dot is ignored)
+func LookupFormat(name string) (ContainerFormat, error) {
+	format, ok := containerFormats[strings.ToLower(strings.TrimPrefix(name, "."))]
+	if !ok {
+		return ContainerFormat{}, fmt.Errorf("unknown format %q (use %s)", name, strings.Join(FormatNames(), ", "))
+	}
+	return format, nil
+}
+
+// FormatNames lists the registered container formats, sorted
+func FormatNames() []string {
+	names := make([]string, 0, len(containerFormats))
+	for name := range containerFormats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Ext returns the format's default file extension, e.g. ".webm"
+func (f ContainerFormat) Ext() string {
+	return f.Extensions[0]
+}
+
+// CheckExtension reports an error when path's extension doesn't belong to
+// the format
+func (f ContainerFormat) CheckExtension(path string) error {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, e := range f.Extensions {
+		if ext == e {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s doesn't match format %s (expected %s)", path, f.Name, strings.Join(f.Extensions, " or "))
+}
+
+// vp9QualityArgs is constant-quality VP9: CRF only applies with -b:v 0
+func vp9QualityArgs(crf int, preset string) []string {
+	return []string{"-crf", fmt.Sprintf("%d", crf), "-b:v", "0", "-row-mt", "1", "-cpu-used", vp9Speed(preset)}
+}
+
+// vp9Speed maps x264 preset names onto libvpx's -cpu-used (0 slowest - 8
+// fastest)
+func vp9Speed(preset string) string {
+	switch preset {
+	case "ultrafast", "superfast":
+		return "8"
+	case "veryfast", "faster":
+		return "5"
+	case "fast":
+		return "3"
+	case "slow", "slower", "veryslow", "placebo":
+		return "1"
+	default:
+		return "2"
+	}
+}
@@ -0,0 +1,44 @@
+package ffmpeg
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLookupFormat(t *testing.T) {
+	webm, err := LookupFormat(".WebM")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if webm.VideoCodec != "libvpx-vp9" || webm.AudioCodec != "libopus" || webm.Ext() != ".webm" {
+		t.Errorf("unexpected webm defaults %+v", webm)
+	}
+
+	if _, err := LookupFormat("avi"); err == nil || !strings.Contains(err.Error(), "mkv, mov, mp4, webm") {
+		t.Errorf("expected an unknown format error listing the formats, got %v", err)
+	}
+}
+
+func TestFormatCheckExtension(t *testing.T) {
+	mp4, _ := LookupFormat("mp4")
+	for _, path := range []string{"out.mp4", "out.M4V"} {
+		if err := mp4.CheckExtension(path); err != nil {
+			t.Errorf("%s: %v", path, err)
+		}
+	}
+	if err := mp4.CheckExtension("out.webm"); err == nil {
+		t.Error("expected a .webm output to be rejected for mp4")
+	}
+}
+
+func TestVP9RateControl(t *testing.T) {
+	enc := videoEncoder{codec: codecVP9}
+
+	got := strings.Join(enc.qualityArgs(31, "ultrafast"), " ")
+	if got != "-crf 31 -b:v 0 -row-mt 1 -cpu-used 8" {
+		t.Errorf("unexpected VP9 quality args %q", got)
+	}
+	if got := strings.Join(enc.bitrateArgs("2M", "medium"), " "); got != "-b:v 2M -cpu-used 2" {
+		t.Errorf("unexpected VP9 bitrate args %q", got)
+	}
+}
@@ -0,0 +1,122 @@
+package ffmpeg
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// FrameFormat is the image format written by ExtractFrameOpts
+type FrameFormat string
+
+const (
+	FrameJPEG FrameFormat = "jpeg"
+	FramePNG  FrameFormat = "png"
+)
+
+// DefaultFrameQuality is the JPEG quality scale used for extracted frames
+// (ffmpeg -q:v, 2 = best, 31 = worst)
+const DefaultFrameQuality = 2
+
+// FrameOptions configures ExtractFrameOpts
+type FrameOptions struct {
+	// Format of the image; empty infers it from the output extension
+	// (.png is PNG, anything else JPEG)
+	Format FrameFormat
+	// Width scales the frame keeping its aspect ratio (0 = source size)
+	Width int
+	// Quality is the JPEG quality scale, 2 (best) to 31 (0 = default);
+	// ignored for lossless PNG
+	Quality int
+}
+
+// ExtractFrame extracts a single JPEG frame at the specified time
+func (e *Executor) ExtractFrame(ctx context.Context, input string, at time.Duration, output string) error {
+	return e.ExtractFrameOpts(ctx, input, at, output, FrameOptions{})
+}
+
+// ExtractFrameOpts extracts a single frame at the specified time with the
+// given format, size and quality
+func (e *Executor) ExtractFrameOpts(ctx context.Context, input string, at time.Duration, output string, opts FrameOptions) error {
+	args, err := frameArgs(input, at, output, opts)
+	if err != nil {
+		return err
+	}
+
+	e.logger.Debug().
+		Str("video", input).
+		Dur("timestamp", at).
+		Str("output", output).
+		Str("format", string(frameFormat(output, opts))).
+		Msg("extracting frame")
+
+	cmd := exec.CommandContext(ctx, e.ffmpegPath, args...)
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("frame extraction failed: %w", err)
+	}
+
+	return nil
+}
+
+// frameArgs builds the ffmpeg arguments for a single-frame extraction
+func frameArgs(input string, at time.Duration, output string, opts FrameOptions) ([]string, error) {
+	if input == "" {
+		return nil, fmt.Errorf("input path is required")
+	}
+	if output == "" {
+		return nil, fmt.Errorf("output path is required")
+	}
+	if opts.Width < 0 {
+		return nil, fmt.Errorf("frame width must not be negative (got %d)", opts.Width)
+	}
+	if opts.Quality != 0 && (opts.Quality < 2 || opts.Quality > 31) {
+		return nil, fmt.Errorf("frame quality must be between 2 and 31 (got %d)", opts.Quality)
+	}
+
+	args := []string{
+		"-ss", fmt.Sprintf("%.3f", at.Seconds()),
+		"-i", input,
+		"-vframes", "1",
+	}
+	if filter := NewFilterBuilder().ScaleWidth(opts.Width).Build(); filter != "" {
+		args = append(args, "-vf", filter)
+	}
+
+	switch format := frameFormat(output, opts); format {
+	case FrameJPEG:
+		quality := opts.Quality
+		if quality == 0 {
+			quality = DefaultFrameQuality
+		}
+		args = append(args, "-c:v", "mjpeg", "-q:v", fmt.Sprintf("%d", quality))
+	case FramePNG:
+		args = append(args, "-c:v", "png")
+	default:
+		return nil, fmt.Errorf("unsupported frame format: %q", format)
+	}
+
+	return append(args, "-f", "image2", "-y", output), nil
+}
+
+// frameFormat resolves the image format for output
+func frameFormat(output string, opts FrameOptions) FrameFormat {
+	if opts.Format != "" {
+		return opts.Format
+	}
+	if strings.EqualFold(filepath.Ext(output), ".png") {
+		return FramePNG
+	}
+	return FrameJPEG
+}
+
+// Ext returns the file extension for frames in this format
+func (f FrameFormat) Ext() string {
+	if f == FramePNG {
+		return ".png"
+	}
+	return ".jpg"
+}
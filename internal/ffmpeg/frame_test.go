@@ -0,0 +1,104 @@
+package ffmpeg
+
+import (
+	"context"
+	"image"
+	_ "image/png"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+func TestFrameArgs(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		opts   FrameOptions
+		want   []string
+	}{
+		{
+			name:   "default jpeg",
+			output: "frame.jpg",
+			want:   []string{"-ss", "1.500", "-i", "in.mp4", "-vframes", "1", "-c:v", "mjpeg", "-q:v", "2", "-f", "image2", "-y", "frame.jpg"},
+		},
+		{
+			name:   "png from extension with scaling",
+			output: "frame.PNG",
+			opts:   FrameOptions{Width: 320},
+			want:   []string{"-ss", "1.500", "-i", "in.mp4", "-vframes", "1", "-vf", "scale=320:-2", "-c:v", "png", "-f", "image2", "-y", "frame.PNG"},
+		},
+		{
+			name:   "explicit jpeg quality",
+			output: "frame.out",
+			opts:   FrameOptions{Format: FrameJPEG, Quality: 8},
+			want:   []string{"-ss", "1.500", "-i", "in.mp4", "-vframes", "1", "-c:v", "mjpeg", "-q:v", "8", "-f", "image2", "-y", "frame.out"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := frameArgs("in.mp4", 1500*time.Millisecond, tt.output, tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("frameArgs = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFrameArgsInvalid(t *testing.T) {
+	tests := []FrameOptions{
+		{Width: -1},
+		{Quality: 1},
+		{Quality: 40},
+		{Format: "bmp"},
+	}
+
+	for _, opts := range tests {
+		if _, err := frameArgs("in.mp4", 0, "frame.jpg", opts); err == nil {
+			t.Errorf("expected error for %+v", opts)
+		}
+	}
+}
+
+func TestExtractFramePNG(t *testing.T) {
+	skipIfNoFFmpeg(t)
+
+	testVideoPath := getTestDataPath("test.mp4")
+	if _, err := os.Stat(testVideoPath); os.IsNotExist(err) {
+		t.Skip("test video not found")
+	}
+
+	exec, err := New(zerolog.Nop(), 0)
+	if err != nil {
+		t.Fatalf("failed to create executor: %v", err)
+	}
+
+	output := filepath.Join(t.TempDir(), "frame.png")
+	if err := exec.ExtractFrameOpts(context.Background(), testVideoPath, time.Second, output, FrameOptions{Width: 320}); err != nil {
+		t.Fatalf("ExtractFrameOpts: %v", err)
+	}
+
+	f, err := os.Open(output)
+	if err != nil {
+		t.Fatalf("frame not written: %v", err)
+	}
+	defer f.Close()
+
+	cfg, format, err := image.DecodeConfig(f)
+	if err != nil {
+		t.Fatalf("failed to decode frame: %v", err)
+	}
+	if format != "png" {
+		t.Errorf("format = %q, want png", format)
+	}
+	if cfg.Width != 320 {
+		t.Errorf("width = %d, want 320", cfg.Width)
+	}
+}
@@ -0,0 +1,135 @@
+package ffmpeg
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/keagan/slopcannon/pkg/util"
+)
+
+// Animated export limits
+const (
+	DefaultGIFFPS   = 15
+	DefaultGIFWidth = 480
+	MaxGIFWidth     = 720
+	// GIFSizeWarning is the output size above which ExportGIF warns;
+	// most chat apps and social sites reject or recompress larger GIFs
+	GIFSizeWarning = 8 << 20
+)
+
+// GIFOptions configures animated GIF/WebP export
+type GIFOptions struct {
+	Start    time.Duration
+	Duration time.Duration // 0 = to end of input
+	FPS      int
+	Width    int  // capped at MaxGIFWidth; height keeps aspect ratio
+	Palette  bool // generate an optimized palette (palettegen/paletteuse); GIF only
+	// MaxBytes overrides GIFSizeWarning (0 = default)
+	MaxBytes     int64
+	ProgressFunc ProgressFunc
+}
+
+// ExportGIF writes a looping GIF, or animated WebP when output ends in .webp
+func (e *Executor) ExportGIF(ctx context.Context, input, output string, opts GIFOptions) error {
+	args, err := buildGIFArgs(input, output, opts)
+	if err != nil {
+		return err
+	}
+
+	e.logger.Info().
+		Str("input", input).
+		Str("output", output).
+		Int("fps", opts.FPS).
+		Int("width", opts.Width).
+		Bool("palette", opts.Palette).
+		Msg("exporting animation")
+
+	total := opts.Duration
+	if total == 0 {
+		total = e.probeDuration(ctx, input, opts.ProgressFunc)
+	}
+
+	runOpts := RunOptions{
+		Args:            args,
+		ProgressHandler: opts.ProgressFunc,
+		TotalDuration:   total,
+		LogHandler: func(line string) {
+			e.logger.Debug().Str("ffmpeg", line).Msg("animation export")
+		},
+	}
+
+	if err := e.Run(ctx, runOpts); err != nil {
+		return fmt.Errorf("animation export failed: %w", err)
+	}
+
+	limit := opts.MaxBytes
+	if limit <= 0 {
+		limit = GIFSizeWarning
+	}
+	if stat, err := os.Stat(output); err == nil && stat.Size() > limit {
+		e.logger.Warn().
+			Str("output", output).
+			Int64("bytes", stat.Size()).
+			Int64("limit", limit).
+			Msg("animation is large; lower fps, width or duration")
+	}
+
+	e.logger.Info().Str("output", output).Msg("animation export complete")
+	return nil
+}
+
+// buildGIFArgs builds the ffmpeg arguments for ExportGIF
+func buildGIFArgs(input, output string, opts GIFOptions) ([]string, error) {
+	if opts.Duration < 0 || opts.Start < 0 {
+		return nil, fmt.Errorf("invalid animation range: start and duration must not be negative")
+	}
+
+	fps := opts.FPS
+	if fps <= 0 {
+		fps = DefaultGIFFPS
+	}
+	width := opts.Width
+	if width <= 0 {
+		width = DefaultGIFWidth
+	}
+	if width > MaxGIFWidth {
+		width = MaxGIFWidth
+	}
+
+	var args []string
+	if opts.Start > 0 {
+		args = append(args, "-ss", util.FormatDuration(opts.Start))
+	}
+	if opts.Duration > 0 {
+		args = append(args, "-t", util.FormatDuration(opts.Duration))
+	}
+	args = append(args, "-i", input)
+
+	base := fmt.Sprintf("fps=%d,scale=%d:-2:flags=lanczos", fps, width)
+
+	switch strings.ToLower(filepath.Ext(output)) {
+	case ".webp":
+		args = append(args,
+			"-vf", base,
+			"-c:v", "libwebp",
+			"-lossless", "0",
+			"-q:v", "75",
+			"-loop", "0",
+			"-an",
+		)
+	case ".gif":
+		filter := base
+		if opts.Palette {
+			filter = base + ",split[s0][s1];[s0]palettegen=stats_mode=diff[p];[s1][p]paletteuse=dither=bayer:bayer_scale=5"
+		}
+		args = append(args, "-filter_complex", filter, "-loop", "0", "-an")
+	default:
+		return nil, fmt.Errorf("unsupported animation format %q (use .gif or .webp)", filepath.Ext(output))
+	}
+
+	return append(args, output), nil
+}
@@ -0,0 +1,50 @@
+package ffmpeg
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildGIFArgs(t *testing.T) {
+	args, err := buildGIFArgs("in.mp4", "out.gif", GIFOptions{
+		Start:    2 * time.Second,
+		Duration: 3 * time.Second,
+		Width:    4000,
+		Palette:  true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	joined := strings.Join(args, " ")
+	for _, want := range []string{"-ss 00:00:02.000", "-t 00:00:03.000", "fps=15,scale=720:-2", "palettegen", "paletteuse", "-loop 0"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("args %q should contain %q", joined, want)
+		}
+	}
+	if args[len(args)-1] != "out.gif" {
+		t.Errorf("output should be last, got %q", args[len(args)-1])
+	}
+}
+
+func TestBuildGIFArgsWebP(t *testing.T) {
+	args, err := buildGIFArgs("in.mp4", "out.WEBP", GIFOptions{FPS: 10, Palette: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	joined := strings.Join(args, " ")
+	if !strings.Contains(joined, "libwebp") || strings.Contains(joined, "palettegen") {
+		t.Errorf("unexpected webp args %q", joined)
+	}
+	if !strings.Contains(joined, "fps=10,scale=480:-2") {
+		t.Errorf("expected default width with fps 10, got %q", joined)
+	}
+}
+
+func TestBuildGIFArgsRejectsUnknownFormat(t *testing.T) {
+	if _, err := buildGIFArgs("in.mp4", "out.mp4", GIFOptions{}); err == nil {
+		t.Error("expected error for .mp4 output")
+	}
+}
@@ -0,0 +1,195 @@
+package ffmpeg
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// HWAccel selects a hardware video encoder
+type HWAccel string
+
+const (
+	HWAccelNone         HWAccel = "none"
+	HWAccelAuto         HWAccel = "auto"
+	HWAccelNVENC        HWAccel = "nvenc"
+	HWAccelVideoToolbox HWAccel = "videotoolbox"
+	HWAccelVAAPI        HWAccel = "vaapi"
+)
+
+// DefaultVAAPIDevice is the render node used for VAAPI encoding
+const DefaultVAAPIDevice = "/dev/dri/renderD128"
+
+// hwEncoders maps each accelerator to its H.264 encoder name
+var hwEncoders = map[HWAccel]string{
+	HWAccelNVENC:        "h264_nvenc",
+	HWAccelVideoToolbox: "h264_videotoolbox",
+	HWAccelVAAPI:        "h264_vaapi",
+}
+
+// videoEncoder describes the resolved encoder for a render
+type videoEncoder struct {
+	codec     string
+	accel     HWAccel
+	inputArgs []string // placed before the first -i
+	filters   []string // appended to the video filter chain
+}
+
+// probeEncoders runs `ffmpeg -encoders` and returns the available encoder names
+func probeEncoders(ctx context.Context, ffmpegPath string) (map[string]bool, error) {
+	out, err := exec.CommandContext(ctx, ffmpegPath, "-hide_banner", "-encoders").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list encoders: %w", err)
+	}
+	return parseEncoders(string(out)), nil
+}
+
+// parseEncoders extracts encoder names from `ffmpeg -encoders` output
+func parseEncoders(output string) map[string]bool {
+	encoders := make(map[string]bool)
+	inList := false
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !inList {
+			// The encoder table starts after the "------" legend separator
+			inList = strings.HasPrefix(line, "------")
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) >= 2 {
+			encoders[fields[1]] = true
+		}
+	}
+
+	return encoders
+}
+
+// selectEncoder resolves the video encoder for the requested acceleration,
+// falling back to software encoding when the hardware encoder is missing
+func (e *Executor) selectEncoder(opts RenderOptions) videoEncoder {
+	codec := opts.VideoCodec
+	if codec == "" {
+		codec = DefaultVideoCodec
+	}
+	software := videoEncoder{codec: codec, accel: HWAccelNone}
+
+	accel := opts.HWAccel
+	if accel == "" || accel == HWAccelNone {
+		return software
+	}
+
+	// Hardware encoders don't support ffmpeg's two-pass log files
+	if opts.TwoPass {
+		e.logger.Debug().Msg("two-pass encoding requested; skipping hardware acceleration")
+		return software
+	}
+
+	// Only substitute the default H.264 encoder; explicit codecs win
+	if codec != DefaultVideoCodec {
+		e.logger.Debug().Str("codec", codec).Msg("explicit video codec set; skipping hardware acceleration")
+		return software
+	}
+
+	if accel == HWAccelAuto {
+		for _, candidate := range autoAccelOrder() {
+			if e.encoders[hwEncoders[candidate]] {
+				accel = candidate
+				break
+			}
+		}
+		if accel == HWAccelAuto {
+			e.logger.Debug().Msg("no hardware encoder available; using software encoding")
+			return software
+		}
+	}
+
+	name, ok := hwEncoders[accel]
+	if !ok || !e.encoders[name] {
+		e.logger.Warn().
+			Str("hwaccel", string(accel)).
+			Msg("hardware encoder not available in this ffmpeg build; falling back to software")
+		return software
+	}
+
+	enc := videoEncoder{codec: name, accel: accel}
+	if accel == HWAccelVAAPI {
+		enc.inputArgs = []string{"-vaapi_device", DefaultVAAPIDevice}
+		enc.filters = []string{"format=nv12", "hwupload"}
+	}
+
+	e.logger.Debug().Str("encoder", name).Msg("using hardware encoder")
+	return enc
+}
+
+// autoAccelOrder returns accelerators in preference order for this platform
+func autoAccelOrder() []HWAccel {
+	if runtime.GOOS == "darwin" {
+		return []HWAccel{HWAccelVideoToolbox, HWAccelNVENC}
+	}
+	return []HWAccel{HWAccelNVENC, HWAccelVAAPI}
+}
+
+// qualityArgs returns the rate-control arguments for the encoder
+func (v videoEncoder) qualityArgs(crf int, preset string) []string {
+	switch v.accel {
+	case HWAccelNVENC:
+		// NVENC uses constant-quality (-cq) instead of CRF
+		return []string{"-rc", "vbr", "-cq", fmt.Sprintf("%d", crf), "-preset", nvencPreset(preset)}
+	case HWAccelVideoToolbox:
+		// VideoToolbox quality is 1-100 (higher is better)
+		q := 100 - crf*2
+		if q < 1 {
+			q = 1
+		}
+		return []string{"-q:v", fmt.Sprintf("%d", q)}
+	case HWAccelVAAPI:
+		return []string{"-qp", fmt.Sprintf("%d", crf)}
+	default:
+		if v.codec == codecVP9 {
+			return vp9QualityArgs(crf, preset)
+		}
+		return []string{"-crf", fmt.Sprintf("%d", crf), "-preset", preset}
+	}
+}
+
+// bitrateArgs returns target-bitrate rate control arguments for the encoder
+func (v videoEncoder) bitrateArgs(bitrate, preset string) []string {
+	args := []string{"-b:v", bitrate}
+	switch v.accel {
+	case HWAccelNVENC:
+		return append(args, "-preset", nvencPreset(preset))
+	case HWAccelVideoToolbox, HWAccelVAAPI:
+		return args
+	default:
+		if v.codec == codecVP9 {
+			return append(args, "-cpu-used", vp9Speed(preset))
+		}
+		return append(args, "-preset", preset)
+	}
+}
+
+// nvencPreset maps x264 preset names onto NVENC's p1 (fastest) - p7 (slowest)
+func nvencPreset(preset string) string {
+	switch preset {
+	case "ultrafast", "superfast":
+		return "p1"
+	case "veryfast":
+		return "p2"
+	case "faster", "fast":
+		return "p3"
+	case "slow":
+		return "p5"
+	case "slower":
+		return "p6"
+	case "veryslow", "placebo":
+		return "p7"
+	default:
+		return "p4"
+	}
+}
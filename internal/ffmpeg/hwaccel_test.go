@@ -0,0 +1,54 @@
+package ffmpeg
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+const cannedEncoders = `Encoders:
+ V..... = Video
+ A..... = Audio
+ ------
+ V....D libx264              libx264 H.264 / AVC / MPEG-4 AVC / MPEG-4 part 10 (codec h264)
+ V....D h264_nvenc           NVIDIA NVENC H.264 encoder (codec h264)
+ A....D aac                  AAC (Advanced Audio Coding)
+`
+
+func TestParseEncoders(t *testing.T) {
+	encoders := parseEncoders(cannedEncoders)
+
+	for _, name := range []string{"libx264", "h264_nvenc", "aac"} {
+		if !encoders[name] {
+			t.Errorf("expected encoder %q to be detected", name)
+		}
+	}
+	if encoders["Video"] || encoders["="] {
+		t.Error("legend lines should not be parsed as encoders")
+	}
+}
+
+func TestSelectEncoder(t *testing.T) {
+	e := &Executor{logger: zerolog.Nop(), encoders: parseEncoders(cannedEncoders)}
+
+	enc := e.selectEncoder(RenderOptions{HWAccel: HWAccelNVENC})
+	if enc.codec != "h264_nvenc" {
+		t.Errorf("expected h264_nvenc, got %s", enc.codec)
+	}
+	args := enc.qualityArgs(23, "medium")
+	if args[2] != "-cq" {
+		t.Errorf("expected NVENC to use -cq, got %v", args)
+	}
+
+	// Missing accelerator falls back to software
+	enc = e.selectEncoder(RenderOptions{HWAccel: HWAccelVAAPI})
+	if enc.codec != DefaultVideoCodec {
+		t.Errorf("expected fallback to %s, got %s", DefaultVideoCodec, enc.codec)
+	}
+
+	// Explicit codecs are never substituted
+	enc = e.selectEncoder(RenderOptions{HWAccel: HWAccelAuto, VideoCodec: "libvpx-vp9"})
+	if enc.codec != "libvpx-vp9" {
+		t.Errorf("expected explicit codec to win, got %s", enc.codec)
+	}
+}
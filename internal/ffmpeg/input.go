@@ -0,0 +1,24 @@
+package ffmpeg
+
+import "strings"
+
+// IsURLInput reports whether input is something ffmpeg opens itself rather
+// than a local path: a protocol URL (http://, rtmp://, file://...), a pipe
+// (pipe:0) or stdin ("-"). Such inputs can't be stat'ed or made absolute.
+func IsURLInput(input string) bool {
+	if input == "-" || strings.HasPrefix(input, "pipe:") {
+		return true
+	}
+
+	scheme, _, ok := strings.Cut(input, "://")
+	// A one-letter "scheme" is a Windows drive (C://...), not a protocol
+	if !ok || len(scheme) < 2 {
+		return false
+	}
+	for _, r := range scheme {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '+' || r == '-' || r == '.') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,59 @@
+package ffmpeg
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestIsURLInput(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"https://example.com/video.mp4", true},
+		{"rtmp://live.example.com/app/key", true},
+		{"file:///tmp/video.mp4", true},
+		{"pipe:0", true},
+		{"-", true},
+		{"video.mp4", false},
+		{"/tmp/My Video.mp4", false},
+		{`C:\Videos\clip.mp4`, false},
+		{"C://Videos/clip.mp4", false},
+		{"weird name://x.mp4", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsURLInput(tt.input); got != tt.want {
+			t.Errorf("IsURLInput(%q) = %v, expected %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestProbeVideoFileURL(t *testing.T) {
+	skipIfNoFFmpeg(t)
+
+	testVideoPath, err := filepath.Abs(getTestDataPath("test.mp4"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(testVideoPath); os.IsNotExist(err) {
+		t.Skipf("test video not found at %s", testVideoPath)
+	}
+
+	exec, err := New(zerolog.New(os.Stderr), 2)
+	if err != nil {
+		t.Fatalf("failed to create executor: %v", err)
+	}
+
+	info, err := exec.ProbeVideoCached(context.Background(), "file://"+filepath.ToSlash(testVideoPath))
+	if err != nil {
+		t.Fatalf("probing a file:// URL failed: %v", err)
+	}
+	if info.Duration == 0 {
+		t.Error("duration is zero")
+	}
+}
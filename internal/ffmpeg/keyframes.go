@@ -0,0 +1,106 @@
+package ffmpeg
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// keyframeSearchWindow is how far before a cut ExtractClip looks for the
+// keyframe a copy-mode cut will actually start on
+const keyframeSearchWindow = 20 * time.Second
+
+// ListKeyframes returns the timestamps of every video keyframe in input
+func (e *Executor) ListKeyframes(ctx context.Context, input string) ([]time.Duration, error) {
+	return e.listKeyframes(ctx, input, "")
+}
+
+// listKeyframes lists keyframes, optionally restricted to an ffprobe
+// -read_intervals expression
+func (e *Executor) listKeyframes(ctx context.Context, input, interval string) ([]time.Duration, error) {
+	if input == "" {
+		return nil, fmt.Errorf("input path is required")
+	}
+
+	cmd := exec.CommandContext(ctx, e.ffprobePath, keyframeArgs(input, interval)...)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("keyframe listing failed: %w", err)
+	}
+
+	return parseKeyframes(output)
+}
+
+// keyframeArgs builds the ffprobe arguments for ListKeyframes
+func keyframeArgs(input, interval string) []string {
+	args := []string{
+		"-v", "error",
+		"-select_streams", "v:0",
+		"-skip_frame", "nokey",
+		"-show_frames",
+		"-show_entries", "frame=pts_time",
+		"-of", "csv=p=0",
+	}
+	if interval != "" {
+		args = append(args, "-read_intervals", interval)
+	}
+	return append(args, input)
+}
+
+// parseKeyframes parses one pts_time per line into sorted timestamps.
+// Frames without a timestamp ("N/A") are skipped.
+func parseKeyframes(output []byte) ([]time.Duration, error) {
+	var keyframes []time.Duration
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(scanner.Text()), ","))
+		if line == "" || line == "N/A" {
+			continue
+		}
+		secs, err := strconv.ParseFloat(line, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid keyframe timestamp %q: %w", line, err)
+		}
+		keyframes = append(keyframes, time.Duration(math.Round(secs*float64(time.Second))))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read keyframes: %w", err)
+	}
+
+	sort.Slice(keyframes, func(i, j int) bool { return keyframes[i] < keyframes[j] })
+	return keyframes, nil
+}
+
+// precedingKeyframe returns the last keyframe at or before ts; ok is false
+// when there is none
+func precedingKeyframe(keyframes []time.Duration, ts time.Duration) (time.Duration, bool) {
+	i := sort.Search(len(keyframes), func(i int) bool { return keyframes[i] > ts })
+	if i == 0 {
+		return 0, false
+	}
+	return keyframes[i-1], true
+}
+
+// keyframeDrift reports where a copy-mode cut at start will really begin
+func (e *Executor) keyframeDrift(ctx context.Context, input string, start time.Duration) (time.Duration, bool) {
+	from := start - keyframeSearchWindow
+	if from < 0 {
+		from = 0
+	}
+	interval := fmt.Sprintf("%.3f%%%.3f", from.Seconds(), start.Seconds()+0.001)
+
+	keyframes, err := e.listKeyframes(ctx, input, interval)
+	if err != nil {
+		e.logger.Debug().Err(err).Str("input", input).Msg("could not list keyframes")
+		return 0, false
+	}
+	return precedingKeyframe(keyframes, start)
+}
@@ -0,0 +1,51 @@
+package ffmpeg
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseKeyframes(t *testing.T) {
+	output := []byte("4.004000\n0.000000\nN/A\n\n2.002000,\n")
+
+	keyframes, err := parseKeyframes(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []time.Duration{0, 2002 * time.Millisecond, 4004 * time.Millisecond}
+	if len(keyframes) != len(want) {
+		t.Fatalf("expected %v, got %v", want, keyframes)
+	}
+	for i := range want {
+		if keyframes[i] != want[i] {
+			t.Errorf("keyframe %d: expected %v, got %v", i, want[i], keyframes[i])
+		}
+	}
+
+	if _, err := parseKeyframes([]byte("abc\n")); err == nil {
+		t.Error("expected error for invalid timestamp")
+	}
+}
+
+func TestPrecedingKeyframe(t *testing.T) {
+	keyframes := []time.Duration{2 * time.Second, 4 * time.Second, 6 * time.Second}
+
+	tests := []struct {
+		ts     time.Duration
+		want   time.Duration
+		wantOK bool
+	}{
+		{1 * time.Second, 0, false},
+		{2 * time.Second, 2 * time.Second, true},
+		{5500 * time.Millisecond, 4 * time.Second, true},
+		{10 * time.Second, 6 * time.Second, true},
+	}
+
+	for _, tt := range tests {
+		got, ok := precedingKeyframe(keyframes, tt.ts)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("precedingKeyframe(%v) = %v, %v; expected %v, %v", tt.ts, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package ffmpeg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+// ffmpeg -loglevel values used by the executor
+const (
+	LogLevelQuiet   = "quiet"
+	LogLevelError   = "error"
+	LogLevelWarning = "warning"
+	LogLevelInfo    = "info"
+	LogLevelVerbose = "verbose"
+	LogLevelDebug   = "debug"
+)
+
+// logLevelOrder lists every ffmpeg log level, quietest first
+var logLevelOrder = []string{"quiet", "panic", "fatal", "error", "warning", "info", "verbose", "debug", "trace"}
+
+// LogLevelFor maps the application log level onto ffmpeg's: debug logging
+// shows verbose ffmpeg output, normal runs only warnings, and quiet modes
+// only errors
+func LogLevelFor(level zerolog.Level) string {
+	switch {
+	case level <= zerolog.TraceLevel:
+		return LogLevelDebug
+	case level == zerolog.DebugLevel:
+		return LogLevelVerbose
+	case level == zerolog.InfoLevel:
+		return LogLevelWarning
+	default:
+		return LogLevelError
+	}
+}
+
+// SetLogLevel sets the ffmpeg -loglevel for every run
+func (e *Executor) SetLogLevel(level string) error {
+	if logLevelRank(level) < 0 {
+		return fmt.Errorf("invalid ffmpeg log level %q (one of: %s)", level, strings.Join(logLevelOrder, ", "))
+	}
+	e.logLevel = level
+	return nil
+}
+
+// runLogLevel returns the level for one run: the executor's level, raised
+// to min for runs that parse ffmpeg's log output. Progress comes from
+// -progress, which is written at any level.
+func runLogLevel(level, min string) string {
+	if level == "" {
+		level = LogLevelInfo
+	}
+	if min != "" && logLevelRank(level) < logLevelRank(min) {
+		return min
+	}
+	return level
+}
+
+// logLevelRank orders levels by verbosity (-1 for unknown levels)
+func logLevelRank(level string) int {
+	for i, l := range logLevelOrder {
+		if l == level {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,55 @@
+package ffmpeg
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestLogLevelFor(t *testing.T) {
+	tests := []struct {
+		level zerolog.Level
+		want  string
+	}{
+		{zerolog.TraceLevel, LogLevelDebug},
+		{zerolog.DebugLevel, LogLevelVerbose},
+		{zerolog.InfoLevel, LogLevelWarning},
+		{zerolog.WarnLevel, LogLevelError},
+		{zerolog.ErrorLevel, LogLevelError},
+	}
+
+	for _, tt := range tests {
+		if got := LogLevelFor(tt.level); got != tt.want {
+			t.Errorf("LogLevelFor(%s) = %q, want %q", tt.level, got, tt.want)
+		}
+	}
+}
+
+func TestRunLogLevel(t *testing.T) {
+	tests := []struct {
+		level, min, want string
+	}{
+		{"", "", LogLevelInfo},
+		{LogLevelError, "", LogLevelError},
+		// Parsing runs need at least info output
+		{LogLevelError, LogLevelInfo, LogLevelInfo},
+		{LogLevelWarning, LogLevelInfo, LogLevelInfo},
+		{LogLevelDebug, LogLevelInfo, LogLevelDebug},
+	}
+
+	for _, tt := range tests {
+		if got := runLogLevel(tt.level, tt.min); got != tt.want {
+			t.Errorf("runLogLevel(%q, %q) = %q, want %q", tt.level, tt.min, got, tt.want)
+		}
+	}
+}
+
+func TestSetLogLevel(t *testing.T) {
+	e := &Executor{}
+	if err := e.SetLogLevel(LogLevelVerbose); err != nil || e.logLevel != LogLevelVerbose {
+		t.Errorf("SetLogLevel(verbose): err=%v level=%q", err, e.logLevel)
+	}
+	if err := e.SetLogLevel("loud"); err == nil {
+		t.Error("expected error for an unknown level")
+	}
+}
@@ -0,0 +1,193 @@
+package ffmpeg
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Loudness defaults (EBU R128, matching what most social platforms target)
+const (
+	DefaultLoudnessTarget = -14.0
+	DefaultTruePeak       = -1.5
+	DefaultLoudnessRange  = 11.0
+)
+
+// NormalizeOptions configures loudness normalization
+type NormalizeOptions struct {
+	TargetLevel float64 // integrated loudness in LUFS (default DefaultLoudnessTarget)
+	TruePeak    float64 // dBTP ceiling (default DefaultTruePeak)
+	LRA         float64 // loudness range (default DefaultLoudnessRange)
+	// TwoPass measures the input first and feeds the measurements to the
+	// second pass, giving accurate linear normalization
+	TwoPass      bool
+	ProgressFunc ProgressFunc
+}
+
+// LoudnessMeasurement is loudnorm's first-pass analysis of an input
+type LoudnessMeasurement struct {
+	InputI       float64
+	InputTP      float64
+	InputLRA     float64
+	InputThresh  float64
+	TargetOffset float64
+}
+
+// NormalizeAudioWithOptions normalizes loudness, optionally in two passes
+func (e *Executor) NormalizeAudioWithOptions(ctx context.Context, input, output string, opts NormalizeOptions) error {
+	opts = opts.withDefaults()
+
+	e.logger.Info().
+		Str("input", input).
+		Str("output", output).
+		Float64("target_level", opts.TargetLevel).
+		Bool("two_pass", opts.TwoPass).
+		Msg("normalizing audio")
+
+	filter := loudnormFilter(opts)
+	if opts.TwoPass {
+		m, err := e.MeasureLoudness(ctx, input, opts)
+		if err != nil {
+			return err
+		}
+		e.logger.Debug().
+			Float64("input_i", m.InputI).
+			Float64("input_tp", m.InputTP).
+			Float64("input_lra", m.InputLRA).
+			Msg("measured loudness")
+		filter = loudnormMeasuredFilter(opts, m)
+	}
+
+	args := []string{
+		"-i", input,
+		"-af", filter,
+		"-ar", "48000", // loudnorm resamples to 192kHz internally
+		"-c:v", "copy", // copy video stream
+		output,
+	}
+
+	runOpts := RunOptions{
+		Args:            args,
+		ProgressHandler: opts.ProgressFunc,
+		TotalDuration:   e.probeDuration(ctx, input, opts.ProgressFunc),
+		LogHandler: func(line string) {
+			e.logger.Debug().Str("ffmpeg", line).Msg("audio normalization")
+		},
+	}
+
+	return e.Run(ctx, runOpts)
+}
+
+// MeasureLoudness runs loudnorm's analysis pass over input
+func (e *Executor) MeasureLoudness(ctx context.Context, input string, opts NormalizeOptions) (*LoudnessMeasurement, error) {
+	opts = opts.withDefaults()
+
+	var stderrBuf bytes.Buffer
+	var mu sync.Mutex
+
+	runOpts := RunOptions{
+		Args: []string{
+			"-i", input,
+			"-af", loudnormFilter(opts) + ":print_format=json",
+			"-vn",
+			"-f", "null",
+			"-",
+		},
+		ProgressHandler: opts.ProgressFunc,
+		TotalDuration:   e.probeDuration(ctx, input, opts.ProgressFunc),
+		MinLogLevel:     LogLevelInfo,
+		LogHandler: func(line string) {
+			mu.Lock()
+			stderrBuf.WriteString(line + "\n")
+			mu.Unlock()
+		},
+	}
+
+	err := e.Run(ctx, runOpts)
+
+	mu.Lock()
+	output := stderrBuf.String()
+	mu.Unlock()
+
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("loudness measurement failed: %w", err)
+	}
+
+	return parseLoudnormOutput(output)
+}
+
+func (o NormalizeOptions) withDefaults() NormalizeOptions {
+	if o.TargetLevel == 0 {
+		o.TargetLevel = DefaultLoudnessTarget
+	}
+	if o.TruePeak == 0 {
+		o.TruePeak = DefaultTruePeak
+	}
+	if o.LRA == 0 {
+		o.LRA = DefaultLoudnessRange
+	}
+	return o
+}
+
+func loudnormFilter(opts NormalizeOptions) string {
+	return fmt.Sprintf("loudnorm=I=%g:TP=%g:LRA=%g", opts.TargetLevel, opts.TruePeak, opts.LRA)
+}
+
+// loudnormMeasuredFilter builds the second-pass filter from measurements
+func loudnormMeasuredFilter(opts NormalizeOptions, m *LoudnessMeasurement) string {
+	return fmt.Sprintf("%s:measured_I=%g:measured_TP=%g:measured_LRA=%g:measured_thresh=%g:offset=%g:linear=true",
+		loudnormFilter(opts), m.InputI, m.InputTP, m.InputLRA, m.InputThresh, m.TargetOffset)
+}
+
+// parseLoudnormOutput extracts the JSON block loudnorm prints at the end of
+// the analysis pass. ffmpeg reports the numbers as strings.
+func parseLoudnormOutput(output string) (*LoudnessMeasurement, error) {
+	key := strings.LastIndex(output, `"input_i"`)
+	if key < 0 {
+		return nil, fmt.Errorf("loudnorm measurement not found in ffmpeg output")
+	}
+	start := strings.LastIndex(output[:key], "{")
+	end := strings.Index(output[key:], "}")
+	if start < 0 || end < 0 {
+		return nil, fmt.Errorf("loudnorm measurement is incomplete")
+	}
+
+	var raw map[string]string
+	if err := json.Unmarshal([]byte(output[start:key+end+1]), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse loudnorm measurement: %w", err)
+	}
+
+	var parseErr error
+	value := func(key string) float64 {
+		if parseErr != nil {
+			return 0
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(raw[key]), 64)
+		if err != nil {
+			parseErr = fmt.Errorf("invalid loudnorm %s %q: %w", key, raw[key], err)
+		} else if math.IsInf(v, 0) {
+			parseErr = fmt.Errorf("loudnorm %s is %q; input is silent", key, raw[key])
+		}
+		return v
+	}
+
+	m := &LoudnessMeasurement{
+		InputI:       value("input_i"),
+		InputTP:      value("input_tp"),
+		InputLRA:     value("input_lra"),
+		InputThresh:  value("input_thresh"),
+		TargetOffset: value("target_offset"),
+	}
+	if parseErr != nil {
+		return nil, parseErr
+	}
+	return m, nil
+}
@@ -0,0 +1,50 @@
+package ffmpeg
+
+import (
+	"strings"
+	"testing"
+)
+
+const loudnormSample = `[out#0/null @ 0x55d0] video:0kB audio:1kB
+size=N/A time=00:00:10.00 bitrate=N/A speed= 120x
+[Parsed_loudnorm_0 @ 0x55d1c8e0]
+{
+	"input_i" : "-27.61",
+	"input_tp" : "-4.47",
+	"input_lra" : "18.06",
+	"input_thresh" : "-39.20",
+	"output_i" : "-16.58",
+	"output_tp" : "-1.50",
+	"output_lra" : "14.78",
+	"output_thresh" : "-27.71",
+	"normalization_type" : "dynamic",
+	"target_offset" : "0.58"
+}
+`
+
+func TestParseLoudnormOutput(t *testing.T) {
+	m, err := parseLoudnormOutput(loudnormSample)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.InputI != -27.61 || m.InputTP != -4.47 || m.InputLRA != 18.06 || m.InputThresh != -39.20 || m.TargetOffset != 0.58 {
+		t.Errorf("unexpected measurement %+v", m)
+	}
+
+	filter := loudnormMeasuredFilter(NormalizeOptions{}.withDefaults(), m)
+	want := "loudnorm=I=-14:TP=-1.5:LRA=11:measured_I=-27.61:measured_TP=-4.47:measured_LRA=18.06:measured_thresh=-39.2:offset=0.58:linear=true"
+	if filter != want {
+		t.Errorf("unexpected filter:\n got %s\nwant %s", filter, want)
+	}
+}
+
+func TestParseLoudnormOutputErrors(t *testing.T) {
+	if _, err := parseLoudnormOutput("no json here"); err == nil {
+		t.Error("expected error without measurement")
+	}
+
+	silent := strings.Replace(loudnormSample, `"-27.61"`, `"-inf"`, 1)
+	if _, err := parseLoudnormOutput(silent); err == nil {
+		t.Error("expected error for silent input")
+	}
+}
@@ -0,0 +1,148 @@
+package ffmpeg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MediaKind classifies an input by the streams it carries
+type MediaKind string
+
+const (
+	// MediaVideo has at least one moving-picture video stream
+	MediaVideo MediaKind = "video"
+	// MediaAudio has audio but no video, cover art aside
+	MediaAudio MediaKind = "audio"
+	// MediaImage is a still image
+	MediaImage MediaKind = "image"
+	// MediaUnknown has neither audio nor video streams
+	MediaUnknown MediaKind = "unknown"
+)
+
+// StreamInfo describes one stream of a probed input
+type StreamInfo struct {
+	Index int
+	// Type is ffprobe's codec_type: video, audio, subtitle, data, ...
+	Type  string
+	Codec string
+
+	// Video streams
+	Width  int
+	Height int
+	// AttachedPic marks cover art rather than real video
+	AttachedPic bool
+
+	// Audio streams
+	Channels   int
+	SampleRate int
+}
+
+// MediaInfo is ProbeMedia's description of an input of any kind. The
+// embedded VideoInfo summarizes the first video and audio streams, so its
+// video fields are zero when HasVideo is false.
+type MediaInfo struct {
+	VideoInfo
+
+	Kind MediaKind
+	// HasVideo is set when there is a video stream that isn't cover art
+	HasVideo bool
+	// FormatName is ffprobe's container name list, e.g. "mov,mp4,m4a,3gp,3g2,mj2"
+	FormatName string
+	Streams    []StreamInfo
+}
+
+// ProbeMedia extracts metadata from any media file. Unlike ProbeVideo it
+// reports what kind of input it found, so audio-only files and images can
+// be told apart from videos.
+func (e *Executor) ProbeMedia(ctx context.Context, filePath string) (*MediaInfo, error) {
+	if filePath == "" {
+		return nil, fmt.Errorf("file path is required")
+	}
+
+	output, err := e.runProbe(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	media, err := parseMediaOutput(filePath, output)
+	if err != nil {
+		return nil, err
+	}
+	e.warnVFR(&media.VideoInfo)
+	return media, nil
+}
+
+// parseMediaOutput converts ffprobe JSON into MediaInfo
+func parseMediaOutput(filePath string, output []byte) (*MediaInfo, error) {
+	var probe probeResult
+	if err := json.Unmarshal(output, &probe); err != nil {
+		return nil, fmt.Errorf("failed to parse ffprobe output: %w", err)
+	}
+
+	media := &MediaInfo{
+		VideoInfo:  *videoInfo(filePath, &probe),
+		FormatName: probe.Format.FormatName,
+		Streams:    make([]StreamInfo, 0, len(probe.Streams)),
+	}
+
+	for _, stream := range probe.Streams {
+		s := StreamInfo{
+			Index:       stream.Index,
+			Type:        stream.CodecType,
+			Codec:       stream.CodecName,
+			Width:       stream.Width,
+			Height:      stream.Height,
+			AttachedPic: stream.Disposition.AttachedPic != 0,
+			Channels:    stream.Channels,
+		}
+		if sr, err := strconv.Atoi(stream.SampleRate); err == nil {
+			s.SampleRate = sr
+		}
+		if s.Type == "video" && !s.AttachedPic {
+			media.HasVideo = true
+		}
+		media.Streams = append(media.Streams, s)
+	}
+
+	media.Kind = mediaKind(media)
+	return media, nil
+}
+
+// mediaKind classifies media from its streams and container
+func mediaKind(media *MediaInfo) MediaKind {
+	switch {
+	case media.HasVideo && isImageFormat(media.FormatName):
+		return MediaImage
+	case media.HasVideo:
+		return MediaVideo
+	case media.HasAudio:
+		return MediaAudio
+	default:
+		return MediaUnknown
+	}
+}
+
+// isImageFormat reports whether ffprobe's format name is one of its still
+// image demuxers (image2, png_pipe, jpeg_pipe, webp_pipe, ...)
+func isImageFormat(formatName string) bool {
+	for _, name := range strings.Split(formatName, ",") {
+		if name == "image2" || strings.HasSuffix(name, "_pipe") {
+			return true
+		}
+	}
+	return false
+}
+
+// StreamsOf returns the streams of streamType, in file order
+func (m *MediaInfo) StreamsOf(streamType string) []StreamInfo {
+	var out []StreamInfo
+	for _, s := range m.Streams {
+		if s.Type == streamType {
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -0,0 +1,111 @@
+package ffmpeg
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Trimmed ffprobe output for an mp3 with embedded cover art
+const mp3ProbeSample = `{
+	"streams": [
+		{
+			"index": 0,
+			"codec_type": "audio",
+			"codec_name": "mp3",
+			"channels": 2,
+			"sample_rate": "44100",
+			"bit_rate": "320000"
+		},
+		{
+			"index": 1,
+			"codec_type": "video",
+			"codec_name": "mjpeg",
+			"width": 600,
+			"height": 600,
+			"disposition": {"default": 0, "attached_pic": 1}
+		}
+	],
+	"format": {"format_name": "mp3", "duration": "183.144000", "bit_rate": "320500"}
+}`
+
+func TestParseMediaOutputAudioOnly(t *testing.T) {
+	media, err := parseMediaOutput("song.mp3", []byte(mp3ProbeSample))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if media.Kind != MediaAudio || media.HasVideo {
+		t.Errorf("expected an audio-only file despite cover art, got kind %q (has video %v)", media.Kind, media.HasVideo)
+	}
+	if media.Width != 0 || media.Height != 0 || media.VideoCodec != "" {
+		t.Errorf("cover art should not be reported as video, got %dx%d %q", media.Width, media.Height, media.VideoCodec)
+	}
+	if !media.HasAudio || media.AudioCodec != "mp3" || media.AudioSampleRate != 44100 {
+		t.Errorf("unexpected audio details %+v", media.VideoInfo)
+	}
+	if len(media.Streams) != 2 || !media.Streams[1].AttachedPic {
+		t.Errorf("expected both streams with the cover flagged, got %+v", media.Streams)
+	}
+	if len(media.StreamsOf("audio")) != 1 {
+		t.Errorf("expected one audio stream, got %+v", media.StreamsOf("audio"))
+	}
+}
+
+func TestParseMediaOutputKinds(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   MediaKind
+	}{
+		{"video", rotatedProbeSample, MediaVideo},
+		{"image", `{"streams": [{"codec_type": "video", "codec_name": "png", "width": 64, "height": 64}],
+			"format": {"format_name": "png_pipe"}}`, MediaImage},
+		{"jpeg", `{"streams": [{"codec_type": "video", "codec_name": "mjpeg"}],
+			"format": {"format_name": "image2"}}`, MediaImage},
+		{"subtitles only", `{"streams": [{"codec_type": "subtitle", "codec_name": "subrip"}],
+			"format": {"format_name": "srt"}}`, MediaUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			media, err := parseMediaOutput("input", []byte(tt.output))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if media.Kind != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, media.Kind)
+			}
+		})
+	}
+}
+
+func TestProbeMediaMP3(t *testing.T) {
+	skipIfNoFFmpeg(t)
+
+	path := filepath.Join(t.TempDir(), "tone.mp3")
+	cmd := exec.Command("ffmpeg", "-f", "lavfi", "-i", "sine=frequency=440:duration=2", "-y", path)
+	if err := cmd.Run(); err != nil {
+		t.Skipf("Could not generate test mp3: %v", err)
+	}
+
+	e, err := New(zerolog.Nop(), 1)
+	if err != nil {
+		t.Fatalf("failed to create executor: %v", err)
+	}
+
+	media, err := e.ProbeMedia(context.Background(), path)
+	if err != nil {
+		t.Fatalf("ProbeMedia failed: %v", err)
+	}
+	if media.Kind != MediaAudio || media.HasVideo || !media.HasAudio {
+		t.Errorf("expected an audio-only input, got %+v", media)
+	}
+	if media.Duration < time.Second {
+		t.Errorf("expected about 2s of audio, got %v", media.Duration)
+	}
+}
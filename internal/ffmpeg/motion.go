@@ -0,0 +1,91 @@
+package ffmpeg
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MotionSample is the mean luma difference between consecutive frames
+type MotionSample struct {
+	Time  time.Duration
+	YDiff float64 // 0-255, higher = more motion
+}
+
+// motionSampleFPS is the frame rate motion is measured at; a low rate on a
+// downscaled copy keeps the extra decode pass cheap
+const motionSampleFPS = 4
+
+// AnalyzeMotion measures frame-to-frame motion across the video using the
+// signalstats YDIF metric
+func (e *Executor) AnalyzeMotion(ctx context.Context, input string) ([]MotionSample, error) {
+	e.logger.Info().Str("input", input).Msg("analyzing motion")
+
+	var stderrBuf bytes.Buffer
+	var mu sync.Mutex
+
+	opts := RunOptions{
+		Args: []string{
+			"-i", input,
+			"-an",
+			"-vf", fmt.Sprintf("scale=320:-2,fps=%d,signalstats,metadata=print:key=lavfi.signalstats.YDIF", motionSampleFPS),
+			"-f", "null",
+			"-",
+		},
+		MinLogLevel: LogLevelInfo,
+		LogHandler: func(line string) {
+			mu.Lock()
+			stderrBuf.WriteString(line + "\n")
+			mu.Unlock()
+		},
+	}
+
+	err := e.Run(ctx, opts)
+
+	mu.Lock()
+	output := stderrBuf.String()
+	mu.Unlock()
+
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if ErrorCategoryOf(err) != CategoryEmptyOutput {
+			return nil, fmt.Errorf("motion analysis failed: %w", err)
+		}
+	}
+
+	samples := parseMotionOutput(output)
+	e.logger.Info().Int("samples", len(samples)).Msg("motion analysis complete")
+	return samples, nil
+}
+
+// parseMotionOutput pairs metadata=print pts_time lines with the YDIF value
+// that follows them
+func parseMotionOutput(output string) []MotionSample {
+	var samples []MotionSample
+	var current time.Duration
+
+	for _, line := range strings.Split(output, "\n") {
+		if strings.Contains(line, "pts_time:") {
+			parts := strings.Split(line, "pts_time:")
+			fields := strings.Fields(parts[len(parts)-1])
+			if len(fields) > 0 {
+				if seconds, err := strconv.ParseFloat(fields[0], 64); err == nil {
+					current = time.Duration(seconds * float64(time.Second))
+				}
+			}
+		} else if strings.Contains(line, "lavfi.signalstats.YDIF=") {
+			parts := strings.SplitN(line, "lavfi.signalstats.YDIF=", 2)
+			if value, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64); err == nil {
+				samples = append(samples, MotionSample{Time: current, YDiff: value})
+			}
+		}
+	}
+
+	return samples
+}
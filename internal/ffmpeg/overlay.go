@@ -0,0 +1,133 @@
+package ffmpeg
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// OverlayInput is one overlay file and how to composite it
+type OverlayInput struct {
+	Path    string
+	Options OverlayOptions
+}
+
+// ApplyOverlays composites every overlay onto input in a single encode.
+// Overlays are stacked in order, so later entries draw on top.
+func (e *Executor) ApplyOverlays(ctx context.Context, input, output string, overlays []OverlayInput, progressFunc ProgressFunc) error {
+	if input == "" {
+		return fmt.Errorf("input path is required")
+	}
+	if output == "" {
+		return fmt.Errorf("output path is required")
+	}
+
+	graph, err := buildOverlayGraph(overlays)
+	if err != nil {
+		return err
+	}
+
+	e.logger.Info().
+		Str("input", input).
+		Int("overlays", len(overlays)).
+		Str("output", output).
+		Msg("applying overlays")
+
+	paths := make([]string, len(overlays))
+	for i, ov := range overlays {
+		paths[i] = ov.Path
+	}
+
+	return e.runOverlayGraph(ctx, input, paths, graph, output, progressFunc)
+}
+
+// runOverlayGraph encodes a filter_complex graph over input plus extra
+// inputs (numbered from 1), mapping [vout] and the base audio
+func (e *Executor) runOverlayGraph(ctx context.Context, input string, extra []string, graph, output string, progressFunc ProgressFunc) error {
+	args := []string{"-i", input}
+	for _, path := range extra {
+		args = append(args, "-i", path)
+	}
+
+	args = append(args,
+		"-filter_complex", graph,
+		"-map", "[vout]",
+		"-map", "0:a?",
+		"-c:v", DefaultVideoCodec,
+		"-crf", fmt.Sprintf("%d", DefaultCRF),
+		"-preset", e.defaultPreset(),
+		"-c:a", "copy",
+		output,
+	)
+
+	runOpts := RunOptions{
+		Args:            args,
+		ProgressHandler: progressFunc,
+		TotalDuration:   e.probeDuration(ctx, input, progressFunc),
+		LogHandler: func(line string) {
+			e.logger.Debug().Str("ffmpeg", line).Msg("overlay output")
+		},
+	}
+
+	if err := e.Run(ctx, runOpts); err != nil {
+		return fmt.Errorf("overlay merge failed: %w", err)
+	}
+
+	e.logger.Info().Str("output", output).Msg("overlay merge completed")
+	return nil
+}
+
+// buildOverlayGraph chains one overlay filter per input; input 0 is the base
+// video and overlay i is input i+1. The result is labelled [vout].
+func buildOverlayGraph(overlays []OverlayInput) (string, error) {
+	if len(overlays) == 0 {
+		return "", fmt.Errorf("at least one overlay is required")
+	}
+
+	var chains []string
+	prev := "[0:v]"
+	for i, ov := range overlays {
+		if ov.Path == "" {
+			return "", fmt.Errorf("overlay %d: path is required", i)
+		}
+		opts := ov.Options
+		if opts.End > 0 && opts.End <= opts.Start {
+			return "", fmt.Errorf("overlay %d: end must be after start", i)
+		}
+
+		src := fmt.Sprintf("[%d:v]", i+1)
+		if opts.Opacity > 0 && opts.Opacity < 1.0 {
+			faded := fmt.Sprintf("[ov%d]", i)
+			chains = append(chains, fmt.Sprintf("%sformat=rgba,colorchannelmixer=aa=%.2f%s", src, opts.Opacity, faded))
+			src = faded
+		}
+
+		out := fmt.Sprintf("[tmp%d]", i)
+		if i == len(overlays)-1 {
+			out = "[vout]"
+		}
+
+		filter := fmt.Sprintf("%s%soverlay=%d:%d", prev, src, opts.X, opts.Y)
+		if enable := overlayEnable(opts); enable != "" {
+			filter += ":enable='" + enable + "'"
+		}
+		chains = append(chains, filter+out)
+		prev = out
+	}
+
+	return strings.Join(chains, ";"), nil
+}
+
+// overlayEnable returns the time-window expression for an overlay, or ""
+// when it should be shown for the whole video
+func overlayEnable(opts OverlayOptions) string {
+	switch {
+	case opts.Start > 0 && opts.End > 0:
+		return fmt.Sprintf("between(t,%.2f,%.2f)", opts.Start.Seconds(), opts.End.Seconds())
+	case opts.Start > 0:
+		return fmt.Sprintf("gte(t,%.2f)", opts.Start.Seconds())
+	case opts.End > 0:
+		return fmt.Sprintf("lte(t,%.2f)", opts.End.Seconds())
+	}
+	return ""
+}
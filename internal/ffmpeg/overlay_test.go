@@ -0,0 +1,39 @@
+package ffmpeg
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildOverlayGraph(t *testing.T) {
+	graph, err := buildOverlayGraph([]OverlayInput{
+		{Path: "logo.png", Options: OverlayOptions{X: 10, Y: 20}},
+		{Path: "lower_third.png", Options: OverlayOptions{
+			Y:       1500,
+			Opacity: 0.5,
+			Start:   2 * time.Second,
+			End:     6 * time.Second,
+		}},
+		{Path: "outro.png", Options: OverlayOptions{Start: 30 * time.Second}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "[0:v][1:v]overlay=10:20[tmp0];" +
+		"[2:v]format=rgba,colorchannelmixer=aa=0.50[ov1];" +
+		"[tmp0][ov1]overlay=0:1500:enable='between(t,2.00,6.00)'[tmp1];" +
+		"[tmp1][3:v]overlay=0:0:enable='gte(t,30.00)'[vout]"
+	if graph != want {
+		t.Errorf("unexpected graph:\n got %s\nwant %s", graph, want)
+	}
+}
+
+func TestBuildOverlayGraphErrors(t *testing.T) {
+	if _, err := buildOverlayGraph(nil); err == nil {
+		t.Error("expected error for no overlays")
+	}
+	if _, err := buildOverlayGraph([]OverlayInput{{Path: "a.png", Options: OverlayOptions{Start: 5 * time.Second, End: time.Second}}}); err == nil {
+		t.Error("expected error for inverted window")
+	}
+}
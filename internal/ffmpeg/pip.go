@@ -0,0 +1,158 @@
+package ffmpeg
+
+import (
+	"context"
+	"fmt"
+)
+
+// PiPCorner selects where the picture-in-picture window sits
+type PiPCorner string
+
+const (
+	PiPTopLeft     PiPCorner = "top-left"
+	PiPTopRight    PiPCorner = "top-right"
+	PiPBottomLeft  PiPCorner = "bottom-left"
+	PiPBottomRight PiPCorner = "bottom-right"
+)
+
+// DefaultPiPScale is the PiP width as a fraction of the main video's width
+const DefaultPiPScale = 0.25
+
+// PiPOptions configures PictureInPicture
+type PiPOptions struct {
+	// Scale is the PiP width relative to the main video, in (0, 1]
+	// (0 = DefaultPiPScale)
+	Scale float64
+	// Corner defaults to PiPBottomRight
+	Corner PiPCorner
+	// Margin in pixels between the PiP and the frame edges
+	Margin int
+	// Optional solid border around the PiP (BorderColor defaults to white)
+	BorderWidth int
+	BorderColor string
+
+	ProgressFunc ProgressFunc
+}
+
+// pipRect is the PiP's scaled size (excluding border) and position
+// (including border) on the main frame
+type pipRect struct {
+	Width, Height int
+	X, Y          int
+}
+
+// PictureInPicture overlays a scaled-down pip video (e.g. a facecam) in a
+// corner of main (e.g. gameplay)
+func (e *Executor) PictureInPicture(ctx context.Context, main, pip, output string, opts PiPOptions) error {
+	if main == "" {
+		return fmt.Errorf("main path is required")
+	}
+	if pip == "" {
+		return fmt.Errorf("pip path is required")
+	}
+	if output == "" {
+		return fmt.Errorf("output path is required")
+	}
+
+	mainInfo, err := e.ProbeVideoCached(ctx, main)
+	if err != nil {
+		return fmt.Errorf("failed to probe main video: %w", err)
+	}
+	pipInfo, err := e.ProbeVideoCached(ctx, pip)
+	if err != nil {
+		return fmt.Errorf("failed to probe pip video: %w", err)
+	}
+
+	rect, err := pipLayout(mainInfo.Width, mainInfo.Height, pipInfo.Width, pipInfo.Height, opts)
+	if err != nil {
+		return err
+	}
+
+	e.logger.Info().
+		Str("main", main).
+		Str("pip", pip).
+		Str("output", output).
+		Str("corner", string(opts.Corner)).
+		Int("width", rect.Width).
+		Int("height", rect.Height).
+		Msg("applying picture-in-picture")
+
+	return e.runOverlayGraph(ctx, main, []string{pip}, buildPiPGraph(rect, opts), output, opts.ProgressFunc)
+}
+
+// pipLayout sizes the PiP to opts.Scale of the main width, keeping its
+// aspect ratio, shrinking it as needed so it (with border and margin) fits
+// inside the main frame, then places it in the chosen corner
+func pipLayout(mainW, mainH, pipW, pipH int, opts PiPOptions) (pipRect, error) {
+	if mainW <= 0 || mainH <= 0 || pipW <= 0 || pipH <= 0 {
+		return pipRect{}, fmt.Errorf("picture-in-picture needs known video dimensions")
+	}
+
+	scale := opts.Scale
+	if scale == 0 {
+		scale = DefaultPiPScale
+	}
+	if scale < 0 || scale > 1 {
+		return pipRect{}, fmt.Errorf("pip scale must be between 0 and 1 (got %g)", scale)
+	}
+	if opts.Margin < 0 || opts.BorderWidth < 0 {
+		return pipRect{}, fmt.Errorf("pip margin and border width must not be negative")
+	}
+
+	// Space available to the PiP itself once margin and border are taken
+	frame := 2 * (opts.Margin + opts.BorderWidth)
+	maxW, maxH := mainW-frame, mainH-frame
+	if maxW < 2 || maxH < 2 {
+		return pipRect{}, fmt.Errorf("pip margin and border leave no room in a %dx%d frame", mainW, mainH)
+	}
+
+	w := float64(mainW) * scale
+	h := w * float64(pipH) / float64(pipW)
+	if w > float64(maxW) {
+		w, h = float64(maxW), float64(maxW)*float64(pipH)/float64(pipW)
+	}
+	if h > float64(maxH) {
+		w, h = float64(maxH)*float64(pipW)/float64(pipH), float64(maxH)
+	}
+
+	// Encoders want even dimensions
+	rect := pipRect{Width: int(w) &^ 1, Height: int(h) &^ 1}
+	if rect.Width < 2 || rect.Height < 2 {
+		return pipRect{}, fmt.Errorf("pip is too small at scale %g", scale)
+	}
+
+	outerW := rect.Width + 2*opts.BorderWidth
+	outerH := rect.Height + 2*opts.BorderWidth
+	left, top := opts.Margin, opts.Margin
+	right, bottom := mainW-outerW-opts.Margin, mainH-outerH-opts.Margin
+
+	switch opts.Corner {
+	case PiPTopLeft:
+		rect.X, rect.Y = left, top
+	case PiPTopRight:
+		rect.X, rect.Y = right, top
+	case PiPBottomLeft:
+		rect.X, rect.Y = left, bottom
+	case PiPBottomRight, "":
+		rect.X, rect.Y = right, bottom
+	default:
+		return pipRect{}, fmt.Errorf("unsupported pip corner: %q", opts.Corner)
+	}
+
+	return rect, nil
+}
+
+// buildPiPGraph scales input 1 into rect and overlays it on input 0 as [vout]
+func buildPiPGraph(rect pipRect, opts PiPOptions) string {
+	pip := fmt.Sprintf("[1:v]scale=%d:%d,setsar=1", rect.Width, rect.Height)
+	if opts.BorderWidth > 0 {
+		color := opts.BorderColor
+		if color == "" {
+			color = "white"
+		}
+		b := opts.BorderWidth
+		pip += fmt.Sprintf(",pad=%d:%d:%d:%d:color=%s", rect.Width+2*b, rect.Height+2*b, b, b, color)
+	}
+
+	return fmt.Sprintf("%s[pip];[0:v][pip]overlay=%d:%d[vout]", pip, rect.X, rect.Y)
+}
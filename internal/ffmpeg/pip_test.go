@@ -0,0 +1,80 @@
+package ffmpeg
+
+import "testing"
+
+func TestPiPLayoutCorners(t *testing.T) {
+	tests := []struct {
+		corner PiPCorner
+		x, y   int
+	}{
+		{PiPTopLeft, 20, 20},
+		{PiPTopRight, 1920 - 480 - 20, 20},
+		{PiPBottomLeft, 20, 1080 - 270 - 20},
+		{PiPBottomRight, 1920 - 480 - 20, 1080 - 270 - 20},
+		{"", 1920 - 480 - 20, 1080 - 270 - 20},
+	}
+
+	for _, tt := range tests {
+		rect, err := pipLayout(1920, 1080, 1280, 720, PiPOptions{Corner: tt.corner, Margin: 20})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.corner, err)
+		}
+		if rect.Width != 480 || rect.Height != 270 {
+			t.Errorf("%s: size = %dx%d, want 480x270", tt.corner, rect.Width, rect.Height)
+		}
+		if rect.X != tt.x || rect.Y != tt.y {
+			t.Errorf("%s: position = %d,%d, want %d,%d", tt.corner, rect.X, rect.Y, tt.x, tt.y)
+		}
+	}
+}
+
+func TestPiPLayoutClamp(t *testing.T) {
+	// A tall facecam at full width would overflow a landscape frame
+	rect, err := pipLayout(1920, 1080, 1080, 1920, PiPOptions{Scale: 1, Margin: 10, BorderWidth: 5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	outerW, outerH := rect.Width+10, rect.Height+10
+	if rect.X < 10 || rect.Y < 10 || rect.X+outerW > 1910 || rect.Y+outerH > 1070 {
+		t.Errorf("pip %+v exceeds the frame", rect)
+	}
+	if rect.Height != 1050 {
+		t.Errorf("height = %d, want 1050", rect.Height)
+	}
+	if rect.Width%2 != 0 || rect.Height%2 != 0 {
+		t.Errorf("size %dx%d is not even", rect.Width, rect.Height)
+	}
+}
+
+func TestPiPLayoutInvalid(t *testing.T) {
+	tests := []PiPOptions{
+		{Scale: 1.5},
+		{Scale: -0.1},
+		{Corner: "middle"},
+		{Margin: -1},
+		{Margin: 600},
+	}
+
+	for _, opts := range tests {
+		if _, err := pipLayout(1920, 1080, 1280, 720, opts); err == nil {
+			t.Errorf("expected error for %+v", opts)
+		}
+	}
+}
+
+func TestBuildPiPGraph(t *testing.T) {
+	rect := pipRect{Width: 480, Height: 270, X: 1400, Y: 770}
+
+	got := buildPiPGraph(rect, PiPOptions{})
+	want := "[1:v]scale=480:270,setsar=1[pip];[0:v][pip]overlay=1400:770[vout]"
+	if got != want {
+		t.Errorf("graph = %q, want %q", got, want)
+	}
+
+	got = buildPiPGraph(rect, PiPOptions{BorderWidth: 4, BorderColor: "#FF0050"})
+	want = "[1:v]scale=480:270,setsar=1,pad=488:278:4:4:color=#FF0050[pip];[0:v][pip]overlay=1400:770[vout]"
+	if got != want {
+		t.Errorf("graph = %q, want %q", got, want)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"os/exec"
 	"strconv"
 	"time"
@@ -17,6 +18,33 @@ func (e *Executor) ProbeVideo(ctx context.Context, filePath string) (*VideoInfo,
 		return nil, fmt.Errorf("file path is required")
 	}
 
+	output, err := e.runProbe(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := parseProbeOutput(filePath, output)
+	if err != nil {
+		return nil, err
+	}
+	e.warnVFR(info)
+	return info, nil
+}
+
+// warnVFR logs when info describes a variable frame rate video
+func (e *Executor) warnVFR(info *VideoInfo) {
+	if info.VariableFrameRate {
+		e.logger.Warn().
+			Str("file", info.FilePath).
+			Float64("r_frame_rate", info.RFrameRate).
+			Float64("avg_frame_rate", info.AvgFPS).
+			Msg("variable frame rate detected; scene timestamps may be less accurate")
+	}
+}
+
+// runProbe returns ffprobe's JSON description of filePath's format and
+// streams
+func (e *Executor) runProbe(ctx context.Context, filePath string) ([]byte, error) {
 	args := []string{
 		"-v", "quiet",
 		"-print_format", "json",
@@ -30,12 +58,25 @@ func (e *Executor) ProbeVideo(ctx context.Context, filePath string) (*VideoInfo,
 	if err != nil {
 		return nil, fmt.Errorf("ffprobe failed: %w", err)
 	}
+	return output, nil
+}
 
+// vfrTolerance is how far avg_frame_rate may stray from r_frame_rate
+// (relative) before a stream is treated as variable frame rate
+const vfrTolerance = 0.02
+
+// parseProbeOutput converts ffprobe JSON into VideoInfo. Width and Height
+// are display dimensions: swapped when the stream is rotated by 90/270.
+func parseProbeOutput(filePath string, output []byte) (*VideoInfo, error) {
 	var probe probeResult
 	if err := json.Unmarshal(output, &probe); err != nil {
 		return nil, fmt.Errorf("failed to parse ffprobe output: %w", err)
 	}
+	return videoInfo(filePath, &probe), nil
+}
 
+// videoInfo summarizes the first video and audio streams of probe
+func videoInfo(filePath string, probe *probeResult) *VideoInfo {
 	info := &VideoInfo{
 		FilePath: filePath,
 	}
@@ -50,42 +91,108 @@ func (e *Executor) ProbeVideo(ctx context.Context, filePath string) (*VideoInfo,
 		info.Bitrate = br
 	}
 
-	// Extract video stream info
+	// Extract info from the first video and audio streams
+	seenVideo := false
 	for _, stream := range probe.Streams {
 		switch stream.CodecType {
 		case "video":
+			// Cover art embedded in audio files is not a video stream
+			if seenVideo || stream.Disposition.AttachedPic != 0 {
+				continue
+			}
+			seenVideo = true
+
 			info.Width = stream.Width
 			info.Height = stream.Height
 			info.VideoCodec = stream.CodecName
+			info.PixFmt = stream.PixFmt
 
-			// Calculate FPS from r_frame_rate (e.g., "30/1")
-			if stream.RFrameRate != "" {
-				info.FPS = util.ParseFrameRate(stream.RFrameRate)
+			// r_frame_rate is the container's nominal (often maximum) rate;
+			// avg_frame_rate reflects what was actually recorded
+			info.RFrameRate = util.ParseFrameRate(stream.RFrameRate)
+			info.AvgFPS = util.ParseFrameRate(stream.AvgFrameRate)
+			info.FPS = info.RFrameRate
+			if info.AvgFPS > 0 && (info.FPS == 0 || math.Abs(info.AvgFPS-info.FPS)/info.FPS > vfrTolerance) {
+				info.VariableFrameRate = info.FPS > 0
+				info.FPS = info.AvgFPS
+			}
+
+			info.Rotation = streamRotation(stream.SideDataList, stream.Tags.Rotate)
+			if info.Rotation == 90 || info.Rotation == 270 {
+				info.Width, info.Height = info.Height, info.Width
 			}
 		case "audio":
+			if info.HasAudio {
+				continue
+			}
 			info.HasAudio = true
 			info.AudioCodec = stream.CodecName
+			info.AudioChannels = stream.Channels
 			if br, err := strconv.ParseInt(stream.BitRate, 10, 64); err == nil {
 				info.AudioBitrate = br
 			}
+			if sr, err := strconv.Atoi(stream.SampleRate); err == nil {
+				info.AudioSampleRate = sr
+			}
 		}
 	}
 
-	return info, nil
+	return info
+}
+
+// streamRotation returns the clockwise display rotation in [0, 360).
+// The display matrix side data wins over the legacy rotate tag.
+func streamRotation(sideData []probeSideData, rotateTag string) int {
+	rotation := 0
+	found := false
+	for _, sd := range sideData {
+		if sd.SideDataType == "Display Matrix" {
+			// The display matrix angle is counter-clockwise
+			rotation = -int(sd.Rotation)
+			found = true
+			break
+		}
+	}
+	if !found && rotateTag != "" {
+		if r, err := strconv.Atoi(rotateTag); err == nil {
+			rotation = r
+		}
+	}
+
+	return ((rotation % 360) + 360) % 360
 }
 
 // probeResult matches ffprobe JSON output structure
 type probeResult struct {
 	Format struct {
-		Duration string `json:"duration"`
-		BitRate  string `json:"bit_rate"`
+		FormatName string `json:"format_name"`
+		Duration   string `json:"duration"`
+		BitRate    string `json:"bit_rate"`
 	} `json:"format"`
 	Streams []struct {
-		CodecType  string `json:"codec_type"`
-		CodecName  string `json:"codec_name"`
-		Width      int    `json:"width"`
-		Height     int    `json:"height"`
-		RFrameRate string `json:"r_frame_rate"`
-		BitRate    string `json:"bit_rate"`
+		Index        int    `json:"index"`
+		CodecType    string `json:"codec_type"`
+		CodecName    string `json:"codec_name"`
+		Width        int    `json:"width"`
+		Height       int    `json:"height"`
+		RFrameRate   string `json:"r_frame_rate"`
+		AvgFrameRate string `json:"avg_frame_rate"`
+		BitRate      string `json:"bit_rate"`
+		PixFmt       string `json:"pix_fmt"`
+		Channels     int    `json:"channels"`
+		SampleRate   string `json:"sample_rate"`
+		Tags         struct {
+			Rotate string `json:"rotate"`
+		} `json:"tags"`
+		SideDataList []probeSideData `json:"side_data_list"`
+		Disposition  struct {
+			AttachedPic int `json:"attached_pic"`
+		} `json:"disposition"`
 	} `json:"streams"`
 }
+
+// probeSideData is one entry of a stream's side_data_list
+type probeSideData struct {
+	SideDataType string  `json:"side_data_type"`
+	Rotation     float64 `json:"rotation"`
+}
@@ -0,0 +1,102 @@
+package ffmpeg
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// DefaultProbeCacheSize is how many probe results ProbeVideoCached keeps
+const DefaultProbeCacheSize = 64
+
+// ProbeVideoCached is ProbeVideo with an in-process LRU keyed by path,
+// modification time and size, so a file is only re-probed after it
+// changes. Inputs that can't be stat'ed (URLs, pipes) are never cached.
+func (e *Executor) ProbeVideoCached(ctx context.Context, filePath string) (*VideoInfo, error) {
+	if IsURLInput(filePath) {
+		return e.ProbeVideo(ctx, filePath)
+	}
+	stat, err := os.Stat(filePath)
+	if err != nil {
+		return e.ProbeVideo(ctx, filePath)
+	}
+	key := fmt.Sprintf("%s|%d|%d", filePath, stat.ModTime().UnixNano(), stat.Size())
+
+	cache := e.probes()
+	if info, ok := cache.get(key); ok {
+		return info, nil
+	}
+
+	info, err := e.ProbeVideo(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	cache.put(key, info)
+
+	copied := *info
+	return &copied, nil
+}
+
+// probes returns the executor's probe cache, creating it on first use
+func (e *Executor) probes() *probeCache {
+	e.probeOnce.Do(func() {
+		e.probeCache = newProbeCache(DefaultProbeCacheSize)
+	})
+	return e.probeCache
+}
+
+// probeCache is a fixed-size LRU of probe results
+type probeCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // front = most recently used
+	entries  map[string]*list.Element
+}
+
+type probeCacheEntry struct {
+	key  string
+	info VideoInfo
+}
+
+func newProbeCache(capacity int) *probeCache {
+	return &probeCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// get returns a copy of the cached result for key
+func (c *probeCache) get(key string) (*VideoInfo, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	info := elem.Value.(*probeCacheEntry).info
+	return &info, true
+}
+
+// put stores a copy of info, evicting the least recently used entry when full
+func (c *probeCache) put(key string, info *VideoInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*probeCacheEntry).info = *info
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&probeCacheEntry{key: key, info: *info})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*probeCacheEntry).key)
+	}
+}
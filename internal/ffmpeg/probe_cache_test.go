@@ -0,0 +1,34 @@
+package ffmpeg
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProbeCacheLRU(t *testing.T) {
+	c := newProbeCache(2)
+	c.put("a", &VideoInfo{FilePath: "a", Duration: time.Second})
+	c.put("b", &VideoInfo{FilePath: "b"})
+
+	// Touch a so b becomes the eviction candidate
+	if info, ok := c.get("a"); !ok || info.Duration != time.Second {
+		t.Fatalf("expected cached a, got %v %v", info, ok)
+	}
+	c.put("c", &VideoInfo{FilePath: "c"})
+
+	if _, ok := c.get("b"); ok {
+		t.Error("expected b to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := c.get(key); !ok {
+			t.Errorf("expected %s to be cached", key)
+		}
+	}
+
+	// Callers get copies, not the cached value
+	info, _ := c.get("a")
+	info.Duration = 0
+	if again, _ := c.get("a"); again.Duration != time.Second {
+		t.Error("mutating a returned result changed the cache")
+	}
+}
@@ -0,0 +1,93 @@
+package ffmpeg
+
+import (
+	"testing"
+	"time"
+)
+
+// Trimmed ffprobe output for a portrait iPhone recording: stored 1920x1080
+// with a -90 degree display matrix
+const rotatedProbeSample = `{
+	"streams": [
+		{
+			"codec_type": "video",
+			"codec_name": "hevc",
+			"width": 1920,
+			"height": 1080,
+			"pix_fmt": "yuv420p10le",
+			"r_frame_rate": "30/1",
+			"side_data_list": [
+				{"side_data_type": "DOVI configuration record"},
+				{"side_data_type": "Display Matrix", "displaymatrix": "...", "rotation": -90}
+			]
+		},
+		{
+			"codec_type": "audio",
+			"codec_name": "aac",
+			"channels": 2,
+			"sample_rate": "44100",
+			"bit_rate": "128000"
+		}
+	],
+	"format": {"duration": "12.500000", "bit_rate": "9000000"}
+}`
+
+func TestParseProbeOutputRotated(t *testing.T) {
+	info, err := parseProbeOutput("portrait.mov", []byte(rotatedProbeSample))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.Width != 1080 || info.Height != 1920 {
+		t.Errorf("expected display size 1080x1920, got %dx%d", info.Width, info.Height)
+	}
+	if info.Rotation != 90 {
+		t.Errorf("expected rotation 90, got %d", info.Rotation)
+	}
+	if info.PixFmt != "yuv420p10le" || info.AudioChannels != 2 || info.AudioSampleRate != 44100 {
+		t.Errorf("unexpected stream details %+v", info)
+	}
+	if info.Duration != 12500*time.Millisecond {
+		t.Errorf("expected 12.5s, got %v", info.Duration)
+	}
+}
+
+func TestStreamRotationTag(t *testing.T) {
+	if got := streamRotation(nil, "270"); got != 270 {
+		t.Errorf("expected 270 from tag, got %d", got)
+	}
+	if got := streamRotation([]probeSideData{{SideDataType: "Display Matrix", Rotation: 180}}, "90"); got != 180 {
+		t.Errorf("display matrix should win over tag, got %d", got)
+	}
+	if got := streamRotation(nil, ""); got != 0 {
+		t.Errorf("expected 0 without rotation, got %d", got)
+	}
+}
+
+func TestParseProbeOutputFrameRates(t *testing.T) {
+	tests := []struct {
+		name    string
+		r, avg  string
+		wantFPS float64
+		wantVFR bool
+	}{
+		{"constant", "30/1", "30/1", 30, false},
+		{"ntsc", "30000/1001", "2997/100", 30000.0 / 1001, false},
+		{"screen recording", "60/1", "24123/1000", 24.123, true},
+		{"unknown average", "25/1", "0/0", 25, false},
+		{"unknown nominal", "0/0", "24/1", 24, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := `{"streams":[{"codec_type":"video","r_frame_rate":"` + tt.r + `","avg_frame_rate":"` + tt.avg + `"}],"format":{}}`
+			info, err := parseProbeOutput("in.mp4", []byte(data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.FPS != tt.wantFPS || info.VariableFrameRate != tt.wantVFR {
+				t.Errorf("expected fps %v vfr %v, got %v %v", tt.wantFPS, tt.wantVFR, info.FPS, info.VariableFrameRate)
+			}
+		})
+	}
+}
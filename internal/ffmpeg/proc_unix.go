@@ -0,0 +1,20 @@
+//go:build !windows
+
+package ffmpeg
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// configureProcessGroup runs ffmpeg in its own process group so that
+// cancellation kills ffmpeg and any helpers it spawned
+func configureProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		if cmd.Process == nil {
+			return nil
+		}
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
@@ -0,0 +1,8 @@
+//go:build windows
+
+package ffmpeg
+
+import "os/exec"
+
+// configureProcessGroup is a no-op on Windows; exec.CommandContext kills the process
+func configureProcessGroup(cmd *exec.Cmd) {}
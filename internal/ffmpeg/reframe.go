@@ -0,0 +1,150 @@
+package ffmpeg
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ReframeMode selects how a landscape source is fit into a vertical frame
+type ReframeMode string
+
+const (
+	// ReframeNone leaves the source framing untouched
+	ReframeNone ReframeMode = ""
+	// ReframeCenterCrop scales the source to fill the frame and crops the sides
+	ReframeCenterCrop ReframeMode = "center-crop"
+	// ReframeBlurPad centers the source over a blurred, zoomed copy of itself
+	ReframeBlurPad ReframeMode = "blur-pad"
+	// ReframeSplitScreen stacks the source above a gameplay overlay
+	ReframeSplitScreen ReframeMode = "split-screen"
+)
+
+// Vertical output dimensions (9:16)
+const (
+	VerticalWidth  = 1080
+	VerticalHeight = 1920
+)
+
+// DefaultBlurSigma is the background blur strength for ReframeBlurPad
+const DefaultBlurSigma = 20.0
+
+// ReframeVertical crops/scales any source to a 1080x1920 portrait frame.
+// The mode and (for split-screen) the gameplay overlay come from opts.
+func (e *Executor) ReframeVertical(ctx context.Context, input, output string, opts RenderOptions) error {
+	if input == "" {
+		return fmt.Errorf("input path is required")
+	}
+	if output == "" {
+		return fmt.Errorf("output path is required")
+	}
+	if opts.Reframe == ReframeSplitScreen && opts.ReframeOverlay == "" {
+		return fmt.Errorf("split-screen reframing requires an overlay path")
+	}
+	if opts.Preset == "" {
+		opts.Preset = e.defaultPreset()
+	}
+
+	graph, err := buildReframeGraph(opts.Reframe, opts.BlurSigma)
+	if err != nil {
+		return err
+	}
+
+	// Subtitles and custom filters run on the reframed output
+	opts.Width, opts.Height, opts.Scale = 0, 0, ""
+	outLabel := "[v]"
+	if post := buildFilterChain(opts); len(post) > 0 {
+		graph += ";[v]" + strings.Join(post, ",") + "[vout]"
+		outLabel = "[vout]"
+	}
+
+	enc := e.selectEncoder(opts)
+	audioCodec := opts.AudioCodec
+	if audioCodec == "" {
+		audioCodec = DefaultAudioCodec
+	}
+	if err := e.requireEncoders(enc.codec, audioCodec); err != nil {
+		return err
+	}
+	if len(enc.filters) > 0 {
+		graph += ";" + outLabel + strings.Join(enc.filters, ",") + "[venc]"
+		outLabel = "[venc]"
+	}
+
+	e.logger.Info().
+		Str("input", input).
+		Str("output", output).
+		Str("mode", string(opts.Reframe)).
+		Msg("reframing to vertical")
+
+	args := append(enc.inputArgs, "-i", input)
+	if opts.Reframe == ReframeSplitScreen {
+		// Loop the gameplay clip so it always covers the full source
+		args = append(args, "-stream_loop", "-1", "-i", opts.ReframeOverlay)
+	}
+
+	args = append(args,
+		"-filter_complex", graph,
+		"-map", outLabel,
+		"-map", "0:a?",
+	)
+	args = append(args, encodeArgs(opts, enc)...)
+	if opts.Reframe == ReframeSplitScreen {
+		args = append(args, "-shortest")
+	}
+	args = append(args, opts.CustomArgs...)
+	args = append(args, output)
+
+	runOpts := RunOptions{
+		Args:            args,
+		ProgressHandler: opts.ProgressFunc,
+		TotalDuration:   e.probeDuration(ctx, input, opts.ProgressFunc),
+		LogHandler: func(line string) {
+			e.logger.Debug().Str("ffmpeg", line).Msg("reframe output")
+		},
+	}
+
+	if err := e.runEncode(ctx, opts, runOpts); err != nil {
+		return fmt.Errorf("vertical reframe failed: %w", err)
+	}
+
+	e.logger.Info().Str("output", output).Msg("vertical reframe completed")
+	return nil
+}
+
+// buildReframeGraph returns a filter_complex graph whose video output is
+// labeled [v]; blurSigma applies to blur-pad (0 = DefaultBlurSigma)
+func buildReframeGraph(mode ReframeMode, blurSigma float64) (string, error) {
+	w, h := VerticalWidth, VerticalHeight
+	fill := func(in string, width, height int, out string) string {
+		return fmt.Sprintf("%sscale=%d:%d:force_original_aspect_ratio=increase,crop=%d:%d,setsar=1%s",
+			in, width, height, width, height, out)
+	}
+
+	switch mode {
+	case ReframeCenterCrop:
+		return fill("[0:v]", w, h, "[v]"), nil
+	case ReframeBlurPad:
+		if blurSigma <= 0 {
+			blurSigma = DefaultBlurSigma
+		}
+		blur := NewFilterBuilder().Blur(blurSigma).Build()
+		return strings.Join([]string{
+			"[0:v]split=2[bg][fg]",
+			fill("[bg]", w, h, ","+blur+"[bgblur]"),
+			fmt.Sprintf("[fg]scale=%d:-2,setsar=1[fgs]", w),
+			"[bgblur][fgs]overlay=(W-w)/2:(H-h)/2[v]",
+		}, ";"), nil
+	case ReframeSplitScreen:
+		half := h / 2
+		return strings.Join([]string{
+			fill("[0:v]", w, half, "[top]"),
+			fill("[1:v]", w, half, "[bottom]"),
+			// The overlay loops forever; end with the source even when it
+			// has no audio for -shortest to stop on
+			"[top][bottom]vstack=inputs=2:shortest=1[v]",
+		}, ";"), nil
+	default:
+		return "", fmt.Errorf("unsupported reframe mode: %q", mode)
+	}
+}
@@ -0,0 +1,77 @@
+package ffmpeg
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestBuildReframeGraph(t *testing.T) {
+	tests := []struct {
+		mode     ReframeMode
+		contains []string
+	}{
+		{ReframeCenterCrop, []string{"[0:v]scale=1080:1920:force_original_aspect_ratio=increase", "crop=1080:1920", "[v]"}},
+		{ReframeBlurPad, []string{"split=2[bg][fg]", "gblur", "overlay=(W-w)/2:(H-h)/2[v]"}},
+		{ReframeSplitScreen, []string{"[0:v]scale=1080:960", "[1:v]scale=1080:960", "vstack=inputs=2:shortest=1[v]"}},
+	}
+
+	for _, tt := range tests {
+		graph, err := buildReframeGraph(tt.mode, 0)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.mode, err)
+		}
+		for _, want := range tt.contains {
+			if !strings.Contains(graph, want) {
+				t.Errorf("%s: graph %q missing %q", tt.mode, graph, want)
+			}
+		}
+	}
+}
+
+func TestBuildReframeGraphInvalidMode(t *testing.T) {
+	if _, err := buildReframeGraph("sideways", 0); err == nil {
+		t.Error("expected error for unknown reframe mode")
+	}
+}
+
+func TestBuildReframeGraphBlurPad(t *testing.T) {
+	graph, err := buildReframeGraph(ReframeBlurPad, 35)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := strings.Join([]string{
+		"[0:v]split=2[bg][fg]",
+		"[bg]scale=1080:1920:force_original_aspect_ratio=increase,crop=1080:1920,setsar=1,gblur=sigma=35[bgblur]",
+		"[fg]scale=1080:-2,setsar=1[fgs]",
+		"[bgblur][fgs]overlay=(W-w)/2:(H-h)/2[v]",
+	}, ";")
+	if graph != want {
+		t.Errorf("graph = %q, want %q", graph, want)
+	}
+
+	// Every intermediate label is produced once and consumed once
+	for _, label := range []string{"[bg]", "[fg]", "[bgblur]", "[fgs]"} {
+		if n := strings.Count(graph, label); n != 2 {
+			t.Errorf("label %s appears %d times, want 2", label, n)
+		}
+	}
+
+	graph, _ = buildReframeGraph(ReframeBlurPad, 0)
+	if !strings.Contains(graph, "gblur=sigma=20[bgblur]") {
+		t.Errorf("expected default sigma in %q", graph)
+	}
+}
+
+func TestReframeVerticalRequiresEncoders(t *testing.T) {
+	e := &Executor{logger: zerolog.Nop(), encoders: map[string]bool{"aac": true}}
+
+	err := e.ReframeVertical(context.Background(), "in.mp4", "out.mp4", RenderOptions{Reframe: ReframeBlurPad})
+	if !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("expected ErrUnsupported before running ffmpeg, got %v", err)
+	}
+}
@@ -13,13 +13,29 @@ func (e *Executor) Render(ctx context.Context, opts RenderOptions) error {
 	if err := validateRenderOptions(opts); err != nil {
 		return fmt.Errorf("invalid render options: %w", err)
 	}
+	if opts.Preset == "" {
+		opts.Preset = e.defaultPreset()
+	}
+
+	if opts.Reframe != ReframeNone {
+		return e.ReframeVertical(ctx, opts.Input, opts.Output, opts)
+	}
 
 	e.logger.Info().
 		Str("input", opts.Input).
 		Str("output", opts.Output).
 		Msg("starting render")
 
-	args := []string{"-i", opts.Input}
+	enc := e.selectEncoder(opts)
+	audioCodec := opts.AudioCodec
+	if audioCodec == "" {
+		audioCodec = DefaultAudioCodec
+	}
+	if err := e.requireEncoders(enc.codec, audioCodec); err != nil {
+		return err
+	}
+
+	args := append(enc.inputArgs, "-i", opts.Input)
 
 	// Apply overlay if specified (requires second input)
 	if opts.Overlay != nil {
@@ -27,43 +43,12 @@ func (e *Executor) Render(ctx context.Context, opts RenderOptions) error {
 	}
 
 	// Build filter chain
-	filters := buildFilterChain(opts)
+	filters := append(buildFilterChain(opts), enc.filters...)
 	if len(filters) > 0 {
 		args = append(args, "-vf", strings.Join(filters, ","))
 	}
 
-	// Video codec settings
-	videoCodec := opts.VideoCodec
-	if videoCodec == "" {
-		videoCodec = DefaultVideoCodec
-	}
-	args = append(args, "-c:v", videoCodec)
-
-	// Quality settings
-	crf := opts.CRF
-	if crf == 0 {
-		crf = DefaultCRF
-	}
-	args = append(args, "-crf", fmt.Sprintf("%d", crf))
-
-	// Preset
-	preset := opts.Preset
-	if preset == "" {
-		preset = DefaultPreset
-	}
-	args = append(args, "-preset", preset)
-
-	// Audio codec settings
-	audioCodec := opts.AudioCodec
-	if audioCodec == "" {
-		audioCodec = DefaultAudioCodec
-	}
-	args = append(args, "-c:a", audioCodec)
-
-	// FPS conversion
-	if opts.FPS > 0 {
-		args = append(args, "-r", fmt.Sprintf("%.2f", opts.FPS))
-	}
+	args = append(args, encodeArgs(opts, enc)...)
 
 	// Custom arguments
 	if len(opts.CustomArgs) > 0 {
@@ -76,12 +61,13 @@ func (e *Executor) Render(ctx context.Context, opts RenderOptions) error {
 	runOpts := RunOptions{
 		Args:            args,
 		ProgressHandler: opts.ProgressFunc,
+		TotalDuration:   e.probeDuration(ctx, opts.Input, opts.ProgressFunc),
 		LogHandler: func(line string) {
 			e.logger.Debug().Str("ffmpeg", line).Msg("render output")
 		},
 	}
 
-	if err := e.Run(ctx, runOpts); err != nil {
+	if err := e.runEncode(ctx, opts, runOpts); err != nil {
 		return fmt.Errorf("render failed: %w", err)
 	}
 
@@ -96,75 +82,11 @@ func (e *Executor) RenderClip(ctx context.Context, opts RenderOptions) error {
 
 // MergeWithOverlay merges a video with an overlay using OverlayOptions
 func (e *Executor) MergeWithOverlay(ctx context.Context, input, overlay, output string, overlayOpts OverlayOptions, progressFunc ProgressFunc) error {
-	if input == "" {
-		return fmt.Errorf("input path is required")
-	}
 	if overlay == "" {
 		return fmt.Errorf("overlay path is required")
 	}
-	if output == "" {
-		return fmt.Errorf("output path is required")
-	}
 
-	e.logger.Info().
-		Str("input", input).
-		Str("overlay", overlay).
-		Str("output", output).
-		Msg("merging with overlay")
-
-	args := []string{
-		"-i", input,
-		"-i", overlay,
-	}
-
-	// Build overlay filter
-	overlayFilter := fmt.Sprintf("overlay=%d:%d", overlayOpts.X, overlayOpts.Y)
-
-	// Add opacity if specified
-	if overlayOpts.Opacity > 0 && overlayOpts.Opacity < 1.0 {
-		overlayFilter = fmt.Sprintf("[1]format=rgba,colorchannelmixer=aa=%.2f[ovr];[0][ovr]%s", overlayOpts.Opacity, overlayFilter)
-	}
-
-	// Add time constraints if specified
-	if overlayOpts.Start > 0 || overlayOpts.End > 0 {
-		if overlayOpts.Start > 0 {
-			overlayFilter += fmt.Sprintf(":enable='gte(t,%.2f)", overlayOpts.Start.Seconds())
-		}
-		if overlayOpts.End > 0 {
-			if overlayOpts.Start > 0 {
-				overlayFilter += fmt.Sprintf("*lte(t,%.2f)", overlayOpts.End.Seconds())
-			} else {
-				overlayFilter += fmt.Sprintf(":enable='lte(t,%.2f)", overlayOpts.End.Seconds())
-			}
-			overlayFilter += "'"
-		} else if overlayOpts.Start > 0 {
-			overlayFilter += "'"
-		}
-	}
-
-	args = append(args,
-		"-filter_complex", overlayFilter,
-		"-c:v", DefaultVideoCodec,
-		"-crf", fmt.Sprintf("%d", DefaultCRF),
-		"-preset", DefaultPreset,
-		"-c:a", "copy",
-		output,
-	)
-
-	runOpts := RunOptions{
-		Args:            args,
-		ProgressHandler: progressFunc,
-		LogHandler: func(line string) {
-			e.logger.Debug().Str("ffmpeg", line).Msg("overlay output")
-		},
-	}
-
-	if err := e.Run(ctx, runOpts); err != nil {
-		return fmt.Errorf("overlay merge failed: %w", err)
-	}
-
-	e.logger.Info().Str("output", output).Msg("overlay merge completed")
-	return nil
+	return e.ApplyOverlays(ctx, input, output, []OverlayInput{{Path: overlay, Options: overlayOpts}}, progressFunc)
 }
 
 // ApplySubtitles burns subtitles into the video
@@ -193,7 +115,7 @@ func (e *Executor) ApplySubtitles(ctx context.Context, input, subtitles, output
 		"-vf", fmt.Sprintf("subtitles=%s", escapedPath),
 		"-c:v", DefaultVideoCodec,
 		"-crf", fmt.Sprintf("%d", DefaultCRF),
-		"-preset", DefaultPreset,
+		"-preset", e.defaultPreset(),
 		"-c:a", "copy",
 		output,
 	}
@@ -201,6 +123,7 @@ func (e *Executor) ApplySubtitles(ctx context.Context, input, subtitles, output
 	runOpts := RunOptions{
 		Args:            args,
 		ProgressHandler: progressFunc,
+		TotalDuration:   e.probeDuration(ctx, input, progressFunc),
 		LogHandler: func(line string) {
 			e.logger.Debug().Str("ffmpeg", line).Msg("subtitle output")
 		},
@@ -237,7 +160,7 @@ func (e *Executor) RenderWithFilterBuilder(ctx context.Context, input, output st
 		"-vf", strings.Join(filterChain.Filters, ","),
 		"-c:v", DefaultVideoCodec,
 		"-crf", fmt.Sprintf("%d", DefaultCRF),
-		"-preset", DefaultPreset,
+		"-preset", e.defaultPreset(),
 		"-c:a", DefaultAudioCodec,
 		output,
 	}
@@ -245,6 +168,7 @@ func (e *Executor) RenderWithFilterBuilder(ctx context.Context, input, output st
 	runOpts := RunOptions{
 		Args:            args,
 		ProgressHandler: progressFunc,
+		TotalDuration:   e.probeDuration(ctx, input, progressFunc),
 		LogHandler: func(line string) {
 			e.logger.Debug().Str("ffmpeg", line).Msg("filter builder output")
 		},
@@ -274,15 +198,65 @@ func validateRenderOptions(opts RenderOptions) error {
 	if opts.FPS < 0 {
 		return fmt.Errorf("FPS cannot be negative")
 	}
+	if opts.TwoPass {
+		if opts.TargetBitrate == "" {
+			return fmt.Errorf("two-pass encoding requires a target bitrate")
+		}
+		if opts.CRF != 0 {
+			return fmt.Errorf("two-pass encoding and CRF are mutually exclusive")
+		}
+	}
 	return nil
 }
 
+// encodeArgs returns codec, quality and frame rate arguments for a render
+func encodeArgs(opts RenderOptions, enc videoEncoder) []string {
+	crf := opts.CRF
+	if crf == 0 {
+		crf = DefaultCRF
+	}
+
+	preset := opts.Preset
+	if preset == "" {
+		preset = DefaultPreset
+	}
+
+	audioCodec := opts.AudioCodec
+	if audioCodec == "" {
+		audioCodec = DefaultAudioCodec
+	}
+
+	args := []string{"-c:v", enc.codec}
+	if opts.TargetBitrate != "" {
+		args = append(args, enc.bitrateArgs(opts.TargetBitrate, preset)...)
+	} else {
+		args = append(args, enc.qualityArgs(crf, preset)...)
+	}
+	if opts.MaxBitrate != "" {
+		// A one-second buffer keeps the ceiling tight enough for upload limits
+		args = append(args, "-maxrate", opts.MaxBitrate, "-bufsize", opts.MaxBitrate)
+	}
+	args = append(args, "-c:a", audioCodec)
+	if opts.Loudness != 0 && audioCodec != "copy" {
+		norm := NormalizeOptions{TargetLevel: opts.Loudness}.withDefaults()
+		args = append(args, "-af", loudnormFilter(norm), "-ar", "48000")
+	}
+
+	if opts.FPS > 0 {
+		args = append(args, "-r", fmt.Sprintf("%.2f", opts.FPS))
+	}
+
+	return args
+}
+
 // buildFilterChain constructs the filter chain from render options
 func buildFilterChain(opts RenderOptions) []string {
 	var filters []string
 
 	// Scaling
-	if opts.Width > 0 && opts.Height > 0 {
+	if opts.Width > 0 && opts.Height > 0 && opts.Fit {
+		filters = append(filters, NewFilterBuilder().ScaleFit(opts.Width, opts.Height, true).BuildAll()...)
+	} else if opts.Width > 0 && opts.Height > 0 {
 		filters = append(filters, fmt.Sprintf("scale=%d:%d", opts.Width, opts.Height))
 	} else if opts.Scale != "" {
 		filters = append(filters, fmt.Sprintf("scale=%s", opts.Scale))
@@ -294,6 +268,13 @@ func buildFilterChain(opts RenderOptions) []string {
 		filters = append(filters, fmt.Sprintf("subtitles=%s", escapedPath))
 	}
 
+	// Text captions
+	for _, c := range opts.Captions {
+		if c.Text != "" {
+			filters = append(filters, drawTextFilter(c.Text, c.Options))
+		}
+	}
+
 	// Custom filters
 	filters = append(filters, opts.Filters...)
 
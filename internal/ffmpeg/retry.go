@@ -0,0 +1,47 @@
+package ffmpeg
+
+import "time"
+
+// DefaultRetryBackoff is the delay before the first retry
+const DefaultRetryBackoff = time.Second
+
+// transientPatterns are stderr fragments of failures worth retrying:
+// network hiccups on remote inputs and busy hardware encoders
+var transientPatterns = []string{
+	"connection reset",
+	"connection refused",
+	"connection timed out",
+	"network is unreachable",
+	"broken pipe",
+	"input/output error",
+	"i/o error",
+	"server returned 5",
+	"resource temporarily unavailable",
+	"device or resource busy",
+	"openencodesessionex failed",
+	"cuda_error_out_of_memory",
+}
+
+// retryDelay returns the exponential backoff before retry attempt n (1-based)
+func retryDelay(base time.Duration, attempt int) time.Duration {
+	if base <= 0 {
+		base = DefaultRetryBackoff
+	}
+	if attempt < 1 {
+		attempt = 1
+	}
+	return base << (attempt - 1)
+}
+
+// retryLimit returns how many retries a run gets: requested when positive,
+// none when negative, and fallback (the executor's setting) when 0
+func retryLimit(requested, fallback int) int {
+	switch {
+	case requested < 0:
+		return 0
+	case requested == 0:
+		return fallback
+	default:
+		return requested
+	}
+}
@@ -0,0 +1,30 @@
+package ffmpeg
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetryDelay(t *testing.T) {
+	if got := retryDelay(0, 1); got != DefaultRetryBackoff {
+		t.Errorf("expected default backoff, got %v", got)
+	}
+	if got := retryDelay(500*time.Millisecond, 3); got != 2*time.Second {
+		t.Errorf("expected 2s for third attempt, got %v", got)
+	}
+}
+
+func TestRetryLimit(t *testing.T) {
+	tests := []struct {
+		requested, fallback, want int
+	}{
+		{0, 2, 2},
+		{3, 2, 3},
+		{-1, 2, 0},
+	}
+	for _, tt := range tests {
+		if got := retryLimit(tt.requested, tt.fallback); got != tt.want {
+			t.Errorf("retryLimit(%d, %d) = %d, want %d", tt.requested, tt.fallback, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package ffmpeg
+
+import "strings"
+
+// DefaultStderrLines is how many trailing stderr lines a failed run reports
+const DefaultStderrLines = 50
+
+// progressKeys are the key=value lines -progress writes; they drown out the
+// messages that explain a failure
+var progressKeys = []string{
+	"frame=", "fps=", "stream_", "bitrate=", "total_size=", "out_time",
+	"dup_frames=", "drop_frames=", "speed=", "progress=", "size=",
+}
+
+// lineRing keeps the last lines written to it
+type lineRing struct {
+	buf   []string
+	next  int
+	count int
+}
+
+// newLineRing creates a ring holding size lines (0 = DefaultStderrLines,
+// negative = discard everything)
+func newLineRing(size int) *lineRing {
+	if size == 0 {
+		size = DefaultStderrLines
+	}
+	if size < 0 {
+		size = 0
+	}
+	return &lineRing{buf: make([]string, size)}
+}
+
+func (r *lineRing) add(line string) {
+	if len(r.buf) == 0 {
+		return
+	}
+	r.buf[r.next] = line
+	r.next = (r.next + 1) % len(r.buf)
+	if r.count < len(r.buf) {
+		r.count++
+	}
+}
+
+// lines returns the buffered lines, oldest first
+func (r *lineRing) lines() []string {
+	if r.count == 0 {
+		return nil
+	}
+
+	out := make([]string, 0, r.count)
+	start := (r.next - r.count + len(r.buf)) % len(r.buf)
+	for i := 0; i < r.count; i++ {
+		out = append(out, r.buf[(start+i)%len(r.buf)])
+	}
+	return out
+}
+
+// isProgressLine reports whether line is -progress output
+func isProgressLine(line string) bool {
+	for _, key := range progressKeys {
+		if strings.HasPrefix(line, key) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package ffmpeg
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLineRing(t *testing.T) {
+	r := newLineRing(3)
+	if got := r.lines(); len(got) != 0 {
+		t.Errorf("expected empty ring, got %v", got)
+	}
+
+	for i := 1; i <= 5; i++ {
+		r.add(fmt.Sprintf("line %d", i))
+	}
+	want := []string{"line 3", "line 4", "line 5"}
+	if got := r.lines(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	if got := len(newLineRing(0).buf); got != DefaultStderrLines {
+		t.Errorf("expected default size %d, got %d", DefaultStderrLines, got)
+	}
+
+	disabled := newLineRing(-1)
+	disabled.add("ignored")
+	if got := disabled.lines(); len(got) != 0 {
+		t.Errorf("expected disabled ring to keep nothing, got %v", got)
+	}
+}
+
+func TestFFmpegErrorIncludesStderr(t *testing.T) {
+	err := &FFmpegError{
+		ExitCode: 1,
+		Stderr:   []string{"missing.mp4: No such file or directory"},
+		Category: CategoryNotFound,
+		Err:      fmt.Errorf("ffmpeg execution failed: exit status 1"),
+	}
+
+	if msg := err.Error(); !strings.Contains(msg, "exit status 1") || !strings.Contains(msg, "No such file or directory") {
+		t.Errorf("expected stderr tail in %q", msg)
+	}
+}
+
+func TestIsProgressLine(t *testing.T) {
+	for _, line := range []string{"frame=120", "out_time_ms=4000000", "progress=continue"} {
+		if !isProgressLine(line) {
+			t.Errorf("expected %q to be a progress line", line)
+		}
+	}
+	if isProgressLine("[mp4 @ 0x1] moov atom not found") {
+		t.Error("expected log line not to be a progress line")
+	}
+}
@@ -4,16 +4,31 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
-
-	"github.com/keagan/slopcannon/pkg/util"
 )
 
+// SceneChange is a detected cut and its scene score (0-1; higher means a
+// more visually distinct cut)
+type SceneChange struct {
+	Time  time.Duration
+	Score float64
+}
+
 // DetectScenes finds scene changes in video using ffmpeg scene detection
 func (e *Executor) DetectScenes(ctx context.Context, input string, threshold float64) ([]time.Duration, error) {
+	changes, err := e.DetectSceneChanges(ctx, input, threshold)
+	if err != nil {
+		return nil, err
+	}
+	return SceneTimes(changes), nil
+}
+
+// DetectSceneChanges finds scene changes along with each cut's scene score
+func (e *Executor) DetectSceneChanges(ctx context.Context, input string, threshold float64) ([]SceneChange, error) {
 	e.logger.Info().
 		Str("input", input).
 		Float64("threshold", threshold).
@@ -25,10 +40,11 @@ func (e *Executor) DetectScenes(ctx context.Context, input string, threshold flo
 	opts := RunOptions{
 		Args: []string{
 			"-i", input,
-			"-vf", fmt.Sprintf("select='gt(scene,%f)',showinfo", threshold),
+			"-vf", fmt.Sprintf("select='gt(scene,%f)',metadata=print", threshold),
 			"-f", "null",
 			"-",
 		},
+		MinLogLevel: LogLevelInfo,
 		LogHandler: func(line string) {
 			mu.Lock()
 			stderrBuf.WriteString(line + "\n")
@@ -49,9 +65,7 @@ func (e *Executor) DetectScenes(ctx context.Context, input string, threshold flo
 		if ctx.Err() != nil {
 			return nil, ctx.Err()
 		}
-		if !strings.Contains(err.Error(), "Conversion failed") &&
-			!strings.Contains(err.Error(), "Invalid return value") &&
-			!strings.Contains(err.Error(), "Output file is empty") {
+		if ErrorCategoryOf(err) != CategoryEmptyOutput {
 			return nil, fmt.Errorf("scene detection failed: %w", err)
 		}
 	}
@@ -61,20 +75,41 @@ func (e *Executor) DetectScenes(ctx context.Context, input string, threshold flo
 	return scenes, nil
 }
 
-// parseSceneOutput extracts scene change timestamps from ffmpeg output
-func parseSceneOutput(output string) []time.Duration {
-	var scenes []time.Duration
+// SceneTimes returns just the timestamps of scene changes
+func SceneTimes(changes []SceneChange) []time.Duration {
+	times := make([]time.Duration, len(changes))
+	for i, c := range changes {
+		times[i] = c.Time
+	}
+	return times
+}
+
+// parseSceneOutput extracts scene changes from ffmpeg metadata=print
+// output: a "pts_time:" line per selected frame followed by its
+// "lavfi.scene_score=" line
+func parseSceneOutput(output string) []SceneChange {
+	var scenes []SceneChange
 
 	lines := strings.Split(output, "\n")
 	for _, line := range lines {
 		if strings.Contains(line, "pts_time:") {
 			parts := strings.Split(line, "pts_time:")
 			if len(parts) == 2 {
-				timeStr := strings.Fields(strings.TrimSpace(parts[1]))[0]
-				if seconds, err := strconv.ParseFloat(timeStr, 64); err == nil {
-					scenes = append(scenes, time.Duration(seconds*float64(time.Second)))
+				fields := strings.Fields(strings.TrimSpace(parts[1]))
+				if len(fields) == 0 {
+					continue
+				}
+				if seconds, err := strconv.ParseFloat(fields[0], 64); err == nil {
+					scenes = append(scenes, SceneChange{Time: time.Duration(math.Round(seconds * float64(time.Second)))})
 				}
 			}
+			continue
+		}
+
+		if _, value, ok := strings.Cut(line, "lavfi.scene_score="); ok && len(scenes) > 0 {
+			if score, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				scenes[len(scenes)-1].Score = score
+			}
 		}
 	}
 
@@ -83,36 +118,7 @@ func parseSceneOutput(output string) []time.Duration {
 
 // GenerateThumbnail creates a thumbnail image at a specific timestamp
 func (e *Executor) GenerateThumbnail(ctx context.Context, input, output string, timestamp time.Duration, progressFunc ProgressFunc) error {
-	if input == "" {
-		return fmt.Errorf("input path is required")
-	}
-	if output == "" {
-		return fmt.Errorf("output path is required")
-	}
-
-	e.logger.Info().
-		Str("input", input).
-		Str("output", output).
-		Dur("timestamp", timestamp).
-		Msg("generating thumbnail")
-
-	args := []string{
-		"-ss", util.FormatDuration(timestamp),
-		"-i", input,
-		"-vframes", "1",
-		"-q:v", "2", // high quality JPEG
-		output,
-	}
-
-	opts := RunOptions{
-		Args:            args,
-		ProgressHandler: progressFunc,
-		LogHandler: func(line string) {
-			e.logger.Debug().Str("ffmpeg", line).Msg("thumbnail generation")
-		},
-	}
-
-	return e.Run(ctx, opts)
+	return e.GenerateThumbnailWithFilter(ctx, input, output, timestamp, "", progressFunc)
 }
 
 // GenerateThumbnails creates multiple thumbnails at specified intervals
@@ -123,6 +129,10 @@ func (e *Executor) GenerateThumbnails(ctx context.Context, input, outputPattern
 	if outputPattern == "" {
 		return fmt.Errorf("output pattern is required")
 	}
+	filter, err := thumbnailsFilter(interval)
+	if err != nil {
+		return err
+	}
 
 	e.logger.Info().
 		Str("input", input).
@@ -132,7 +142,7 @@ func (e *Executor) GenerateThumbnails(ctx context.Context, input, outputPattern
 
 	args := []string{
 		"-i", input,
-		"-vf", fmt.Sprintf("fps=1/%d", int(interval.Seconds())),
+		"-vf", filter,
 		"-q:v", "2",
 		outputPattern,
 	}
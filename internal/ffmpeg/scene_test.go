@@ -0,0 +1,35 @@
+package ffmpeg
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseSceneOutput(t *testing.T) {
+	output := `[Parsed_metadata_1 @ 0x600000c8c000] frame:0    pts:62562   pts_time:4.17083
+[Parsed_metadata_1 @ 0x600000c8c000] lavfi.scene_score=0.912000
+[Parsed_metadata_1 @ 0x600000c8c000] frame:1    pts:201201  pts_time:13.4134
+[Parsed_metadata_1 @ 0x600000c8c000] lavfi.scene_score=0.418500
+frame=  812 fps=402 q=-0.0 Lsize=N/A time=00:00:27.06 bitrate=N/A speed=13.4x`
+
+	got := parseSceneOutput(output)
+	want := []SceneChange{
+		{Time: 4170830 * time.Microsecond, Score: 0.912},
+		{Time: 13413400 * time.Microsecond, Score: 0.4185},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseSceneOutput = %+v, want %+v", got, want)
+	}
+
+	times := SceneTimes(got)
+	if !reflect.DeepEqual(times, []time.Duration{want[0].Time, want[1].Time}) {
+		t.Errorf("SceneTimes = %v", times)
+	}
+}
+
+func TestParseSceneOutputEmpty(t *testing.T) {
+	if got := parseSceneOutput("frame=  812 fps=402\n"); len(got) != 0 {
+		t.Errorf("expected no scenes, got %v", got)
+	}
+}
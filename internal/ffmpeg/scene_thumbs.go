@@ -0,0 +1,77 @@
+package ffmpeg
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/keagan/slopcannon/pkg/util"
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultThumbnailWorkers bounds concurrent thumbnail extractions
+const DefaultThumbnailWorkers = 4
+
+// SceneThumbnail is a detected scene cut and the frame grabbed at it
+type SceneThumbnail struct {
+	Timestamp     time.Duration
+	ThumbnailPath string
+}
+
+// DetectScenesWithThumbnails detects scene changes and writes a thumbnail
+// of each scene's first frame into thumbDir (e.g. for a storyboard).
+// Results follow scene order.
+func (e *Executor) DetectScenesWithThumbnails(ctx context.Context, input string, threshold float64, thumbDir string) ([]SceneThumbnail, error) {
+	if thumbDir == "" {
+		return nil, fmt.Errorf("thumbnail directory is required")
+	}
+
+	scenes, err := e.DetectScenes(ctx, input, threshold)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := util.EnsureDir(thumbDir); err != nil {
+		return nil, fmt.Errorf("failed to create thumbnail dir: %w", err)
+	}
+
+	thumbs := make([]SceneThumbnail, len(scenes))
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(DefaultThumbnailWorkers)
+
+	for i, ts := range scenes {
+		i, ts := i, ts
+		thumbs[i] = SceneThumbnail{
+			Timestamp:     ts,
+			ThumbnailPath: filepath.Join(thumbDir, sceneThumbnailName(i)),
+		}
+
+		g.Go(func() error {
+			if err := e.GenerateThumbnail(gctx, input, thumbs[i].ThumbnailPath, ts, nil); err != nil {
+				return fmt.Errorf("scene %d thumbnail: %w", i, err)
+			}
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		paths := make([]string, len(thumbs))
+		for i, th := range thumbs {
+			paths[i] = th.ThumbnailPath
+		}
+		util.CleanupFiles(paths...)
+		return nil, err
+	}
+
+	e.logger.Info().
+		Int("scenes", len(thumbs)).
+		Str("dir", thumbDir).
+		Msg("scene thumbnails generated")
+	return thumbs, nil
+}
+
+// sceneThumbnailName is the file name of the i-th scene's thumbnail
+func sceneThumbnailName(i int) string {
+	return fmt.Sprintf("scene_%04d.jpg", i)
+}
@@ -0,0 +1,46 @@
+package ffmpeg
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestDetectScenesWithThumbnailsRequiresDir(t *testing.T) {
+	exec := &Executor{logger: zerolog.Nop()}
+	if _, err := exec.DetectScenesWithThumbnails(context.Background(), "in.mp4", 0.4, ""); err == nil {
+		t.Error("expected error without a thumbnail directory")
+	}
+}
+
+func TestDetectScenesWithThumbnails(t *testing.T) {
+	skipIfNoFFmpeg(t)
+
+	testVideoPath := getTestDataPath("test.mp4")
+	if _, err := os.Stat(testVideoPath); os.IsNotExist(err) {
+		t.Skip("test video not found")
+	}
+
+	exec, err := New(zerolog.Nop(), 0)
+	if err != nil {
+		t.Fatalf("failed to create executor: %v", err)
+	}
+
+	dir := filepath.Join(t.TempDir(), "storyboard")
+	thumbs, err := exec.DetectScenesWithThumbnails(context.Background(), testVideoPath, 0.3, dir)
+	if err != nil {
+		t.Fatalf("DetectScenesWithThumbnails: %v", err)
+	}
+
+	for i, th := range thumbs {
+		if i > 0 && th.Timestamp < thumbs[i-1].Timestamp {
+			t.Errorf("scene %d out of order: %v after %v", i, th.Timestamp, thumbs[i-1].Timestamp)
+		}
+		if _, err := os.Stat(th.ThumbnailPath); err != nil {
+			t.Errorf("scene %d thumbnail missing: %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package ffmpeg
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/keagan/slopcannon/pkg/util"
+)
+
+// MinSplitRemainder is the shortest final chunk Split writes on its own;
+// a shorter remainder is folded into the previous chunk
+const MinSplitRemainder = time.Second
+
+// SplitOptions configures Split
+type SplitOptions struct {
+	// Chunk is the length of each part; the last one may be shorter
+	Chunk time.Duration
+	// OutputDir receives <name>_001<ext>, <name>_002<ext>, ...
+	OutputDir string
+	// Reencode cuts frame-accurately instead of stream copying; copying is
+	// much faster but moves each cut back to the preceding keyframe
+	Reencode     bool
+	ProgressFunc ProgressFunc
+}
+
+// SplitBoundaries divides total into consecutive [start, end) chunks of
+// length chunk. The final chunk holds the remainder, unless that's under
+// MinSplitRemainder, in which case it extends the chunk before it.
+func SplitBoundaries(total, chunk time.Duration) ([][2]time.Duration, error) {
+	if chunk <= 0 {
+		return nil, fmt.Errorf("chunk duration must be positive")
+	}
+	if total <= 0 {
+		return nil, fmt.Errorf("input has no duration")
+	}
+
+	var bounds [][2]time.Duration
+	for start := time.Duration(0); start < total; start += chunk {
+		end := start + chunk
+		if end > total {
+			end = total
+		}
+		if end-start < MinSplitRemainder && len(bounds) > 0 {
+			bounds[len(bounds)-1][1] = end
+			break
+		}
+		bounds = append(bounds, [2]time.Duration{start, end})
+	}
+	return bounds, nil
+}
+
+// snapBoundaries moves every cut between consecutive chunks back to the
+// keyframe preceding it, where a stream copy really cuts, so the parts
+// still join up exactly. Chunks left empty are dropped.
+func snapBoundaries(bounds [][2]time.Duration, keyframes []time.Duration) [][2]time.Duration {
+	if len(bounds) == 0 {
+		return bounds
+	}
+
+	snapped := make([][2]time.Duration, 0, len(bounds))
+	start := bounds[0][0]
+	for i, b := range bounds {
+		end := b[1]
+		if i < len(bounds)-1 {
+			if keyframe, ok := precedingKeyframe(keyframes, end); ok {
+				end = keyframe
+			}
+		}
+		if end <= start {
+			continue
+		}
+		snapped = append(snapped, [2]time.Duration{start, end})
+		start = end
+	}
+	return snapped
+}
+
+// splitOutputPath names the index-th (0-based) part of input in dir
+func splitOutputPath(input, dir string, index int) string {
+	ext := filepath.Ext(input)
+	name := strings.TrimSuffix(filepath.Base(input), ext)
+	if ext == "" {
+		ext = ".mp4"
+	}
+	return filepath.Join(dir, fmt.Sprintf("%s_%03d%s", name, index+1, ext))
+}
+
+// Split cuts input into consecutive chunks of opts.Chunk with ExtractClip
+// and returns the written files in order. No analysis is involved.
+func (e *Executor) Split(ctx context.Context, input string, opts SplitOptions) ([]string, error) {
+	if input == "" {
+		return nil, fmt.Errorf("input path is required")
+	}
+
+	info, err := e.ProbeVideoCached(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	bounds, err := SplitBoundaries(info.Duration, opts.Chunk)
+	if err != nil {
+		return nil, err
+	}
+	if !opts.Reencode {
+		keyframes, err := e.ListKeyframes(ctx, input)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			e.logger.Warn().Err(err).Msg("could not list keyframes; parts may overlap where they are cut")
+		} else {
+			bounds = snapBoundaries(bounds, keyframes)
+		}
+	}
+
+	dir := opts.OutputDir
+	if dir == "" {
+		dir = filepath.Dir(input)
+	}
+	if err := util.EnsureDir(dir); err != nil {
+		return nil, fmt.Errorf("failed to create output dir: %w", err)
+	}
+
+	e.logger.Info().
+		Str("input", input).
+		Str("output_dir", dir).
+		Dur("chunk", opts.Chunk).
+		Int("parts", len(bounds)).
+		Msg("splitting video")
+
+	outputs := make([]string, 0, len(bounds))
+	for i, b := range bounds {
+		output := splitOutputPath(input, dir, i)
+		if err := e.ExtractClip(ctx, input, ClipOptions{
+			Start:        b[0],
+			End:          b[1],
+			Output:       output,
+			CopyCodec:    !opts.Reencode,
+			ProgressFunc: opts.ProgressFunc,
+		}); err != nil {
+			_ = os.Remove(output)
+			util.CleanupFiles(outputs...)
+			return nil, fmt.Errorf("part %d of %d: %w", i+1, len(bounds), err)
+		}
+		outputs = append(outputs, output)
+	}
+
+	return outputs, nil
+}
@@ -0,0 +1,101 @@
+package ffmpeg
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSplitBoundaries(t *testing.T) {
+	tests := []struct {
+		name         string
+		total, chunk time.Duration
+		want         [][2]time.Duration
+	}{
+		{"exact", 3 * time.Minute, time.Minute, [][2]time.Duration{
+			{0, time.Minute}, {time.Minute, 2 * time.Minute}, {2 * time.Minute, 3 * time.Minute},
+		}},
+		{"remainder", 150 * time.Second, time.Minute, [][2]time.Duration{
+			{0, time.Minute}, {time.Minute, 2 * time.Minute}, {2 * time.Minute, 150 * time.Second},
+		}},
+		{"tiny remainder folds", 120500 * time.Millisecond, time.Minute, [][2]time.Duration{
+			{0, time.Minute}, {time.Minute, 120500 * time.Millisecond},
+		}},
+		{"shorter than chunk", 30 * time.Second, time.Minute, [][2]time.Duration{
+			{0, 30 * time.Second},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SplitBoundaries(tt.total, tt.chunk)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("chunk %d: expected %v, got %v", i, tt.want[i], got[i])
+				}
+			}
+		})
+	}
+
+	if _, err := SplitBoundaries(time.Minute, 0); err == nil {
+		t.Error("expected an error for a zero chunk")
+	}
+	if _, err := SplitBoundaries(0, time.Minute); err == nil {
+		t.Error("expected an error for an input without duration")
+	}
+}
+
+func TestSplitOutputPath(t *testing.T) {
+	if got, want := splitOutputPath("/videos/stream.mkv", "out", 0), filepath.Join("out", "stream_001.mkv"); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	if got, want := splitOutputPath("stream", "out", 11), filepath.Join("out", "stream_012.mp4"); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestSnapBoundariesCoverInputOnce(t *testing.T) {
+	total := 200 * time.Second
+	bounds, err := SplitBoundaries(total, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A keyframe every 7s: cuts at 60s, 120s and 180s move to 56s, 119s and 175s
+	var keyframes []time.Duration
+	for ts := time.Duration(0); ts < total; ts += 7 * time.Second {
+		keyframes = append(keyframes, ts)
+	}
+
+	snapped := snapBoundaries(bounds, keyframes)
+	want := [][2]time.Duration{
+		{0, 56 * time.Second}, {56 * time.Second, 119 * time.Second},
+		{119 * time.Second, 175 * time.Second}, {175 * time.Second, total},
+	}
+	if len(snapped) != len(want) {
+		t.Fatalf("expected %v, got %v", want, snapped)
+	}
+	for i := range want {
+		if snapped[i] != want[i] {
+			t.Errorf("part %d: expected %v, got %v", i, want[i], snapped[i])
+		}
+	}
+
+	// Sparse keyframes can swallow a whole chunk; the rest still tile the input
+	snapped = snapBoundaries(bounds, []time.Duration{0, 150 * time.Second})
+	var covered time.Duration
+	for i, b := range snapped {
+		if i > 0 && b[0] != snapped[i-1][1] {
+			t.Errorf("part %d starts at %v, previous ended at %v", i, b[0], snapped[i-1][1])
+		}
+		covered += b[1] - b[0]
+	}
+	if snapped[0][0] != 0 || covered != total {
+		t.Errorf("expected the parts to cover [0, %v) once, got %v", total, snapped)
+	}
+}
@@ -0,0 +1,74 @@
+package ffmpeg
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/keagan/slopcannon/pkg/util"
+)
+
+// GenerateThumbnailWithFilter grabs the frame at timestamp, passing it
+// through filter (e.g. from a FilterBuilder; "" for none)
+func (e *Executor) GenerateThumbnailWithFilter(ctx context.Context, input, output string, timestamp time.Duration, filter string, progressFunc ProgressFunc) error {
+	if input == "" {
+		return fmt.Errorf("input path is required")
+	}
+	if output == "" {
+		return fmt.Errorf("output path is required")
+	}
+
+	e.logger.Info().
+		Str("input", input).
+		Str("output", output).
+		Dur("timestamp", timestamp).
+		Str("filter", filter).
+		Msg("generating thumbnail")
+
+	opts := RunOptions{
+		Args:            thumbnailArgs(input, output, timestamp, filter),
+		ProgressHandler: progressFunc,
+		LogHandler: func(line string) {
+			e.logger.Debug().Str("ffmpeg", line).Msg("thumbnail generation")
+		},
+	}
+
+	return e.Run(ctx, opts)
+}
+
+// thumbnailArgs builds the ffmpeg arguments for a single-frame grab
+func thumbnailArgs(input, output string, timestamp time.Duration, filter string) []string {
+	args := []string{
+		"-ss", util.FormatDuration(timestamp),
+		"-i", input,
+		"-vframes", "1",
+	}
+	if filter != "" {
+		args = append(args, "-vf", filter)
+	}
+	return append(args,
+		"-q:v", "2", // high quality JPEG
+		output,
+	)
+}
+
+// CheckTimestamp reports an error when at falls outside a video of the
+// given duration; an unknown (zero) duration accepts any non-negative time
+func CheckTimestamp(at, duration time.Duration) error {
+	if at < 0 {
+		return fmt.Errorf("timestamp %s is negative", util.FormatDuration(at))
+	}
+	if duration > 0 && at >= duration {
+		return fmt.Errorf("timestamp %s is past the end of the video (duration %s)",
+			util.FormatDuration(at), util.FormatDuration(duration))
+	}
+	return nil
+}
+
+// thumbnailsFilter returns the fps filter that emits one frame per interval
+func thumbnailsFilter(interval time.Duration) (string, error) {
+	if interval <= 0 {
+		return "", fmt.Errorf("thumbnail interval must be positive (got %s)", interval)
+	}
+	return fmt.Sprintf("fps=1/%.3f", interval.Seconds()), nil
+}
@@ -0,0 +1,87 @@
+package ffmpeg
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestThumbnailArgs(t *testing.T) {
+	filter := NewFilterBuilder().ScaleWidth(640).Build()
+	got := thumbnailArgs("in.mp4", "thumb.jpg", 83*time.Second, filter)
+
+	want := []string{
+		"-ss", "00:01:23.000",
+		"-i", "in.mp4",
+		"-vframes", "1",
+		"-vf", "scale=640:-2",
+		"-q:v", "2",
+		"thumb.jpg",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("thumbnailArgs = %v, want %v", got, want)
+	}
+
+	for _, arg := range thumbnailArgs("in.mp4", "thumb.jpg", 0, "") {
+		if arg == "-vf" {
+			t.Errorf("expected no -vf without a filter")
+		}
+	}
+}
+
+func TestCheckTimestamp(t *testing.T) {
+	tests := []struct {
+		name     string
+		at       time.Duration
+		duration time.Duration
+		wantErr  string
+	}{
+		{"within", 30 * time.Second, time.Minute, ""},
+		{"start", 0, time.Minute, ""},
+		{"unknown duration", time.Hour, 0, ""},
+		{"at end", time.Minute, time.Minute, "past the end"},
+		{"past end", 83 * time.Second, time.Minute, "past the end"},
+		{"negative", -time.Second, time.Minute, "negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckTimestamp(tt.at, tt.duration)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestThumbnailsFilter(t *testing.T) {
+	tests := []struct {
+		interval time.Duration
+		want     string
+	}{
+		{500 * time.Millisecond, "fps=1/0.500"},
+		{2500 * time.Millisecond, "fps=1/2.500"},
+		{10 * time.Second, "fps=1/10.000"},
+	}
+
+	for _, tt := range tests {
+		got, err := thumbnailsFilter(tt.interval)
+		if err != nil {
+			t.Fatalf("thumbnailsFilter(%s): %v", tt.interval, err)
+		}
+		if got != tt.want {
+			t.Errorf("thumbnailsFilter(%s) = %q, want %q", tt.interval, got, tt.want)
+		}
+	}
+
+	if _, err := thumbnailsFilter(0); err == nil {
+		t.Error("expected an error for a zero interval")
+	}
+}
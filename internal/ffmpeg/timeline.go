@@ -0,0 +1,101 @@
+package ffmpeg
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// SpriteTileWidth is the width of each thumbnail in a sprite sheet
+const SpriteTileWidth = 160
+
+// GenerateWaveform renders the input's audio as a width x height PNG
+func (e *Executor) GenerateWaveform(ctx context.Context, input, output string, width, height int) error {
+	args, err := buildWaveformArgs(input, output, width, height)
+	if err != nil {
+		return err
+	}
+
+	e.logger.Info().
+		Str("input", input).
+		Str("output", output).
+		Int("width", width).
+		Int("height", height).
+		Msg("generating waveform")
+
+	opts := RunOptions{
+		Args: args,
+		LogHandler: func(line string) {
+			e.logger.Debug().Str("ffmpeg", line).Msg("waveform generation")
+		},
+	}
+
+	if err := e.Run(ctx, opts); err != nil {
+		return fmt.Errorf("waveform generation failed: %w", err)
+	}
+	return nil
+}
+
+// GenerateSpriteSheet tiles one thumbnail every interval into a cols x rows
+// image. Thumbnails past cols*rows are dropped; unused cells stay black.
+func (e *Executor) GenerateSpriteSheet(ctx context.Context, input, output string, cols, rows int, interval time.Duration) error {
+	args, err := buildSpriteSheetArgs(input, output, cols, rows, interval)
+	if err != nil {
+		return err
+	}
+
+	e.logger.Info().
+		Str("input", input).
+		Str("output", output).
+		Int("cols", cols).
+		Int("rows", rows).
+		Dur("interval", interval).
+		Msg("generating sprite sheet")
+
+	opts := RunOptions{
+		Args: args,
+		LogHandler: func(line string) {
+			e.logger.Debug().Str("ffmpeg", line).Msg("sprite sheet generation")
+		},
+	}
+
+	if err := e.Run(ctx, opts); err != nil {
+		return fmt.Errorf("sprite sheet generation failed: %w", err)
+	}
+	return nil
+}
+
+// buildWaveformArgs builds the showwavespic invocation
+func buildWaveformArgs(input, output string, width, height int) ([]string, error) {
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("invalid waveform size %dx%d", width, height)
+	}
+
+	filter := fmt.Sprintf("[0:a]aformat=channel_layouts=mono,showwavespic=s=%dx%d:colors=white", width, height)
+	return []string{
+		"-i", input,
+		"-filter_complex", filter,
+		"-frames:v", "1",
+		output,
+	}, nil
+}
+
+// buildSpriteSheetArgs builds the fps+tile invocation
+func buildSpriteSheetArgs(input, output string, cols, rows int, interval time.Duration) ([]string, error) {
+	if cols <= 0 || rows <= 0 {
+		return nil, fmt.Errorf("invalid sprite grid %dx%d", cols, rows)
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("sprite interval must be positive")
+	}
+
+	filter := fmt.Sprintf("fps=1/%g,scale=%d:-2,tile=%dx%d",
+		interval.Seconds(), SpriteTileWidth, cols, rows)
+	return []string{
+		"-i", input,
+		"-vf", filter,
+		"-frames:v", "1",
+		"-q:v", "3",
+		output,
+	}, nil
+}
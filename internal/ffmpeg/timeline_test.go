@@ -0,0 +1,35 @@
+package ffmpeg
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildWaveformArgs(t *testing.T) {
+	args, err := buildWaveformArgs("in.mp4", "wave.png", 1200, 80)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if joined := strings.Join(args, " "); !strings.Contains(joined, "showwavespic=s=1200x80") {
+		t.Errorf("unexpected args %q", joined)
+	}
+
+	if _, err := buildWaveformArgs("in.mp4", "wave.png", 0, 80); err == nil {
+		t.Error("expected error for zero width")
+	}
+}
+
+func TestBuildSpriteSheetArgs(t *testing.T) {
+	args, err := buildSpriteSheetArgs("in.mp4", "sprite.jpg", 10, 5, 2500*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if joined := strings.Join(args, " "); !strings.Contains(joined, "fps=1/2.5,scale=160:-2,tile=10x5") {
+		t.Errorf("unexpected args %q", joined)
+	}
+
+	if _, err := buildSpriteSheetArgs("in.mp4", "sprite.jpg", 10, 5, 0); err == nil {
+		t.Error("expected error for zero interval")
+	}
+}
@@ -0,0 +1,186 @@
+package ffmpeg
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Transition is the effect between concatenated clips
+type Transition string
+
+const (
+	TransitionNone      Transition = "none"
+	TransitionFade      Transition = "fade"      // fade to black and back at each cut
+	TransitionCrossfade Transition = "crossfade" // overlap adjacent clips with xfade
+)
+
+// DefaultTransitionDuration is used when ConcatOptions.TransitionDuration is 0
+const DefaultTransitionDuration = 500 * time.Millisecond
+
+// ValidateTransition reports an error for an unknown transition ("" is
+// the same as none)
+func ValidateTransition(transition Transition) error {
+	switch transition {
+	case "", TransitionNone, TransitionFade, TransitionCrossfade:
+		return nil
+	default:
+		return fmt.Errorf("unknown transition %q (use none, fade or crossfade)", transition)
+	}
+}
+
+// concatWithTransitions joins inputs through a filter_complex graph. Every
+// input must have an audio stream; the output is always re-encoded.
+func (e *Executor) concatWithTransitions(ctx context.Context, opts ConcatOptions) error {
+	if err := e.requireFilters(transitionFilters(opts.Transition)...); err != nil {
+		return err
+	}
+
+	durations := make([]time.Duration, len(opts.Inputs))
+	for i, input := range opts.Inputs {
+		info, err := e.ProbeVideo(ctx, input)
+		if err != nil {
+			return fmt.Errorf("failed to probe %s: %w", input, err)
+		}
+		durations[i] = info.Duration
+	}
+
+	graph, err := buildTransitionGraph(opts.Transition, durations, opts.TransitionDuration)
+	if err != nil {
+		return err
+	}
+
+	var args []string
+	for _, input := range opts.Inputs {
+		args = append(args, "-i", input)
+	}
+	args = append(args,
+		"-filter_complex", graph,
+		"-map", "[vout]",
+		"-map", "[aout]",
+	)
+	args = append(args, concatEncodeArgs(opts)...)
+	args = append(args, opts.Output)
+
+	runOpts := RunOptions{
+		Args:            args,
+		ProgressHandler: opts.ProgressFunc,
+		TotalDuration:   transitionOutputDuration(opts.Transition, durations, opts.TransitionDuration),
+		LogHandler: func(line string) {
+			e.logger.Debug().Str("ffmpeg", line).Msg("concatenating with transitions")
+		},
+	}
+
+	return e.Run(ctx, runOpts)
+}
+
+// transitionOutputDuration is the length of the joined output: crossfades
+// overlap adjacent clips, fades keep every clip whole
+func transitionOutputDuration(transition Transition, durations []time.Duration, length time.Duration) time.Duration {
+	if length <= 0 {
+		length = DefaultTransitionDuration
+	}
+	var total time.Duration
+	for _, d := range durations {
+		total += d
+	}
+	if transition == TransitionCrossfade && len(durations) > 1 {
+		total -= time.Duration(len(durations)-1) * length
+	}
+	return total
+}
+
+// transitionFilters lists the filters a transition's graph uses
+func transitionFilters(transition Transition) []string {
+	switch transition {
+	case TransitionCrossfade:
+		return []string{"xfade", "acrossfade"}
+	case TransitionFade:
+		return []string{"fade", "afade"}
+	default:
+		return nil
+	}
+}
+
+// buildTransitionGraph builds the filter graph joining len(durations)
+// inputs; the outputs are labelled [vout] and [aout]
+func buildTransitionGraph(transition Transition, durations []time.Duration, length time.Duration) (string, error) {
+	if len(durations) < 2 {
+		return "", fmt.Errorf("transitions need at least two inputs")
+	}
+	if length <= 0 {
+		length = DefaultTransitionDuration
+	}
+	for i, d := range durations {
+		if d <= length {
+			return "", fmt.Errorf("input %d (%s) is shorter than the %s transition", i, d, length)
+		}
+	}
+
+	switch transition {
+	case TransitionCrossfade:
+		return crossfadeGraph(durations, length), nil
+	case TransitionFade:
+		return fadeGraph(durations, length), nil
+	default:
+		return "", fmt.Errorf("unknown transition %q (use none, fade or crossfade)", transition)
+	}
+}
+
+// crossfadeGraph chains xfade/acrossfade; each transition starts length
+// before the end of everything joined so far
+func crossfadeGraph(durations []time.Duration, length time.Duration) string {
+	var chains []string
+	d := length.Seconds()
+
+	prevV, prevA := "[0:v]", "[0:a]"
+	offset := 0.0
+	for i := 1; i < len(durations); i++ {
+		offset += durations[i-1].Seconds() - d
+
+		outV, outA := fmt.Sprintf("[v%d]", i), fmt.Sprintf("[a%d]", i)
+		if i == len(durations)-1 {
+			outV, outA = "[vout]", "[aout]"
+		}
+
+		chains = append(chains,
+			fmt.Sprintf("%s[%d:v]xfade=transition=fade:duration=%.3f:offset=%.3f%s", prevV, i, d, offset, outV),
+			fmt.Sprintf("%s[%d:a]acrossfade=d=%.3f%s", prevA, i, d, outA),
+		)
+		prevV, prevA = outV, outA
+	}
+
+	return strings.Join(chains, ";")
+}
+
+// fadeGraph fades each clip out to black/silence and the next one back in,
+// then joins them with the concat filter
+func fadeGraph(durations []time.Duration, length time.Duration) string {
+	var chains []string
+	var joined strings.Builder
+	d := length.Seconds()
+	last := len(durations) - 1
+
+	for i, dur := range durations {
+		var vf, af []string
+		if i > 0 {
+			vf = append(vf, fmt.Sprintf("fade=t=in:st=0:d=%.3f", d))
+			af = append(af, fmt.Sprintf("afade=t=in:st=0:d=%.3f", d))
+		}
+		if i < last {
+			start := dur.Seconds() - d
+			vf = append(vf, fmt.Sprintf("fade=t=out:st=%.3f:d=%.3f", start, d))
+			af = append(af, fmt.Sprintf("afade=t=out:st=%.3f:d=%.3f", start, d))
+		}
+
+		chains = append(chains,
+			fmt.Sprintf("[%d:v]%s[v%d]", i, strings.Join(vf, ","), i),
+			fmt.Sprintf("[%d:a]%s[a%d]", i, strings.Join(af, ","), i),
+		)
+		fmt.Fprintf(&joined, "[v%d][a%d]", i, i)
+	}
+
+	chains = append(chains, fmt.Sprintf("%sconcat=n=%d:v=1:a=1[vout][aout]", joined.String(), len(durations)))
+	return strings.Join(chains, ";")
+}
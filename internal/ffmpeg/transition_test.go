@@ -0,0 +1,74 @@
+package ffmpeg
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildCrossfadeGraphThreeInputs(t *testing.T) {
+	durations := []time.Duration{10 * time.Second, 8 * time.Second, 12 * time.Second}
+	graph, err := buildTransitionGraph(TransitionCrossfade, durations, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := strings.Join([]string{
+		"[0:v][1:v]xfade=transition=fade:duration=1.000:offset=9.000[v1]",
+		"[0:a][1:a]acrossfade=d=1.000[a1]",
+		"[v1][2:v]xfade=transition=fade:duration=1.000:offset=16.000[vout]",
+		"[a1][2:a]acrossfade=d=1.000[aout]",
+	}, ";")
+	if graph != want {
+		t.Errorf("unexpected graph:\n got %s\nwant %s", graph, want)
+	}
+}
+
+func TestBuildFadeGraphThreeInputs(t *testing.T) {
+	durations := []time.Duration{10 * time.Second, 8 * time.Second, 12 * time.Second}
+	graph, err := buildTransitionGraph(TransitionFade, durations, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := strings.Join([]string{
+		"[0:v]fade=t=out:st=9.500:d=0.500[v0]",
+		"[0:a]afade=t=out:st=9.500:d=0.500[a0]",
+		"[1:v]fade=t=in:st=0:d=0.500,fade=t=out:st=7.500:d=0.500[v1]",
+		"[1:a]afade=t=in:st=0:d=0.500,afade=t=out:st=7.500:d=0.500[a1]",
+		"[2:v]fade=t=in:st=0:d=0.500[v2]",
+		"[2:a]afade=t=in:st=0:d=0.500[a2]",
+		"[v0][a0][v1][a1][v2][a2]concat=n=3:v=1:a=1[vout][aout]",
+	}, ";")
+	if graph != want {
+		t.Errorf("unexpected graph:\n got %s\nwant %s", graph, want)
+	}
+}
+
+func TestBuildTransitionGraphRejectsShortInputs(t *testing.T) {
+	_, err := buildTransitionGraph(TransitionCrossfade, []time.Duration{5 * time.Second, 300 * time.Millisecond}, time.Second)
+	if err == nil {
+		t.Error("expected error when an input is shorter than the transition")
+	}
+}
+
+func TestTransitionOutputDuration(t *testing.T) {
+	durations := []time.Duration{10 * time.Second, 8 * time.Second, 12 * time.Second}
+	if got := transitionOutputDuration(TransitionCrossfade, durations, time.Second); got != 28*time.Second {
+		t.Errorf("expected two 1s overlaps to shorten the output to 28s, got %v", got)
+	}
+	if got := transitionOutputDuration(TransitionFade, durations, time.Second); got != 30*time.Second {
+		t.Errorf("expected fades to keep the full 30s, got %v", got)
+	}
+}
+
+func TestValidateTransition(t *testing.T) {
+	for _, transition := range []Transition{"", TransitionNone, TransitionFade, TransitionCrossfade} {
+		if err := ValidateTransition(transition); err != nil {
+			t.Errorf("%q: unexpected error %v", transition, err)
+		}
+	}
+	if err := ValidateTransition("wipe"); err == nil {
+		t.Error("expected an error for an unknown transition")
+	}
+}
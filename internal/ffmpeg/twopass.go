@@ -0,0 +1,52 @@
+package ffmpeg
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// runEncode runs a render whose arguments end with the output file, as two
+// passes when opts.TwoPass is set
+func (e *Executor) runEncode(ctx context.Context, opts RenderOptions, runOpts RunOptions) error {
+	if !opts.TwoPass {
+		return e.Run(ctx, runOpts)
+	}
+
+	// Pass logs go in their own dir so concurrent renders don't collide
+	dir, err := os.MkdirTemp("", "ffmpeg2pass-*")
+	if err != nil {
+		return fmt.Errorf("failed to create pass log dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	first, second := twoPassArgs(runOpts.Args, filepath.Join(dir, "ffmpeg2pass"))
+
+	e.logger.Debug().Str("bitrate", opts.TargetBitrate).Msg("running first pass")
+	pass := runOpts
+	pass.Args = first
+	if err := e.Run(ctx, pass); err != nil {
+		return fmt.Errorf("first pass failed: %w", err)
+	}
+
+	e.logger.Debug().Msg("running second pass")
+	pass.Args = second
+	if err := e.Run(ctx, pass); err != nil {
+		return fmt.Errorf("second pass failed: %w", err)
+	}
+	return nil
+}
+
+// twoPassArgs splits single-pass arguments (ending in the output file) into
+// an analysis pass writing to the null muxer and a final encoding pass
+func twoPassArgs(args []string, passlog string) (first, second []string) {
+	output := args[len(args)-1]
+	base := args[:len(args)-1]
+
+	first = append(append([]string{}, base...),
+		"-pass", "1", "-passlogfile", passlog, "-an", "-f", "null", "-")
+	second = append(append([]string{}, base...),
+		"-pass", "2", "-passlogfile", passlog, output)
+	return first, second
+}
@@ -0,0 +1,61 @@
+package ffmpeg
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTwoPassArgs(t *testing.T) {
+	args := []string{"-i", "in.mp4", "-c:v", "libx264", "-b:v", "4M", "out.mp4"}
+	first, second := twoPassArgs(args, "/tmp/x/ffmpeg2pass")
+
+	if got := strings.Join(first, " "); got != "-i in.mp4 -c:v libx264 -b:v 4M -pass 1 -passlogfile /tmp/x/ffmpeg2pass -an -f null -" {
+		t.Errorf("unexpected first pass %q", got)
+	}
+	if got := strings.Join(second, " "); got != "-i in.mp4 -c:v libx264 -b:v 4M -pass 2 -passlogfile /tmp/x/ffmpeg2pass out.mp4" {
+		t.Errorf("unexpected second pass %q", got)
+	}
+}
+
+func TestTwoPassValidation(t *testing.T) {
+	base := RenderOptions{Input: "in.mp4", Output: "out.mp4", TwoPass: true}
+
+	if err := validateRenderOptions(base); err == nil {
+		t.Error("expected error without target bitrate")
+	}
+
+	withCRF := base
+	withCRF.TargetBitrate = "4M"
+	withCRF.CRF = 20
+	if err := validateRenderOptions(withCRF); err == nil {
+		t.Error("expected error combining two-pass and CRF")
+	}
+
+	ok := base
+	ok.TargetBitrate = "4M"
+	if err := validateRenderOptions(ok); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestEncodeArgsBitrate(t *testing.T) {
+	args := strings.Join(encodeArgs(RenderOptions{TargetBitrate: "2500k"}, videoEncoder{codec: DefaultVideoCodec}), " ")
+	if !strings.Contains(args, "-b:v 2500k -preset medium") || strings.Contains(args, "-crf") {
+		t.Errorf("unexpected bitrate args %q", args)
+	}
+}
+
+func TestEncodeArgsCeilingAndLoudness(t *testing.T) {
+	args := strings.Join(encodeArgs(RenderOptions{MaxBitrate: "8M", Loudness: -14}, videoEncoder{codec: DefaultVideoCodec}), " ")
+	if !strings.Contains(args, "-crf 23 -preset medium -maxrate 8M -bufsize 8M") {
+		t.Errorf("expected a capped CRF encode, got %q", args)
+	}
+	if !strings.Contains(args, "-af loudnorm=I=-14") {
+		t.Errorf("expected loudness normalization, got %q", args)
+	}
+
+	copied := strings.Join(encodeArgs(RenderOptions{AudioCodec: "copy", Loudness: -14}, videoEncoder{codec: DefaultVideoCodec}), " ")
+	if strings.Contains(copied, "loudnorm") {
+		t.Errorf("copied audio can't be filtered, got %q", copied)
+	}
+}
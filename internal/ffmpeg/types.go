@@ -4,16 +4,32 @@ import "time"
 
 // VideoInfo contains metadata about a video file
 type VideoInfo struct {
-	FilePath     string
-	Duration     time.Duration
-	Width        int
-	Height       int
+	FilePath string
+	Duration time.Duration
+	Width    int
+	Height   int
+	// FPS is the effective frame rate: avg_frame_rate when it differs
+	// materially from the nominal r_frame_rate (variable frame rate)
 	FPS          float64
 	Bitrate      int64
 	VideoCodec   string
 	HasAudio     bool
 	AudioCodec   string
 	AudioBitrate int64
+
+	// RFrameRate is ffprobe's nominal r_frame_rate
+	RFrameRate float64
+	// AvgFPS is ffprobe's avg_frame_rate over the whole stream
+	AvgFPS float64
+	// VariableFrameRate is set when AvgFPS and RFrameRate disagree
+	VariableFrameRate bool
+
+	// Display rotation in degrees clockwise (0, 90, 180, 270); Width and
+	// Height already account for it
+	Rotation        int
+	PixFmt          string
+	AudioChannels   int
+	AudioSampleRate int
 }
 
 // OverlayOptions configures overlay compositing
@@ -33,6 +49,8 @@ type Progress struct {
 	Time       string
 	Speed      string
 	Percentage float64
+	// Input is the file the run reads (its first -i), when there is one
+	Input string
 }
 
 // RunOptions configures ffmpeg execution
@@ -40,6 +58,21 @@ type RunOptions struct {
 	Args            []string
 	ProgressHandler func(*Progress)
 	LogHandler      func(line string)
+	// TotalDuration of the output, used to compute Progress.Percentage (0 = unknown)
+	TotalDuration time.Duration
+	// Timeout kills ffmpeg if it runs longer than this (0 = no timeout)
+	Timeout time.Duration
+	// MaxRetries re-runs ffmpeg after transient failures (network or
+	// device I/O), waiting RetryBackoff (default 1s) doubled per attempt.
+	// 0 uses the executor's ffmpeg.max_retries setting; negative = none.
+	MaxRetries   int
+	RetryBackoff time.Duration
+	// StderrLines is how many trailing log lines failures report
+	// (0 = DefaultStderrLines, negative = none)
+	StderrLines int
+	// MinLogLevel raises the executor's log level for this run; set it
+	// when LogHandler parses filter output (e.g. LogLevelInfo)
+	MinLogLevel string
 }
 
 // Default encoding settings
@@ -67,6 +100,33 @@ type RenderOptions struct {
 	Scale        string
 	ProgressFunc ProgressFunc
 	CustomArgs   []string
+
+	// Fit scales into Width x Height keeping the aspect ratio, padding
+	// the rest with bars, instead of stretching to it
+	Fit bool
+
+	// Vertical reframing (see ReframeVertical)
+	Reframe        ReframeMode
+	ReframeOverlay string  // gameplay clip for split-screen mode
+	BlurSigma      float64 // background blur for blur-pad mode (0 = DefaultBlurSigma)
+
+	// Hardware encoding: auto|nvenc|videotoolbox|vaapi|none (default none)
+	HWAccel HWAccel
+
+	// Bitrate mode: target video bitrate (e.g. "4M") instead of CRF.
+	// TwoPass runs an analysis pass first for accurate rate control and
+	// requires TargetBitrate; it can't be combined with CRF.
+	TargetBitrate string
+	TwoPass       bool
+	// MaxBitrate caps the video bitrate (e.g. "8M") in either rate mode
+	MaxBitrate string
+
+	// Loudness normalizes audio to this integrated loudness in LUFS
+	// (0 = leave audio levels alone)
+	Loudness float64
+
+	// Timed text overlays drawn after subtitles
+	Captions []Caption
 }
 
 // ProgressFunc is a callback for progress updates during ffmpeg operations.
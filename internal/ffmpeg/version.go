@@ -0,0 +1,113 @@
+package ffmpeg
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// FFmpegVersion describes the ffmpeg build in use
+type FFmpegVersion struct {
+	// Version as printed, e.g. "6.1.1-3ubuntu5" or "N-113000-g1234abcd"
+	Version string
+	// Major and Minor release numbers; 0 when Version isn't a release
+	Major int
+	Minor int
+	// Configure flags the build was made with
+	Configuration []string
+	// Library versions, e.g. "libavcodec" -> "60.31.102"
+	Libraries map[string]string
+}
+
+// releaseVersion matches release versions like 6.1.1 or n7.0
+var releaseVersion = regexp.MustCompile(`^n?(\d+)\.(\d+)`)
+
+// String returns the version as printed by ffmpeg
+func (v FFmpegVersion) String() string {
+	return v.Version
+}
+
+// AtLeast reports whether the build is release major.minor or newer.
+// Non-release builds (git snapshots) are assumed to be new enough.
+func (v FFmpegVersion) AtLeast(major, minor int) bool {
+	if v.Major == 0 && v.Minor == 0 {
+		return true
+	}
+	if v.Major != major {
+		return v.Major > major
+	}
+	return v.Minor >= minor
+}
+
+// HasConfig reports whether the build was configured with flag (e.g. "--enable-libx264")
+func (v FFmpegVersion) HasConfig(flag string) bool {
+	for _, f := range v.Configuration {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// Version returns the ffmpeg build version. It is probed once and cached.
+func (e *Executor) Version(ctx context.Context) (FFmpegVersion, error) {
+	e.versionMu.Lock()
+	defer e.versionMu.Unlock()
+
+	if e.version != nil {
+		return *e.version, nil
+	}
+
+	out, err := exec.CommandContext(ctx, e.ffmpegPath, "-version").Output()
+	if err != nil {
+		return FFmpegVersion{}, fmt.Errorf("failed to get ffmpeg version: %w", err)
+	}
+
+	v, err := parseVersion(string(out))
+	if err != nil {
+		return FFmpegVersion{}, err
+	}
+	e.version = &v
+	return v, nil
+}
+
+// parseVersion parses `ffmpeg -version` output
+func parseVersion(output string) (FFmpegVersion, error) {
+	v := FFmpegVersion{Libraries: make(map[string]string)}
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case strings.HasPrefix(line, "ffmpeg version "):
+			fields := strings.Fields(line)
+			v.Version = fields[2]
+			if m := releaseVersion.FindStringSubmatch(v.Version); m != nil {
+				v.Major, _ = strconv.Atoi(m[1])
+				v.Minor, _ = strconv.Atoi(m[2])
+			}
+
+		case strings.HasPrefix(line, "configuration:"):
+			v.Configuration = strings.Fields(strings.TrimPrefix(line, "configuration:"))
+
+		case strings.HasPrefix(line, "lib"):
+			// "libavcodec     60. 31.102 / 60. 31.102": compile-time version first
+			name, rest, ok := strings.Cut(line, " ")
+			if !ok {
+				continue
+			}
+			built, _, _ := strings.Cut(rest, "/")
+			v.Libraries[name] = strings.ReplaceAll(built, " ", "")
+		}
+	}
+
+	if v.Version == "" {
+		return FFmpegVersion{}, fmt.Errorf("unrecognized ffmpeg -version output")
+	}
+	return v, nil
+}
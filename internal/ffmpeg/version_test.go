@@ -0,0 +1,76 @@
+package ffmpeg
+
+import "testing"
+
+const sampleVersionOutput = `ffmpeg version 6.1.1-3ubuntu5 Copyright (c) 2000-2023 the FFmpeg developers
+built with gcc 13 (Ubuntu 13.2.0-23ubuntu3)
+configuration: --prefix=/usr --extra-version=3ubuntu5 --enable-gpl --enable-libx264
+libavutil      58. 29.100 / 58. 29.100
+libavcodec     60. 31.102 / 60. 31.102
+libavfilter     9. 12.100 /  9. 12.100
+`
+
+func TestParseVersion(t *testing.T) {
+	v, err := parseVersion(sampleVersionOutput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v.Version != "6.1.1-3ubuntu5" || v.Major != 6 || v.Minor != 1 {
+		t.Errorf("unexpected version: %+v", v)
+	}
+	if !v.HasConfig("--enable-libx264") || v.HasConfig("--enable-nonfree") {
+		t.Errorf("unexpected configuration: %v", v.Configuration)
+	}
+	if got := v.Libraries["libavcodec"]; got != "60.31.102" {
+		t.Errorf("libavcodec = %q", got)
+	}
+	if got := v.Libraries["libavfilter"]; got != "9.12.100" {
+		t.Errorf("libavfilter = %q", got)
+	}
+}
+
+func TestParseVersionVariants(t *testing.T) {
+	tests := []struct {
+		output       string
+		version      string
+		major, minor int
+	}{
+		{"ffmpeg version n7.0-static https://johnvansickle.com/ffmpeg/", "n7.0-static", 7, 0},
+		{"ffmpeg version N-113000-g1234abcd Copyright (c) 2000-2024", "N-113000-g1234abcd", 0, 0},
+	}
+
+	for _, tt := range tests {
+		v, err := parseVersion(tt.output)
+		if err != nil {
+			t.Errorf("%q: %v", tt.output, err)
+			continue
+		}
+		if v.Version != tt.version || v.Major != tt.major || v.Minor != tt.minor {
+			t.Errorf("%q: got %+v", tt.output, v)
+		}
+	}
+
+	if _, err := parseVersion("not ffmpeg at all"); err == nil {
+		t.Error("expected error for unrecognized output")
+	}
+}
+
+func TestVersionAtLeast(t *testing.T) {
+	v := FFmpegVersion{Version: "6.1", Major: 6, Minor: 1}
+	for _, tt := range []struct {
+		major, minor int
+		want         bool
+	}{
+		{4, 3, true}, {6, 0, true}, {6, 1, true}, {6, 2, false}, {7, 0, false},
+	} {
+		if got := v.AtLeast(tt.major, tt.minor); got != tt.want {
+			t.Errorf("AtLeast(%d, %d) = %v", tt.major, tt.minor, got)
+		}
+	}
+
+	snapshot := FFmpegVersion{Version: "N-113000-g1234abcd"}
+	if !snapshot.AtLeast(7, 0) {
+		t.Error("expected git snapshot to count as new enough")
+	}
+}
@@ -0,0 +1,36 @@
+package ffmpeg
+
+import "fmt"
+
+// AspectRatio returns display width over height (0 when unknown). Width
+// and Height are already rotation-corrected, so a phone video recorded
+// sideways reports its upright ratio.
+func (v *VideoInfo) AspectRatio() float64 {
+	if v.Width <= 0 || v.Height <= 0 {
+		return 0
+	}
+	return float64(v.Width) / float64(v.Height)
+}
+
+// IsPortrait reports whether the video displays taller than it is wide
+func (v *VideoInfo) IsPortrait() bool {
+	return v.Width > 0 && v.Height > v.Width
+}
+
+// IsLandscape reports whether the video displays wider than it is tall
+func (v *VideoInfo) IsLandscape() bool {
+	return v.Height > 0 && v.Width > v.Height
+}
+
+// Resolution names the video's quality tier by its short side, e.g.
+// "1080p" for both 1920x1080 and 1080x1920 ("" when unknown)
+func (v *VideoInfo) Resolution() string {
+	short := v.Width
+	if v.Height < short {
+		short = v.Height
+	}
+	if short <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("%dp", short)
+}
@@ -0,0 +1,54 @@
+package ffmpeg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestVideoInfoOrientation(t *testing.T) {
+	tests := []struct {
+		name       string
+		info       VideoInfo
+		aspect     float64
+		portrait   bool
+		landscape  bool
+		resolution string
+	}{
+		{"landscape 1080p", VideoInfo{Width: 1920, Height: 1080}, 16.0 / 9.0, false, true, "1080p"},
+		{"portrait 1080p", VideoInfo{Width: 1080, Height: 1920}, 9.0 / 16.0, true, false, "1080p"},
+		{"square", VideoInfo{Width: 720, Height: 720}, 1, false, false, "720p"},
+		{"unknown", VideoInfo{}, 0, false, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.info.AspectRatio(); math.Abs(got-tt.aspect) > 1e-9 {
+				t.Errorf("AspectRatio() = %g, want %g", got, tt.aspect)
+			}
+			if got := tt.info.IsPortrait(); got != tt.portrait {
+				t.Errorf("IsPortrait() = %v, want %v", got, tt.portrait)
+			}
+			if got := tt.info.IsLandscape(); got != tt.landscape {
+				t.Errorf("IsLandscape() = %v, want %v", got, tt.landscape)
+			}
+			if got := tt.info.Resolution(); got != tt.resolution {
+				t.Errorf("Resolution() = %q, want %q", got, tt.resolution)
+			}
+		})
+	}
+}
+
+func TestVideoInfoOrientationRotated(t *testing.T) {
+	// The stream is stored landscape but displays rotated by 90 degrees
+	info, err := parseProbeOutput("portrait.mov", []byte(rotatedProbeSample))
+	if err != nil {
+		t.Fatalf("parseProbeOutput: %v", err)
+	}
+
+	if !info.IsPortrait() || info.IsLandscape() {
+		t.Errorf("expected a portrait video, got %dx%d", info.Width, info.Height)
+	}
+	if got := info.AspectRatio(); got >= 1 {
+		t.Errorf("AspectRatio() = %g, want < 1", got)
+	}
+}
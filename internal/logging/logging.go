@@ -1,6 +1,7 @@
 package logging
 
 import (
+	"fmt"
 	"io"
 	"os"
 	"time"
@@ -9,24 +10,106 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+// Log output formats
+const (
+	FormatConsole = "console"
+	FormatJSON    = "json"
+)
+
+// Options configures the global logger
+type Options struct {
+	Verbose bool
+	// Format is console (default; colored on stderr) or json
+	Format string
+	// File also receives every log line when set (appended, never colored)
+	File string
+	// MaxSizeMB rotates File once it reaches this size (0 = never), keeping
+	// MaxBackups old files (0 = DefaultMaxBackups)
+	MaxSizeMB  int
+	MaxBackups int
+}
+
+// logFile is the open --log-file, closed by Close
+var logFile io.WriteCloser
+
 // Init initializes the global logger
 func Init(verbose bool) {
+	_ = InitWith(Options{Verbose: verbose})
+}
+
+// InitWith initializes the global logger for stderr and, optionally, a log file
+func InitWith(opts Options) error {
 	zerolog.TimeFieldFormat = time.RFC3339
 
 	level := zerolog.InfoLevel
-	if verbose {
+	if opts.Verbose {
 		level = zerolog.DebugLevel
 	}
 
 	zerolog.SetGlobalLevel(level)
 
-	output := zerolog.ConsoleWriter{
-		Out:        os.Stderr,
+	if opts.Format == "" {
+		opts.Format = FormatConsole
+	}
+	if opts.Format != FormatConsole && opts.Format != FormatJSON {
+		return fmt.Errorf("unknown log format %q (use console or json)", opts.Format)
+	}
+
+	writers := []io.Writer{formatWriter(os.Stderr, opts.Format, true)}
+
+	if opts.File != "" {
+		f, err := openLogFile(opts)
+		if err != nil {
+			return err
+		}
+		Close()
+		logFile = f
+		writers = append(writers, formatWriter(f, opts.Format, false))
+	}
+
+	log.Logger = NewLogger(writers...)
+	return nil
+}
+
+// Close flushes and closes the log file, if any
+func Close() error {
+	if logFile == nil {
+		return nil
+	}
+	err := logFile.Close()
+	logFile = nil
+	return err
+}
+
+// openLogFile opens the log file, rotating when a max size is set
+func openLogFile(opts Options) (io.WriteCloser, error) {
+	if opts.MaxSizeMB > 0 {
+		return NewRotatingWriter(opts.File, int64(opts.MaxSizeMB)<<20, opts.MaxBackups)
+	}
+
+	f, err := os.OpenFile(opts.File, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+	return f, nil
+}
+
+// formatWriter wraps out for the log format; JSON is written as-is
+func formatWriter(out io.Writer, format string, color bool) io.Writer {
+	if format == FormatJSON {
+		return out
+	}
+	return zerolog.ConsoleWriter{
+		Out:        out,
 		TimeFormat: "15:04:05",
-		NoColor:    false,
+		NoColor:    !color,
 	}
+}
 
-	log.Logger = zerolog.New(output).With().Timestamp().Logger()
+// Quiet limits logging to warnings and errors, for machine-readable output
+// modes. Logs always go to stderr, so stdout stays clean.
+func Quiet() {
+	zerolog.SetGlobalLevel(zerolog.WarnLevel)
 }
 
 // NewLogger creates a new logger with optional writers
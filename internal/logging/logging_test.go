@@ -0,0 +1,65 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+func TestFormatWriterJSON(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger(formatWriter(&buf, FormatJSON, true))
+	logger.Info().Str("clip", "a").Msg("scored")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("expected a JSON line, got %q: %v", buf.String(), err)
+	}
+	if entry["message"] != "scored" || entry["clip"] != "a" {
+		t.Errorf("unexpected entry: %v", entry)
+	}
+}
+
+func TestFormatWriterConsoleFileHasNoColor(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger(formatWriter(&buf, FormatConsole, false))
+	logger.Info().Msg("hello")
+
+	if strings.Contains(buf.String(), "\x1b[") {
+		t.Errorf("expected no ANSI colors, got %q", buf.String())
+	}
+}
+
+func TestInitWithLogFile(t *testing.T) {
+	defer func(l zerolog.Logger, level zerolog.Level) {
+		log.Logger = l
+		zerolog.SetGlobalLevel(level)
+	}(log.Logger, zerolog.GlobalLevel())
+
+	path := filepath.Join(t.TempDir(), "run.log")
+	if err := InitWith(Options{Format: FormatJSON, File: path}); err != nil {
+		t.Fatal(err)
+	}
+	log.Info().Msg("to file")
+	if err := Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"message":"to file"`) {
+		t.Errorf("log file missing entry: %q", data)
+	}
+
+	if err := InitWith(Options{Format: "xml"}); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
@@ -0,0 +1,119 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// DefaultMaxBackups is how many rotated files are kept when none is set
+const DefaultMaxBackups = 3
+
+// RotatingWriter appends to a file and rotates it once it would exceed
+// maxSize bytes: path becomes path.1, path.1 becomes path.2 and so on, and
+// the oldest backup beyond maxBackups is removed. Each Write goes whole to
+// one file, so log lines are never split or dropped by a rotation.
+type RotatingWriter struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+// NewRotatingWriter opens path for appending. maxBackups <= 0 uses
+// DefaultMaxBackups.
+func NewRotatingWriter(path string, maxSize int64, maxBackups int) (*RotatingWriter, error) {
+	if maxSize <= 0 {
+		return nil, fmt.Errorf("max log size must be positive")
+	}
+	if maxBackups <= 0 {
+		maxBackups = DefaultMaxBackups
+	}
+
+	w := &RotatingWriter{path: path, maxSize: maxSize, maxBackups: maxBackups}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Write appends p, rotating first if p would push the file past maxSize
+func (w *RotatingWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	// An empty file takes p even when p alone exceeds maxSize
+	if w.size > 0 && w.size+int64(len(p)) > w.maxSize {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Close closes the current file
+func (w *RotatingWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+// open opens (or creates) path and records its current size
+func (w *RotatingWriter) open() error {
+	f, err := os.OpenFile(w.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+
+	w.file = f
+	w.size = info.Size()
+	return nil
+}
+
+// rotate shifts the backups and starts a new file; the caller holds mu
+func (w *RotatingWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
+	w.file = nil
+
+	_ = os.Remove(backupName(w.path, w.maxBackups))
+	for i := w.maxBackups - 1; i >= 1; i-- {
+		_ = os.Rename(backupName(w.path, i), backupName(w.path, i+1))
+	}
+	if err := os.Rename(w.path, backupName(w.path, 1)); err != nil {
+		// Keep appending to the current file so later writes still work
+		if openErr := w.open(); openErr != nil {
+			return fmt.Errorf("failed to rotate log file: %w (reopening: %v)", err, openErr)
+		}
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+
+	return w.open()
+}
+
+// backupName returns the name of the nth rotated file
+func backupName(path string, n int) string {
+	return fmt.Sprintf("%s.%d", path, n)
+}
@@ -0,0 +1,135 @@
+package logging
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestRotatingWriterRotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.log")
+	w, err := NewRotatingWriter(path, 100, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	line := bytes.Repeat([]byte("x"), 39)
+	line = append(line, '\n')
+	for i := 0; i < 10; i++ {
+		if _, err := w.Write(line); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 40-byte lines, two per 100-byte file: run.log plus two backups
+	for _, name := range []string{path, path + ".1", path + ".2"} {
+		info, err := os.Stat(name)
+		if err != nil {
+			t.Fatalf("expected %s: %v", name, err)
+		}
+		if info.Size() != 80 {
+			t.Errorf("%s: expected 80 bytes, got %d", name, info.Size())
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Error("expected backups beyond MaxBackups to be removed")
+	}
+}
+
+func TestRotatingWriterKeepsEveryLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.log")
+	// Enough backups that nothing is discarded
+	w, err := NewRotatingWriter(path, 256, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				fmt.Fprintf(w, "worker %d line %03d\n", g, i)
+			}
+		}(g)
+	}
+	wg.Wait()
+	w.Close()
+
+	matches, _ := filepath.Glob(path + "*")
+	lines := 0
+	for _, name := range matches {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(data) > 0 && data[len(data)-1] != '\n' {
+			t.Errorf("%s ends with a partial line", name)
+		}
+		lines += bytes.Count(data, []byte("\n"))
+	}
+	if lines != 800 {
+		t.Errorf("expected 800 lines across %d files, got %d", len(matches), lines)
+	}
+}
+
+func TestRotatingWriterAppendsToExisting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.log")
+	if err := os.WriteFile(path, bytes.Repeat([]byte("y"), 90), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := NewRotatingWriter(path, 100, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	if _, err := w.Write([]byte("0123456789abc\n")); err != nil {
+		t.Fatal(err)
+	}
+	if info, _ := os.Stat(path + ".1"); info == nil || info.Size() != 90 {
+		t.Error("expected the existing file's size to count towards rotation")
+	}
+}
+
+func TestRotatingWriterRecoversFromFailedRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.log")
+	w, err := NewRotatingWriter(path, 10, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	if _, err := w.Write([]byte("first line\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	// A non-empty directory where the backup goes makes the rename fail
+	blocker := filepath.Join(path+".1", "blocker")
+	if err := os.MkdirAll(blocker, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("second line\n")); err == nil {
+		t.Fatal("expected the blocked rotation to fail")
+	}
+
+	if err := os.RemoveAll(path + ".1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("third line\n")); err != nil {
+		t.Fatalf("expected writes to resume once rotation works, got %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "third line\n" {
+		t.Errorf("unexpected log contents %q", data)
+	}
+}
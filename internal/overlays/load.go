@@ -0,0 +1,71 @@
+package overlays
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/keagan/slopcannon/internal/config"
+)
+
+// videoExtensions are the files LoadFromDir registers
+var videoExtensions = map[string]bool{
+	".mp4":  true,
+	".mov":  true,
+	".mkv":  true,
+	".webm": true,
+	".avi":  true,
+}
+
+// LoadFromConfig registers every named overlay in cfg.Overlays
+func (r *Registry) LoadFromConfig(cfg config.OverlayConfig) {
+	names := make([]string, 0, len(cfg.Overlays))
+	for name := range cfg.Overlays {
+		names = append(names, name)
+	}
+	// Sorted so logging and any future side effects are deterministic
+	sort.Strings(names)
+
+	for _, name := range names {
+		r.Register(name, cfg.Overlays[name])
+	}
+}
+
+// LoadFromDir registers each video file in dir under its basename without
+// extension (minecraft_parkour.mp4 -> "minecraft_parkour")
+func (r *Registry) LoadFromDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read overlay dir: %w", err)
+	}
+
+	// ReadDir returns entries sorted by filename, so when two files share a
+	// basename the later one wins consistently
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || !videoExtensions[ext] {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		r.Register(name, filepath.Join(dir, entry.Name()))
+	}
+	return nil
+}
+
+// LoadAll registers presets, then files in cfg.Dir, then cfg.Overlays, so
+// explicit config entries take precedence. A missing directory is not an error.
+func (r *Registry) LoadAll(cfg config.OverlayConfig) error {
+	if cfg.Dir != "" {
+		r.RegisterPresets(cfg.Dir)
+		if err := r.LoadFromDir(cfg.Dir); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	r.LoadFromConfig(cfg)
+	return nil
+}
@@ -0,0 +1,51 @@
+package overlays
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/keagan/slopcannon/internal/config"
+)
+
+func TestLoadAll(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"minecraft_parkour.mov", "rain.mp4", "notes.txt", "rain.webm"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := NewRegistry()
+	err := r.LoadAll(config.OverlayConfig{
+		Dir:      dir,
+		Overlays: map[string]string{"watermark": "wm.png", "rain": "custom/rain.mp4"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"csgo_surfing", "minecraft_parkour", "rain", "subway_surfers", "watermark"}
+	if got := r.List(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	// Scanned file replaces the preset default; config replaces the scan
+	if path, _ := r.Get("minecraft_parkour"); path != filepath.Join(dir, "minecraft_parkour.mov") {
+		t.Errorf("unexpected preset path %q", path)
+	}
+	if path, _ := r.Get("rain"); path != "custom/rain.mp4" {
+		t.Errorf("config should win for rain, got %q", path)
+	}
+}
+
+func TestLoadAllMissingDir(t *testing.T) {
+	r := NewRegistry()
+	if err := r.LoadAll(config.OverlayConfig{Dir: filepath.Join(t.TempDir(), "nope")}); err != nil {
+		t.Errorf("missing dir should not be an error: %v", err)
+	}
+	if _, ok := r.Get(SubwaySurfers); !ok {
+		t.Error("presets should still be registered")
+	}
+}
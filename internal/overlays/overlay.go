@@ -2,7 +2,11 @@ package overlays
 
 import (
 	"context"
+	"math/rand"
+	"sort"
 	"time"
+
+	"github.com/rs/zerolog"
 )
 
 // Renderer applies overlays to video
@@ -28,18 +32,33 @@ type Position struct {
 
 // Registry manages available overlays
 type Registry struct {
+	logger   zerolog.Logger
 	overlays map[string]string
+	rng      *rand.Rand
 }
 
 // NewRegistry creates a new overlay registry
 func NewRegistry() *Registry {
 	return &Registry{
+		logger:   zerolog.Nop(),
 		overlays: make(map[string]string),
 	}
 }
 
-// Register adds an overlay to the registry
+// SetLogger sets the logger used to report replaced registrations
+func (r *Registry) SetLogger(logger zerolog.Logger) {
+	r.logger = logger.With().Str("component", "overlays").Logger()
+}
+
+// Register adds an overlay to the registry; re-registering a name replaces it
 func (r *Registry) Register(name, path string) {
+	if old, ok := r.overlays[name]; ok && old != path {
+		r.logger.Debug().
+			Str("overlay", name).
+			Str("old", old).
+			Str("new", path).
+			Msg("overlay re-registered")
+	}
 	r.overlays[name] = path
 }
 
@@ -49,12 +68,13 @@ func (r *Registry) Get(name string) (string, bool) {
 	return path, ok
 }
 
-// List returns all registered overlays
+// List returns all registered overlay names, sorted
 func (r *Registry) List() []string {
 	names := make([]string, 0, len(r.overlays))
 	for name := range r.overlays {
 		names = append(names, name)
 	}
+	sort.Strings(names)
 	return names
 }
 
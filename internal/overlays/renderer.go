@@ -0,0 +1,91 @@
+package overlays
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/keagan/slopcannon/internal/ffmpeg"
+	"github.com/rs/zerolog"
+)
+
+// PresetExtension is the file extension assumed for preset gameplay clips
+const PresetExtension = ".mp4"
+
+// Presets lists the built-in overlay names
+var Presets = []string{MinecraftParkour, CSGOSurfing, SubwaySurfers}
+
+// RegisterPresets maps each preset name to <dir>/<name>.mp4
+func (r *Registry) RegisterPresets(dir string) {
+	for _, name := range Presets {
+		r.Register(name, filepath.Join(dir, name+PresetExtension))
+	}
+}
+
+// Resolve returns the file for a registered name. Values that already look
+// like file paths (with a directory or extension) are returned unchanged.
+func (r *Registry) Resolve(nameOrPath string) (string, error) {
+	if path, ok := r.Get(nameOrPath); ok {
+		return path, nil
+	}
+	if filepath.Ext(nameOrPath) != "" || filepath.Base(nameOrPath) != nameOrPath {
+		return nameOrPath, nil
+	}
+	return "", fmt.Errorf("overlay %q is not registered", nameOrPath)
+}
+
+// FFmpegRenderer composites overlays with ffmpeg in a single pass
+type FFmpegRenderer struct {
+	logger   zerolog.Logger
+	ffmpeg   *ffmpeg.Executor
+	registry *Registry
+}
+
+// NewFFmpegRenderer creates a renderer resolving overlay names via registry
+func NewFFmpegRenderer(logger zerolog.Logger, exec *ffmpeg.Executor, registry *Registry) *FFmpegRenderer {
+	return &FFmpegRenderer{
+		logger:   logger.With().Str("component", "overlays").Logger(),
+		ffmpeg:   exec,
+		registry: registry,
+	}
+}
+
+// Render applies overlays to input and writes output
+func (r *FFmpegRenderer) Render(ctx context.Context, input, output string, overlays []Overlay) error {
+	inputs, err := r.resolve(overlays)
+	if err != nil {
+		return err
+	}
+
+	r.logger.Debug().Int("overlays", len(inputs)).Msg("rendering overlays")
+	return r.ffmpeg.ApplyOverlays(ctx, input, output, inputs, nil)
+}
+
+// resolve converts overlays to ffmpeg inputs, looking up registered names
+func (r *FFmpegRenderer) resolve(overlays []Overlay) ([]ffmpeg.OverlayInput, error) {
+	inputs := make([]ffmpeg.OverlayInput, 0, len(overlays))
+	for _, ov := range overlays {
+		// Path may name a registered overlay; fall back to the type for presets
+		name := ov.Path
+		if name == "" {
+			name = ov.Type
+		}
+
+		path, err := r.registry.Resolve(name)
+		if err != nil {
+			return nil, err
+		}
+
+		inputs = append(inputs, ffmpeg.OverlayInput{
+			Path: path,
+			Options: ffmpeg.OverlayOptions{
+				X:       ov.Position.X,
+				Y:       ov.Position.Y,
+				Opacity: ov.Opacity,
+				Start:   ov.Start,
+				End:     ov.End,
+			},
+		})
+	}
+	return inputs, nil
+}
@@ -0,0 +1,42 @@
+package overlays
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+func TestRendererResolvesRegisteredNames(t *testing.T) {
+	registry := NewRegistry()
+	registry.RegisterPresets("assets")
+	registry.Register("watermark", "assets/wm.png")
+
+	r := NewFFmpegRenderer(zerolog.Nop(), nil, registry)
+	inputs, err := r.resolve([]Overlay{
+		{Type: MinecraftParkour, Position: Position{Y: 960}},
+		{Path: "watermark", Opacity: 0.5, Start: time.Second},
+		{Path: "./custom/lower_third.png"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{filepath.Join("assets", "minecraft_parkour.mp4"), "assets/wm.png", "./custom/lower_third.png"}
+	for i, in := range inputs {
+		if in.Path != want[i] {
+			t.Errorf("overlay %d: expected %q, got %q", i, want[i], in.Path)
+		}
+	}
+	if inputs[0].Options.Y != 960 || inputs[1].Options.Opacity != 0.5 {
+		t.Errorf("options not carried over: %+v", inputs)
+	}
+}
+
+func TestRendererUnknownOverlay(t *testing.T) {
+	r := NewFFmpegRenderer(zerolog.Nop(), nil, NewRegistry())
+	if _, err := r.resolve([]Overlay{{Path: "nyan_cat"}}); err == nil {
+		t.Error("expected error for unregistered overlay")
+	}
+}
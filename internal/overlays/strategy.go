@@ -0,0 +1,83 @@
+package overlays
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Strategy chooses which overlay each clip in a batch gets
+type Strategy string
+
+const (
+	// StrategyFixed uses the same overlay for every clip
+	StrategyFixed Strategy = "fixed"
+	// StrategyRandom picks a random overlay per clip
+	StrategyRandom Strategy = "random"
+	// StrategyRoundRobin cycles through overlays in name order
+	StrategyRoundRobin Strategy = "roundrobin"
+)
+
+// SetSeed makes Random reproducible; 0 seeds from the clock
+func (r *Registry) SetSeed(seed int64) {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	r.rng = rand.New(rand.NewSource(seed))
+}
+
+// Available returns registered names whose files exist, sorted
+func (r *Registry) Available() []string {
+	var names []string
+	for _, name := range r.List() {
+		if _, err := os.Stat(r.overlays[name]); err == nil {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// gameplay returns the available overlays that are video files; images
+// registered for timeline overlays can't fill a split-screen half
+func (r *Registry) gameplay() []string {
+	var names []string
+	for _, name := range r.Available() {
+		if videoExtensions[strings.ToLower(filepath.Ext(r.overlays[name]))] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Random returns a random available gameplay video, or empty strings if
+// none exist
+func (r *Registry) Random() (name, path string) {
+	names := r.gameplay()
+	if len(names) == 0 {
+		return "", ""
+	}
+	if r.rng == nil {
+		r.SetSeed(0)
+	}
+
+	name = names[r.rng.Intn(len(names))]
+	return name, r.overlays[name]
+}
+
+// RoundRobin returns an iterator cycling through the available gameplay
+// videos in name order. The iterator returns empty strings if none exist.
+func (r *Registry) RoundRobin() func() (name, path string) {
+	names := r.gameplay()
+	next := 0
+
+	return func() (string, string) {
+		if len(names) == 0 {
+			return "", ""
+		}
+		name := names[next%len(names)]
+		next++
+		return name, r.overlays[name]
+	}
+}
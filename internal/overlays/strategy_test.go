@@ -0,0 +1,62 @@
+package overlays
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func testRegistry(t *testing.T) *Registry {
+	t.Helper()
+	dir := t.TempDir()
+	r := NewRegistry()
+	for _, name := range []string{"a", "b", "c"} {
+		path := filepath.Join(dir, name+".mp4")
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+		r.Register(name, path)
+	}
+	r.Register("missing", filepath.Join(dir, "missing.mp4"))
+	return r
+}
+
+func TestRoundRobinCyclesAvailable(t *testing.T) {
+	next := testRegistry(t).RoundRobin()
+
+	var got []string
+	for i := 0; i < 5; i++ {
+		name, _ := next()
+		got = append(got, name)
+	}
+
+	want := []string{"a", "b", "c", "a", "b"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestRandomIsReproducibleWithSeed(t *testing.T) {
+	r1, r2 := testRegistry(t), testRegistry(t)
+	r1.SetSeed(42)
+	r2.SetSeed(42)
+
+	for i := 0; i < 10; i++ {
+		n1, _ := r1.Random()
+		n2, _ := r2.Random()
+		if n1 != n2 {
+			t.Fatalf("pick %d differs: %s vs %s", i, n1, n2)
+		}
+		if n1 == "missing" {
+			t.Fatal("random picked an overlay whose file does not exist")
+		}
+	}
+}
+
+func TestRandomEmptyRegistry(t *testing.T) {
+	if name, path := NewRegistry().Random(); name != "" || path != "" {
+		t.Errorf("expected empty pick, got %q %q", name, path)
+	}
+}
@@ -0,0 +1,121 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/keagan/slopcannon/pkg/util"
+	"golang.org/x/sync/errgroup"
+)
+
+// BatchResult is the outcome of analyzing one batch input
+type BatchResult struct {
+	Input       string
+	ProjectPath string
+	Clips       int
+	// Clips dropped as duplicates of earlier ones (with AnalyzeOptions.Dedupe)
+	Duplicates int
+	Err        error
+}
+
+// analyzeFunc analyzes a single input; matches Pipeline.Analyze
+type analyzeFunc func(ctx context.Context, input string, opts AnalyzeOptions) (*Project, error)
+
+// AnalyzeBatch analyzes every input, up to workers at a time, and saves each
+// project as <outDir>/<input name>.json. A failing input is recorded in its
+// result and does not stop the rest. Results follow input order. With
+// opts.Dedupe, clips that look like one from an earlier input (or earlier
+// in the same input) are dropped.
+func (p *Pipeline) AnalyzeBatch(ctx context.Context, inputs []string, outDir string, workers int, opts AnalyzeOptions) ([]BatchResult, error) {
+	if err := util.EnsureDir(outDir); err != nil {
+		return nil, fmt.Errorf("failed to create output dir: %w", err)
+	}
+	return analyzeBatch(ctx, inputs, outDir, workers, opts, p.Analyze), nil
+}
+
+func analyzeBatch(ctx context.Context, inputs []string, outDir string, workers int, opts AnalyzeOptions, analyze analyzeFunc) []BatchResult {
+	if workers < 1 {
+		workers = 1
+	}
+
+	names := batchProjectNames(inputs)
+	results := make([]BatchResult, len(inputs))
+	projects := make([]*Project, len(inputs))
+
+	save := func(i int, project *Project) {
+		project.Name = names[i]
+		path := filepath.Join(outDir, names[i]+".json")
+		if err := project.Save(path); err != nil {
+			results[i].Err = fmt.Errorf("failed to save project: %w", err)
+			return
+		}
+		results[i].ProjectPath = path
+		results[i].Clips = len(project.Clips)
+	}
+
+	var g errgroup.Group
+	g.SetLimit(workers)
+
+	for i, input := range inputs {
+		i, input := i, input
+		results[i].Input = input
+
+		g.Go(func() error {
+			if err := ctx.Err(); err != nil {
+				results[i].Err = err
+				return nil
+			}
+
+			project, err := analyze(ctx, input, opts)
+			if err != nil {
+				results[i].Err = err
+				return nil
+			}
+			if !opts.Dedupe {
+				// Save right away so finished projects survive a later crash
+				save(i, project)
+				return nil
+			}
+			projects[i] = project
+			return nil
+		})
+	}
+
+	_ = g.Wait()
+	if !opts.Dedupe {
+		return results
+	}
+
+	// Dedupe and save in input order, so the earliest copy of a clip wins
+	dedupe := newDeduper(opts.DedupeDistance)
+	for i, project := range projects {
+		if project == nil {
+			continue
+		}
+		results[i].Duplicates = dedupeProject(project, dedupe)
+		save(i, project)
+	}
+
+	return results
+}
+
+// batchProjectNames derives a project name per input from its file name,
+// suffixing duplicates (talk.mp4 and talk.mov -> talk, talk_2)
+func batchProjectNames(inputs []string) []string {
+	names := make([]string, len(inputs))
+	seen := make(map[string]int, len(inputs))
+
+	for i, input := range inputs {
+		base := filepath.Base(input)
+		name := strings.TrimSuffix(base, filepath.Ext(base))
+
+		seen[name]++
+		if n := seen[name]; n > 1 {
+			name = fmt.Sprintf("%s_%d", name, n)
+		}
+		names[i] = name
+	}
+	return names
+}
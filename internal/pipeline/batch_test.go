@@ -0,0 +1,48 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestAnalyzeBatchCollectsFailures(t *testing.T) {
+	dir := t.TempDir()
+	inputs := []string{"in/talk.mp4", "in/broken.mp4", "other/talk.mov"}
+
+	analyze := func(ctx context.Context, input string, opts AnalyzeOptions) (*Project, error) {
+		if input == "in/broken.mp4" {
+			return nil, errors.New("moov atom not found")
+		}
+		return &Project{InputPath: input, Clips: fakeClips(opts.MaxClips)}, nil
+	}
+
+	results := analyzeBatch(context.Background(), inputs, dir, 2, AnalyzeOptions{MaxClips: 3}, analyze)
+
+	if results[1].Err == nil {
+		t.Error("expected broken input to fail")
+	}
+	for _, i := range []int{0, 2} {
+		if results[i].Err != nil {
+			t.Fatalf("input %s failed: %v", results[i].Input, results[i].Err)
+		}
+		if results[i].Clips != 3 {
+			t.Errorf("expected 3 clips for %s, got %d", results[i].Input, results[i].Clips)
+		}
+		if _, err := os.Stat(results[i].ProjectPath); err != nil {
+			t.Errorf("project file missing: %v", err)
+		}
+	}
+	if results[0].ProjectPath == results[2].ProjectPath {
+		t.Error("inputs with the same base name should get distinct project files")
+	}
+
+	loaded, err := LoadProject(results[2].ProjectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Name != "talk_2" {
+		t.Errorf("expected project name talk_2, got %q", loaded.Name)
+	}
+}
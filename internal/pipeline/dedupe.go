@@ -0,0 +1,92 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/keagan/slopcannon/internal/ai"
+	"github.com/keagan/slopcannon/internal/clips"
+)
+
+// DefaultDedupeDistance is the dHash Hamming distance (out of 64 bits) at
+// or below which two clips count as duplicates
+const DefaultDedupeDistance = 10
+
+// hashClips records each clip's perceptual hash; clips that fail to hash
+// are logged and left without one (and never count as duplicates)
+func (p *Pipeline) hashClips(ctx context.Context, list []*clips.Clip) {
+	for _, clip := range list {
+		if _, err := ai.HashClip(ctx, p.ffmpeg, clip); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			p.logger.Warn().Err(err).Str("clip", clip.ID).Msg("perceptual hash failed")
+		}
+	}
+}
+
+// deduper drops clips whose hash is within distance of one already kept
+type deduper struct {
+	distance int
+	seen     []uint64
+}
+
+// newDeduper creates a deduper; a negative distance means
+// DefaultDedupeDistance, and 0 only matches identical hashes
+func newDeduper(distance int) *deduper {
+	if distance < 0 {
+		distance = DefaultDedupeDistance
+	}
+	return &deduper{distance: distance}
+}
+
+// filter returns the clips that aren't duplicates of earlier ones, and
+// how many were dropped. Kept clips are remembered for later calls.
+func (d *deduper) filter(list []*clips.Clip) ([]*clips.Clip, int) {
+	kept := make([]*clips.Clip, 0, len(list))
+	for _, clip := range list {
+		hash, ok := ai.ClipHash(clip)
+		if !ok {
+			kept = append(kept, clip)
+			continue
+		}
+		if d.isDuplicate(hash) {
+			continue
+		}
+		d.seen = append(d.seen, hash)
+		kept = append(kept, clip)
+	}
+	return kept, len(list) - len(kept)
+}
+
+func (d *deduper) isDuplicate(hash uint64) bool {
+	for _, seen := range d.seen {
+		if ai.HammingDistance(hash, seen) <= d.distance {
+			return true
+		}
+	}
+	return false
+}
+
+// dedupeProject removes duplicate clips from the project and its timeline
+func dedupeProject(project *Project, d *deduper) int {
+	kept, dropped := d.filter(project.Clips)
+	if dropped == 0 {
+		return 0
+	}
+
+	project.Clips = kept
+	if project.Timeline != nil {
+		keep := make(map[*clips.Clip]bool, len(kept))
+		for _, clip := range kept {
+			keep[clip] = true
+		}
+		timeline := project.Timeline.Clips[:0]
+		for _, clip := range project.Timeline.Clips {
+			if keep[clip] {
+				timeline = append(timeline, clip)
+			}
+		}
+		project.Timeline.Clips = timeline
+	}
+	return dropped
+}
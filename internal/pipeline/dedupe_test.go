@@ -0,0 +1,81 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/keagan/slopcannon/internal/ai"
+	"github.com/keagan/slopcannon/internal/clips"
+)
+
+func hashedClip(id string, hash uint64) *clips.Clip {
+	clip := &clips.Clip{ID: id}
+	clip.SetMeta(ai.MetaPHash, fmt.Sprintf("%016x", hash))
+	return clip
+}
+
+func TestDeduperFilter(t *testing.T) {
+	d := newDeduper(2)
+
+	kept, dropped := d.filter([]*clips.Clip{
+		hashedClip("a", 0xF0F0),
+		hashedClip("b", 0xF0F3), // 2 bits from a
+		hashedClip("c", 0x0F0F),
+		{ID: "unhashed"},
+	})
+	if dropped != 1 || len(kept) != 3 || kept[1].ID != "c" {
+		t.Errorf("unexpected result: dropped %d, kept %v", dropped, kept)
+	}
+
+	// Later calls compare against everything kept so far
+	_, dropped = d.filter([]*clips.Clip{hashedClip("d", 0x0F0E), hashedClip("e", 0xFFFF)})
+	if dropped != 1 {
+		t.Errorf("expected d to duplicate c, dropped %d", dropped)
+	}
+}
+
+func TestDeduperDistanceZeroIsExact(t *testing.T) {
+	if d := newDeduper(-1); d.distance != DefaultDedupeDistance {
+		t.Errorf("a negative distance should use the default, got %d", d.distance)
+	}
+
+	_, dropped := newDeduper(0).filter([]*clips.Clip{
+		hashedClip("a", 0xF0F0),
+		hashedClip("b", 0xF0F1),
+		hashedClip("c", 0xF0F0),
+	})
+	if dropped != 1 {
+		t.Errorf("distance 0 should only drop identical hashes, dropped %d", dropped)
+	}
+}
+
+func TestAnalyzeBatchDedupe(t *testing.T) {
+	dir := t.TempDir()
+	inputs := []string{"first.mp4", "second.mp4"}
+
+	analyze := func(ctx context.Context, input string, opts AnalyzeOptions) (*Project, error) {
+		list := []*clips.Clip{hashedClip(input+"/shared", 0xABCD), hashedClip(input+"/own", 0xFFFFFFFF00000000)}
+		if input == "second.mp4" {
+			list[1] = hashedClip(input+"/own", 0xFFFF0000)
+		}
+		return &Project{InputPath: input, Clips: list, Timeline: &Timeline{Clips: list}}, nil
+	}
+
+	results := analyzeBatch(context.Background(), inputs, dir, 2, AnalyzeOptions{Dedupe: true}, analyze)
+
+	if results[0].Duplicates != 0 || results[0].Clips != 2 {
+		t.Errorf("first input should keep both clips: %+v", results[0])
+	}
+	if results[1].Duplicates != 1 || results[1].Clips != 1 {
+		t.Errorf("second input should drop the shared clip: %+v", results[1])
+	}
+
+	loaded, err := LoadProject(results[1].ProjectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Timeline.Clips) != 1 || loaded.Timeline.Clips[0].ID != "second.mp4/own" {
+		t.Errorf("timeline should only hold the kept clip, got %v", loaded.Timeline.Clips)
+	}
+}
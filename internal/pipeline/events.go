@@ -0,0 +1,80 @@
+package pipeline
+
+import (
+	"github.com/keagan/slopcannon/internal/ai"
+	"github.com/keagan/slopcannon/internal/clips"
+	"github.com/keagan/slopcannon/internal/ffmpeg"
+)
+
+// EventType identifies a pipeline milestone
+type EventType string
+
+const (
+	EventProbed         EventType = "probed"
+	EventScenesDetected EventType = "scenes_detected"
+	EventCandidates     EventType = "candidates"
+	EventClipScored     EventType = "clip_scored"
+	// EventProgress carries ffmpeg progress for the running stage (analysis
+	// passes and renders alike)
+	EventProgress EventType = "progress"
+)
+
+// Event reports pipeline progress to Config.OnEvent. Only the fields that
+// apply to Type are set.
+type Event struct {
+	Type  EventType
+	Input string
+	// Probe result, for EventProbed
+	Info *ffmpeg.VideoInfo
+	// Scene changes or candidates found
+	Count int
+	// Scored clip and its position among the candidates, for EventClipScored
+	Clip  *clips.Clip
+	Index int
+	Total int
+	// ffmpeg progress, for EventProgress; Input is then the file that
+	// ffmpeg run reads (the source video, or an intermediate when rendering)
+	Progress *ffmpeg.Progress
+}
+
+// emit delivers ev to the OnEvent callback. Calls are serialized, so the
+// callback needn't be safe for concurrent use, but it must return quickly.
+func (p *Pipeline) emit(ev Event) {
+	if p.config.OnEvent == nil {
+		return
+	}
+	p.eventMu.Lock()
+	defer p.eventMu.Unlock()
+	p.config.OnEvent(ev)
+}
+
+// progressFunc forwards ffmpeg progress to Config.Progress and as events
+func (p *Pipeline) progressFunc() ffmpeg.ProgressFunc {
+	if p.config.Progress == nil && p.config.OnEvent == nil {
+		return nil
+	}
+	return func(progress *ffmpeg.Progress) {
+		if p.config.Progress != nil {
+			p.config.Progress(progress)
+		}
+		p.emit(Event{Type: EventProgress, Input: progress.Input, Progress: progress})
+	}
+}
+
+// detectorEvents converts detector milestones for input into pipeline events
+func (p *Pipeline) detectorEvents(input string) func(ai.DetectEvent) {
+	return func(ev ai.DetectEvent) {
+		out := Event{Input: input, Count: ev.Count, Clip: ev.Clip, Index: ev.Index, Total: ev.Total}
+		switch ev.Stage {
+		case ai.StageScenesDetected:
+			out.Type = EventScenesDetected
+		case ai.StageCandidates:
+			out.Type = EventCandidates
+		case ai.StageClipScored:
+			out.Type = EventClipScored
+		default:
+			return
+		}
+		p.emit(out)
+	}
+}
@@ -0,0 +1,76 @@
+package pipeline
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/keagan/slopcannon/internal/ai"
+	"github.com/keagan/slopcannon/internal/clips"
+	"github.com/keagan/slopcannon/internal/ffmpeg"
+)
+
+func TestDetectorEventsAreForwarded(t *testing.T) {
+	var got []Event
+	p := &Pipeline{config: &Config{OnEvent: func(ev Event) { got = append(got, ev) }}}
+
+	forward := p.detectorEvents("talk.mp4")
+	clip := &clips.Clip{ID: "clip_0"}
+	forward(ai.DetectEvent{Stage: ai.StageScenesDetected, Count: 12})
+	forward(ai.DetectEvent{Stage: ai.StageCandidates, Count: 3})
+	forward(ai.DetectEvent{Stage: ai.StageClipScored, Index: 0, Total: 3, Clip: clip})
+	forward(ai.DetectEvent{Stage: "unknown"})
+
+	want := []EventType{EventScenesDetected, EventCandidates, EventClipScored}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d events, got %+v", len(want), got)
+	}
+	for i, ev := range got {
+		if ev.Type != want[i] || ev.Input != "talk.mp4" {
+			t.Errorf("event %d: got %+v", i, ev)
+		}
+	}
+	if got[0].Count != 12 || got[2].Clip != clip || got[2].Total != 3 {
+		t.Errorf("event fields not carried over: %+v", got)
+	}
+}
+
+func TestProgressFuncFeedsCallbackAndEvents(t *testing.T) {
+	if (&Pipeline{config: &Config{}}).progressFunc() != nil {
+		t.Error("expected no progress func without listeners")
+	}
+
+	var progressCalls, events int
+	p := &Pipeline{config: &Config{
+		Progress: func(*ffmpeg.Progress) { progressCalls++ },
+		OnEvent: func(ev Event) {
+			if ev.Type == EventProgress && ev.Progress.Percentage == 50 && ev.Input == "in.mp4" {
+				events++
+			}
+		},
+	}}
+
+	p.progressFunc()(&ffmpeg.Progress{Percentage: 50, Input: "in.mp4"})
+	if progressCalls != 1 || events != 1 {
+		t.Errorf("expected one progress call and one event, got %d and %d", progressCalls, events)
+	}
+}
+
+func TestEmitSerializesCalls(t *testing.T) {
+	// The callback isn't safe for concurrent use; emit must serialize it
+	count := 0
+	p := &Pipeline{config: &Config{OnEvent: func(Event) { count++ }}}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.emit(Event{Type: EventProgress})
+		}()
+	}
+	wg.Wait()
+
+	if count != 50 {
+		t.Errorf("expected 50 events, got %d", count)
+	}
+}
@@ -0,0 +1,43 @@
+package pipeline
+
+import (
+	"fmt"
+
+	"github.com/keagan/slopcannon/internal/ai"
+)
+
+// featureDumper records each scored candidate in a feature dump, then
+// forwards the detector event to next
+type featureDumper struct {
+	dump *ai.FeatureDump
+	next func(ai.DetectEvent)
+	rows int
+	// First write error; later rows are skipped
+	err error
+}
+
+// handle is the detector event callback
+func (f *featureDumper) handle(ev ai.DetectEvent) {
+	if ev.Stage == ai.StageClipScored && ev.Features != nil && f.err == nil {
+		if err := f.dump.Write(ai.NewFeatureRecord(ev.Clip, *ev.Features)); err != nil {
+			f.err = err
+		} else {
+			f.rows++
+		}
+	}
+	if f.next != nil {
+		f.next(ev)
+	}
+}
+
+// close flushes the dump and reports the first error
+func (f *featureDumper) close() error {
+	err := f.dump.Close()
+	if f.err != nil {
+		err = f.err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write feature dump: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package pipeline
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/keagan/slopcannon/internal/ai"
+	"github.com/keagan/slopcannon/internal/clips"
+)
+
+func TestFeatureDumperRecordsScoredClips(t *testing.T) {
+	var buf bytes.Buffer
+	dump, err := ai.NewFeatureDump(&buf, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var forwarded int
+	dumper := &featureDumper{dump: dump, next: func(ai.DetectEvent) { forwarded++ }}
+
+	features := ai.ClipFeatures{SceneChangeCount: 2}
+	dumper.handle(ai.DetectEvent{Stage: ai.StageCandidates, Count: 2})
+	dumper.handle(ai.DetectEvent{Stage: ai.StageClipScored, Clip: &clips.Clip{ID: "clip_0"}, Features: &features})
+	dumper.handle(ai.DetectEvent{Stage: ai.StageClipScored, Clip: &clips.Clip{ID: "clip_1"}, Features: &features})
+	if err := dumper.close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if forwarded != 3 {
+		t.Errorf("expected every event forwarded, got %d", forwarded)
+	}
+	if dumper.rows != 2 {
+		t.Errorf("expected 2 rows, got %d", dumper.rows)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[2], ",clip_1,") {
+		t.Errorf("unexpected dump:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,61 @@
+package pipeline
+
+import (
+	"github.com/keagan/slopcannon/internal/ffmpeg"
+)
+
+// applyFormat fills codecs the user didn't set from opts.Format and checks
+// that output names carry one of the format's extensions
+func applyFormat(opts RenderOptions) (RenderOptions, error) {
+	if opts.Format == "" {
+		return opts, nil
+	}
+	format, err := ffmpeg.LookupFormat(opts.Format)
+	if err != nil {
+		return opts, err
+	}
+
+	if opts.VideoCodec == "" {
+		opts.VideoCodec = format.VideoCodec
+	}
+	if opts.AudioCodec == "" {
+		opts.AudioCodec = format.AudioCodec
+	}
+
+	if opts.OutputPath != "" {
+		if err := format.CheckExtension(opts.OutputPath); err != nil {
+			return opts, err
+		}
+	}
+	if opts.OutputDir != "" {
+		if opts.NameTemplate == "" {
+			opts.NameTemplate = "clip_{index}" + format.Ext()
+		}
+		if err := format.CheckExtension(opts.NameTemplate); err != nil {
+			return opts, err
+		}
+	}
+	return opts, nil
+}
+
+// CheckRenderOptions resolves opts' social preset and format the way
+// Render and RenderClips do and returns the error they would fail with, so
+// callers can reject bad options before any work starts
+func CheckRenderOptions(opts RenderOptions) error {
+	if err := ffmpeg.ValidateTransition(opts.Transition); err != nil {
+		return err
+	}
+	opts, err := applySocialPreset(opts)
+	if err != nil {
+		return err
+	}
+	_, err = applyFormat(opts)
+	return err
+}
+
+// transcodes reports whether the output codecs differ from the ones clips
+// are cut with, so a final encode is needed
+func transcodes(opts RenderOptions) bool {
+	return (opts.VideoCodec != "" && opts.VideoCodec != ffmpeg.DefaultVideoCodec) ||
+		(opts.AudioCodec != "" && opts.AudioCodec != ffmpeg.DefaultAudioCodec)
+}
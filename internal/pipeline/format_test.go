@@ -0,0 +1,35 @@
+package pipeline
+
+import "testing"
+
+func TestApplyFormat(t *testing.T) {
+	opts, err := applyFormat(RenderOptions{Format: "webm", OutputPath: "out.webm", AudioCodec: "libvorbis"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.VideoCodec != "libvpx-vp9" || opts.AudioCodec != "libvorbis" {
+		t.Errorf("expected format video codec and overridden audio codec, got %q and %q", opts.VideoCodec, opts.AudioCodec)
+	}
+	if !needsFinalPass(opts) {
+		t.Error("expected a webm render to re-encode the mp4 clips")
+	}
+
+	if _, err := applyFormat(RenderOptions{Format: "webm", OutputPath: "out.mp4"}); err == nil {
+		t.Error("expected an error for an output extension not matching the format")
+	}
+
+	clipsOpts, err := applyFormat(RenderOptions{Format: "mov", OutputDir: "clips"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if clipsOpts.NameTemplate != "clip_{index}.mov" {
+		t.Errorf("expected the default name template to use .mov, got %q", clipsOpts.NameTemplate)
+	}
+	if needsFinalPass(clipsOpts) {
+		t.Error("expected mov's h264/aac to need no final encode")
+	}
+
+	if _, err := applyFormat(RenderOptions{Format: "mov", OutputDir: "clips", NameTemplate: "{index}.mp4"}); err == nil {
+		t.Error("expected an error for a name template not matching the format")
+	}
+}
@@ -0,0 +1,102 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ManifestFile is the manifest's file name inside RenderClips' output
+// directory
+const ManifestFile = "manifest.json"
+
+// ManifestSuffix is appended to a joined Render's output path to name its
+// manifest, e.g. out.mp4.manifest.json
+const ManifestSuffix = ".manifest.json"
+
+// Manifest summarizes a render for downstream tools: one entry per clip
+// plus run metadata
+type Manifest struct {
+	CreatedAt     time.Time       `json:"created_at"`
+	Project       string          `json:"project"`
+	ConfigHash    string          `json:"config_hash"`
+	FFmpegVersion string          `json:"ffmpeg_version,omitempty"`
+	Clips         []ManifestEntry `json:"clips"`
+}
+
+// ManifestEntry describes one rendered clip. Output is relative to the
+// manifest; clips joined by Render share an output.
+type ManifestEntry struct {
+	Output     string  `json:"output"`
+	ClipID     string  `json:"clip_id"`
+	Source     string  `json:"source"`
+	StartNS    int64   `json:"start_ns"`
+	EndNS      int64   `json:"end_ns"`
+	DurationNS int64   `json:"duration_ns"`
+	Score      float64 `json:"score"`
+	Overlay    string  `json:"overlay,omitempty"`
+}
+
+// newManifest builds entries for the project's clips; outputs and
+// overlays are indexed like project.Clips
+func newManifest(project *Project, dir string, outputs, overlays []string) *Manifest {
+	m := &Manifest{
+		CreatedAt: time.Now().UTC(),
+		Project:   project.Name,
+		Clips:     make([]ManifestEntry, len(project.Clips)),
+	}
+
+	for i, clip := range project.Clips {
+		source := clip.SourceURL
+		if source == "" {
+			source = project.InputPath
+		}
+
+		output := outputs[i]
+		if rel, err := filepath.Rel(dir, output); err == nil {
+			output = filepath.ToSlash(rel)
+		}
+
+		m.Clips[i] = ManifestEntry{
+			Output:     output,
+			ClipID:     clip.ID,
+			Source:     source,
+			StartNS:    int64(clip.Start),
+			EndNS:      int64(clip.End),
+			DurationNS: int64(clip.End - clip.Start),
+			Score:      clip.Score,
+			Overlay:    overlays[i],
+		}
+	}
+	return m
+}
+
+// Save writes the manifest to path
+func (m *Manifest) Save(path string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// writeManifest fills in run metadata and saves the manifest to path;
+// outputs are recorded relative to its directory
+func (p *Pipeline) writeManifest(ctx context.Context, project *Project, path string, outputs, overlays []string) error {
+	m := newManifest(project, filepath.Dir(path), outputs, overlays)
+	m.ConfigHash = p.configHash
+
+	version, err := p.ffmpeg.Version(ctx)
+	if err != nil {
+		p.logger.Debug().Err(err).Msg("ffmpeg version unavailable for manifest")
+	}
+	m.FFmpegVersion = version.String()
+
+	if err := m.Save(path); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNewManifest(t *testing.T) {
+	project := namingProject()
+	project.Name = "stream"
+	dir := t.TempDir()
+
+	outputs := []string{filepath.Join(dir, "a.mp4"), filepath.Join(dir, "b.mp4")}
+	m := newManifest(project, dir, outputs, []string{"parkour.mp4", ""})
+
+	if m.Project != "stream" || len(m.Clips) != 2 {
+		t.Fatalf("unexpected manifest: %+v", m)
+	}
+
+	first := m.Clips[0]
+	if first.Output != "a.mp4" {
+		t.Errorf("expected output relative to dir, got %q", first.Output)
+	}
+	if first.Source != project.InputPath {
+		t.Errorf("expected project input as source, got %q", first.Source)
+	}
+	if first.DurationNS != int64(7*time.Second) || first.Overlay != "parkour.mp4" {
+		t.Errorf("unexpected entry: %+v", first)
+	}
+	if m.Clips[1].Source != "other.mkv" {
+		t.Errorf("expected clip source URL, got %q", m.Clips[1].Source)
+	}
+}
+
+func TestManifestSave(t *testing.T) {
+	dir := t.TempDir()
+	m := newManifest(namingProject(), dir, []string{"x.mp4", "x.mp4"}, []string{"", ""})
+	m.ConfigHash = "abc123"
+
+	if err := m.Save(filepath.Join(dir, ManifestFile)); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var loaded Manifest
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatal(err)
+	}
+	if loaded.ConfigHash != "abc123" || len(loaded.Clips) != 2 || loaded.Clips[1].StartNS != m.Clips[1].StartNS {
+		t.Errorf("round trip mismatch: %+v", loaded)
+	}
+}
@@ -0,0 +1,123 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// metaValue is a metadata value tagged with its Go type. Plain JSON would
+// turn every number into float64, breaking consumers that assert .(int).
+type metaValue struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+// encodeMetadata converts a metadata map into type-tagged JSON values
+func encodeMetadata(m map[string]interface{}) (map[string]metaValue, error) {
+	if m == nil {
+		return nil, nil
+	}
+
+	out := make(map[string]metaValue, len(m))
+	for key, value := range m {
+		typeName := metaTypeName(value)
+
+		raw := value
+		if d, ok := value.(time.Duration); ok {
+			raw = int64(d)
+		}
+
+		data, err := json.Marshal(raw)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", key, err)
+		}
+		out[key] = metaValue{Type: typeName, Value: data}
+	}
+	return out, nil
+}
+
+// decodeMetadata restores a metadata map written by encodeMetadata
+func decodeMetadata(m map[string]metaValue) (map[string]interface{}, error) {
+	out := make(map[string]interface{}, len(m))
+	for key, mv := range m {
+		value, err := decodeMetaValue(mv)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", key, err)
+		}
+		out[key] = value
+	}
+	return out, nil
+}
+
+func metaTypeName(value interface{}) string {
+	switch value.(type) {
+	case int:
+		return "int"
+	case int64:
+		return "int64"
+	case float64:
+		return "float64"
+	case bool:
+		return "bool"
+	case string:
+		return "string"
+	case time.Duration:
+		return "duration"
+	case map[string]float64:
+		return "map_float64"
+	case []float64:
+		return "slice_float64"
+	case []string:
+		return "slice_string"
+	default:
+		return "json"
+	}
+}
+
+func decodeMetaValue(mv metaValue) (interface{}, error) {
+	switch mv.Type {
+	case "int":
+		var v int
+		err := json.Unmarshal(mv.Value, &v)
+		return v, err
+	case "int64":
+		var v int64
+		err := json.Unmarshal(mv.Value, &v)
+		return v, err
+	case "float64":
+		var v float64
+		err := json.Unmarshal(mv.Value, &v)
+		return v, err
+	case "bool":
+		var v bool
+		err := json.Unmarshal(mv.Value, &v)
+		return v, err
+	case "string":
+		var v string
+		err := json.Unmarshal(mv.Value, &v)
+		return v, err
+	case "duration":
+		var v int64
+		err := json.Unmarshal(mv.Value, &v)
+		return time.Duration(v), err
+	case "map_float64":
+		var v map[string]float64
+		err := json.Unmarshal(mv.Value, &v)
+		return v, err
+	case "slice_float64":
+		var v []float64
+		err := json.Unmarshal(mv.Value, &v)
+		return v, err
+	case "slice_string":
+		var v []string
+		err := json.Unmarshal(mv.Value, &v)
+		return v, err
+	case "json":
+		var v interface{}
+		err := json.Unmarshal(mv.Value, &v)
+		return v, err
+	default:
+		return nil, fmt.Errorf("unknown metadata type %q", mv.Type)
+	}
+}
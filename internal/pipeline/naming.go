@@ -0,0 +1,98 @@
+package pipeline
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/keagan/slopcannon/internal/clips"
+)
+
+// DefaultNameTemplate names per-clip outputs when RenderOptions.NameTemplate is empty
+const DefaultNameTemplate = "clip_{index}.mp4"
+
+var (
+	templateToken = regexp.MustCompile(`\{[^{}]*\}`)
+	unsafeChars   = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+)
+
+// clipOutputNames expands tmpl for every clip in the project. Supported
+// tokens are {index} (zero-based, zero-padded), {score}, {start} and
+// {source}. Token values are sanitized; the expanded names must be
+// distinct plain file names.
+func clipOutputNames(tmpl string, project *Project) ([]string, error) {
+	if tmpl == "" {
+		tmpl = DefaultNameTemplate
+	}
+
+	width := len(fmt.Sprint(len(project.Clips) - 1))
+	if width < 3 {
+		width = 3
+	}
+
+	names := make([]string, len(project.Clips))
+	seen := make(map[string]int, len(project.Clips))
+	for i, clip := range project.Clips {
+		name, err := expandName(tmpl, i, width, clip, project.InputPath)
+		if err != nil {
+			return nil, err
+		}
+		if prev, ok := seen[name]; ok {
+			return nil, fmt.Errorf("name template %q gives clips %d and %d the same name %q; add {index}", tmpl, prev, i, name)
+		}
+		seen[name] = i
+		names[i] = name
+	}
+	return names, nil
+}
+
+// expandName fills in one clip's template tokens
+func expandName(tmpl string, index, width int, clip *clips.Clip, source string) (string, error) {
+	if clip.SourceURL != "" {
+		source = clip.SourceURL
+	}
+
+	var unknown string
+	name := templateToken.ReplaceAllStringFunc(tmpl, func(token string) string {
+		switch token {
+		case "{index}":
+			return fmt.Sprintf("%0*d", width, index)
+		case "{score}":
+			return fmt.Sprintf("%.2f", clip.Score)
+		case "{start}":
+			return formatNameTime(clip.Start)
+		case "{source}":
+			base := filepath.Base(source)
+			return sanitizeName(strings.TrimSuffix(base, filepath.Ext(base)))
+		}
+		if unknown == "" {
+			unknown = token
+		}
+		return token
+	})
+
+	if unknown != "" {
+		return "", fmt.Errorf("unknown name template token %s (use {index}, {score}, {start} or {source})", unknown)
+	}
+	if name == "" || name != filepath.Base(name) || name == "." || name == ".." {
+		return "", fmt.Errorf("name template %q must expand to a file name, got %q", tmpl, name)
+	}
+	return name, nil
+}
+
+// formatNameTime renders d as HH-MM-SS, which sorts by time and is safe on Windows
+func formatNameTime(d time.Duration) string {
+	s := int(d / time.Second)
+	return fmt.Sprintf("%02d-%02d-%02d", s/3600, s/60%60, s%60)
+}
+
+// sanitizeName replaces runs of characters that are unsafe in file names with "_"
+func sanitizeName(s string) string {
+	s = unsafeChars.ReplaceAllString(s, "_")
+	if s == "" {
+		return "_"
+	}
+	return s
+}
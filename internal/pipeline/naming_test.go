@@ -0,0 +1,64 @@
+package pipeline
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/keagan/slopcannon/internal/clips"
+)
+
+func namingProject() *Project {
+	return &Project{
+		InputPath: "/videos/My Stream: Day 1.mp4",
+		Clips: []*clips.Clip{
+			{ID: "a", Start: 83 * time.Second, End: 90 * time.Second, Score: 0.875},
+			{ID: "b", Start: time.Hour + 2*time.Second, End: time.Hour + 9*time.Second, Score: 0.5, SourceURL: "other.mkv"},
+		},
+	}
+}
+
+func TestClipOutputNames(t *testing.T) {
+	tests := []struct {
+		tmpl string
+		want []string
+	}{
+		{"", []string{"clip_000.mp4", "clip_001.mp4"}},
+		{"{source}_{index}_score{score}.mp4", []string{"My_Stream_Day_1_000_score0.88.mp4", "other_001_score0.50.mp4"}},
+		{"{start}.mp4", []string{"00-01-23.mp4", "01-00-02.mp4"}},
+	}
+
+	for _, tt := range tests {
+		got, err := clipOutputNames(tt.tmpl, namingProject())
+		if err != nil {
+			t.Errorf("%q: %v", tt.tmpl, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q: got %v, want %v", tt.tmpl, got, tt.want)
+		}
+	}
+}
+
+func TestClipOutputNamesPadsToClipCount(t *testing.T) {
+	project := &Project{Clips: fakeClips(1200)}
+	names, err := clipOutputNames("", project)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names[7] != "clip_0007.mp4" {
+		t.Errorf("expected 4-digit index, got %q", names[7])
+	}
+}
+
+func TestClipOutputNamesErrors(t *testing.T) {
+	// Both clips get the same score, so {score} alone collides
+	project := namingProject()
+	project.Clips[1].Score = project.Clips[0].Score
+
+	for _, tmpl := range []string{"{title}.mp4", "out/{index}.mp4", "..", "{score}.mp4"} {
+		if _, err := clipOutputNames(tmpl, project); err == nil {
+			t.Errorf("%q: expected error", tmpl)
+		}
+	}
+}
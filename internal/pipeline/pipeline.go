@@ -3,23 +3,47 @@ package pipeline
 import (
 	"context"
 	"fmt"
-	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/keagan/slopcannon/internal/ai"
 	"github.com/keagan/slopcannon/internal/clips"
 	"github.com/keagan/slopcannon/internal/config"
 	"github.com/keagan/slopcannon/internal/ffmpeg"
+	"github.com/keagan/slopcannon/internal/overlays"
 	"github.com/rs/zerolog"
 )
 
 // Pipeline orchestrates the entire video processing workflow
 type Pipeline struct {
-	logger   zerolog.Logger
-	config   *Config
-	ffmpeg   *ffmpeg.Executor
+	logger zerolog.Logger
+	config *Config
+	ffmpeg *ffmpeg.Executor
+	// extract cuts clips during rendering (the executor's ExtractClip)
+	extract  extractFunc
 	detector *ai.ClipDetector
+	tempDir  string
+	workDir  string
+	weights  map[string]float64
+	strategy ai.CandidateStrategy
+	keywords map[string]float64
+	provider ai.ExecutionProvider
+	// Keyframes per CLIP inference run (0 = ai.DefaultBatchSize)
+	batchSize int
+	// Face detector path ("" = ai.FaceModelFile in the model dir)
+	faceModel string
+	// Audio event classifier path ("" = ai.AudioEventModelFile in the model dir)
+	audioModel string
+	// How the composite scorer treats a failing scorer ("" = skip)
+	failMode ai.FailureMode
+	overlays *overlays.Registry
+	// Default caption styling, from the subtitles config
+	captionStyle ffmpeg.DrawTextOptions
+	// Fingerprint of the app config, recorded in render manifests
+	configHash string
+	// eventMu serializes OnEvent calls
+	eventMu sync.Mutex
 }
 
 // New creates a new pipeline instance
@@ -33,18 +57,50 @@ func New(logger zerolog.Logger, cfg *Config, appCfg *config.Config) (*Pipeline,
 	} else if cfg.ModelPath == "" {
 		cfg.ModelPath = appCfg.AI.ModelPath
 	}
+	if cfg.Workers < 1 {
+		cfg.Workers = 1
+	}
 
-	ffmpegExec, err := ffmpeg.New(logger, appCfg.FFmpeg.Threads)
+	ffmpegExec, err := ffmpeg.NewWithConfig(logger, appCfg.FFmpeg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize ffmpeg: %w", err)
 	}
 
+	registry := overlays.NewRegistry()
+	registry.SetLogger(logger)
+	registry.SetSeed(appCfg.Overlays.Seed)
+	if err := registry.LoadAll(appCfg.Overlays); err != nil {
+		return nil, fmt.Errorf("failed to load overlays: %w", err)
+	}
+
 	p := &Pipeline{
-		logger: logger.With().Str("component", "pipeline").Logger(),
-		config: cfg,
-		ffmpeg: ffmpegExec,
+		logger:     logger.With().Str("component", "pipeline").Logger(),
+		config:     cfg,
+		ffmpeg:     ffmpegExec,
+		extract:    ffmpegExec.ExtractClip,
+		tempDir:    appCfg.TempDir,
+		workDir:    appCfg.WorkDir,
+		weights:    appCfg.AI.ScoringWeights,
+		strategy:   ai.CandidateStrategy(appCfg.AI.CandidateStrategy),
+		keywords:   appCfg.AI.Keywords,
+		provider:   ai.ExecutionProvider(appCfg.AI.ExecutionProvider),
+		batchSize:  appCfg.AI.BatchSize,
+		faceModel:  appCfg.AI.FaceModel,
+		audioModel: appCfg.AI.AudioEventModel,
+		failMode:   ai.FailureMode(appCfg.AI.ScorerFailures),
+		overlays:   registry,
+		configHash: appCfg.Hash(),
+		captionStyle: ffmpeg.DrawTextOptions{
+			FontName:    appCfg.Subtitles.FontName,
+			FontSize:    appCfg.Subtitles.FontSize,
+			FontColor:   appCfg.Subtitles.FontColor,
+			BorderWidth: appCfg.Subtitles.OutlineWidth,
+		},
 		// detector will be created per detectClips call
 	}
+	if progress := p.progressFunc(); progress != nil {
+		ffmpegExec.SetProgressFunc(progress)
+	}
 
 	return p, nil
 }
@@ -68,12 +124,25 @@ func (p *Pipeline) Analyze(ctx context.Context, input string, opts AnalyzeOption
 	if input == "" {
 		return nil, fmt.Errorf("input path cannot be empty")
 	}
+	detectorCfg, err := p.detectorConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Probe results and keyframes are shared with the detector
+	runCache := ai.NewRunCache(p.logger)
+	defer runCache.Close()
+	ctx = ai.WithRunCache(ctx, runCache)
 
 	// Stage 1: Extract video metadata
-	videoInfo, err := p.ffmpeg.ProbeVideo(ctx, input)
+	media, err := runCache.ProbeMedia(ctx, p.ffmpeg, input)
 	if err != nil {
 		return nil, fmt.Errorf("failed to probe video: %w", err)
 	}
+	if err := requireVideo(input, media); err != nil {
+		return nil, err
+	}
+	videoInfo := &media.VideoInfo
 
 	p.logger.Info().
 		Dur("duration", videoInfo.Duration).
@@ -81,9 +150,10 @@ func (p *Pipeline) Analyze(ctx context.Context, input string, opts AnalyzeOption
 		Int("height", videoInfo.Height).
 		Float64("fps", videoInfo.FPS).
 		Msg("video metadata extracted")
+	p.emit(Event{Type: EventProbed, Input: input, Info: videoInfo})
 
 	// Stage 2: AI-powered clip detection
-	detectedClips, err := p.detectClips(ctx, input, opts)
+	detectedClips, err := p.detectClips(ctx, input, detectorCfg, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to detect clips: %w", err)
 	}
@@ -98,6 +168,10 @@ func (p *Pipeline) Analyze(ctx context.Context, input string, opts AnalyzeOption
 		detectedClips = detectedClips[:opts.MaxClips]
 	}
 
+	if opts.Dedupe {
+		p.hashClips(ctx, detectedClips)
+	}
+
 	// Stage 3: Create project
 	project := &Project{
 		Name:      fmt.Sprintf("project_%d", time.Now().Unix()),
@@ -124,120 +198,93 @@ func (p *Pipeline) Analyze(ctx context.Context, input string, opts AnalyzeOption
 	return project, nil
 }
 
-// Render executes the rendering pipeline for a project
-func (p *Pipeline) Render(ctx context.Context, project *Project, opts RenderOptions) (string, error) {
-	// Validate project
-	if project == nil {
-		return "", fmt.Errorf("project cannot be nil")
-	}
-
-	p.logger.Info().
-		Str("project", project.Name).
-		Str("output", opts.OutputPath).
-		Msg("starting render pipeline")
-	if len(project.Clips) == 0 {
-		return "", fmt.Errorf("project has no clips to render")
+// requireVideo rejects inputs clip detection can't work with, such as
+// audio-only files, which would otherwise yield meaningless candidates
+func requireVideo(input string, media *ffmpeg.MediaInfo) error {
+	switch media.Kind {
+	case ffmpeg.MediaVideo:
+	case ffmpeg.MediaAudio:
+		return fmt.Errorf("%s is audio-only; clip detection needs a video stream", input)
+	case ffmpeg.MediaImage:
+		return fmt.Errorf("%s is a still image; clip detection needs a video", input)
+	default:
+		return fmt.Errorf("%s has no audio or video streams", input)
 	}
-	if opts.OutputPath == "" {
-		return "", fmt.Errorf("output path cannot be empty")
+	if media.Duration <= 0 {
+		return fmt.Errorf("%s has no duration; can't detect clips in it", input)
 	}
-
-	// TODO: Implement render stages:
-	// 1. Extract clips from source video
-	// 2. Generate subtitles (if enabled)
-	// 3. Apply overlays
-	// 4. Concatenate clips
-	// 5. Final render with effects
-
-	p.logger.Info().
-		Str("output", opts.OutputPath).
-		Msg("render pipeline complete")
-
-	return opts.OutputPath, nil
+	return nil
 }
 
-// detectClips performs AI-powered clip detection with composite scoring
-func (p *Pipeline) detectClips(ctx context.Context, videoPath string, opts AnalyzeOptions) ([]*clips.Clip, error) {
-	p.logger.Debug().Msg("detecting clips with AI")
-
-	// Create detector config
+// detectorConfig builds the detector config for opts; clip length limits
+// fall back to the pipeline Config, then to the detector defaults
+func (p *Pipeline) detectorConfig(opts AnalyzeOptions) (ai.DetectorConfig, error) {
 	detectorCfg := ai.DefaultDetectorConfig()
+	if p.config.MinClipLength > 0 {
+		detectorCfg.MinClipLength = p.config.MinClipLength
+	}
+	if p.config.MaxClipLength > 0 {
+		detectorCfg.MaxClipLength = p.config.MaxClipLength
+	}
 	if opts.MinClipLen > 0 {
 		detectorCfg.MinClipLength = opts.MinClipLen
 	}
+	if opts.MaxClipLen > 0 {
+		detectorCfg.MaxClipLength = opts.MaxClipLen
+	}
+	if detectorCfg.MaxClipLength <= detectorCfg.MinClipLength {
+		return detectorCfg, fmt.Errorf("max clip length (%s) must be greater than min clip length (%s)",
+			detectorCfg.MaxClipLength, detectorCfg.MinClipLength)
+	}
+
 	if opts.MaxClips > 0 {
 		detectorCfg.TopN = opts.MaxClips
 	}
+	detectorCfg.ScoreTimeout = opts.ScoreTimeout
+	detectorCfg.Workers = p.config.Workers
+	if p.strategy != "" {
+		detectorCfg.CandidateStrategy = p.strategy
+	}
+	return detectorCfg, nil
+}
+
+// detectClips performs AI-powered clip detection with composite scoring
+func (p *Pipeline) detectClips(ctx context.Context, videoPath string, detectorCfg ai.DetectorConfig, opts AnalyzeOptions) ([]*clips.Clip, error) {
+	p.logger.Debug().Msg("detecting clips with AI")
 
 	// Build scorer based on model availability
-	scorer := p.buildScorer()
+	scorer := p.buildScorer(detectorCfg, opts.Transcript)
 	defer scorer.Close()
 
 	// Create detector with custom scorer
 	detector := ai.NewClipDetector(p.logger, p.ffmpeg, scorer, detectorCfg)
 	defer detector.Close()
+	events := p.detectorEvents(videoPath)
 
-	return detector.Detect(ctx, videoPath)
-}
+	var dumper *featureDumper
+	if opts.DumpFeatures != "" {
+		dump, err := ai.CreateFeatureDump(opts.DumpFeatures)
+		if err != nil {
+			return nil, err
+		}
+		dumper = &featureDumper{dump: dump, next: events}
+		events = dumper.handle
+	}
+	detector.SetEventFunc(events)
 
-// buildScorer creates appropriate scorer based on pipeline config.
-func (p *Pipeline) buildScorer() ai.Scorer {
-	// Always have heuristic scoring
-	heuristic := ai.NewHeuristicScorer()
-	aesthetic := ai.NewAestheticScorer(p.logger, p.ffmpeg)
-
-	modelDir := p.config.ModelPath
-	if modelDir == "" {
-		// No model configured → heuristic + aesthetic only
-		p.logger.Info().Msg("no model path configured; using heuristic + aesthetic scoring")
-		return ai.NewCompositeScorer(
-			[]ai.Scorer{heuristic, aesthetic},
-			[]float64{0.6, 0.4},
-		)
-	}
-
-	encoderPath := filepath.Join(modelDir, "clip_image_encoder.onnx")
-	headPath := filepath.Join(modelDir, "virality_head.onnx")
-
-	// Sanity check: files exist
-	if _, err := os.Stat(encoderPath); err != nil {
-		p.logger.Warn().Err(err).
-			Str("encoder", encoderPath).
-			Msg("encoder model not found; falling back to heuristic + aesthetic scoring")
-		return ai.NewCompositeScorer(
-			[]ai.Scorer{heuristic, aesthetic},
-			[]float64{0.6, 0.4},
-		)
-	}
-	if _, err := os.Stat(headPath); err != nil {
-		p.logger.Warn().Err(err).
-			Str("head", headPath).
-			Msg("virality head model not found; falling back to heuristic + aesthetic scoring")
-		return ai.NewCompositeScorer(
-			[]ai.Scorer{heuristic, aesthetic},
-			[]float64{0.6, 0.4},
-		)
-	}
-
-	clipScorer, err := ai.NewCLIPScorer(p.logger, p.ffmpeg, encoderPath, headPath)
-	if err != nil {
-		p.logger.Warn().Err(err).
-			Str("encoder", encoderPath).
-			Str("head", headPath).
-			Msg("failed to initialize CLIP scorer; using heuristic + aesthetic scoring")
-		return ai.NewCompositeScorer(
-			[]ai.Scorer{heuristic, aesthetic},
-			[]float64{0.6, 0.4},
-		)
+	if p.config.EnableCache {
+		detector.SetCache(ai.NewAnalysisCache(p.logger, filepath.Join(p.workDir, "cache")))
 	}
 
-	p.logger.Info().
-		Str("encoder_model", encoderPath).
-		Str("head_model", headPath).
-		Msg("using heuristic + aesthetic + CLIP scoring")
-
-	return ai.NewCompositeScorer(
-		[]ai.Scorer{heuristic, aesthetic, clipScorer},
-		[]float64{0.3, 0.2, 0.5}, // adjust weights as you like
-	)
+	detected, err := detector.Detect(ctx, videoPath)
+	if dumper != nil {
+		if derr := dumper.close(); derr != nil && err == nil {
+			return nil, derr
+		}
+		p.logger.Info().
+			Str("path", opts.DumpFeatures).
+			Int("rows", dumper.rows).
+			Msg("candidate features written")
+	}
+	return detected, err
 }
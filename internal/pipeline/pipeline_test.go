@@ -0,0 +1,55 @@
+package pipeline
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keagan/slopcannon/internal/ffmpeg"
+)
+
+func TestDetectorConfigClipLengths(t *testing.T) {
+	p := &Pipeline{config: &Config{MaxClipLength: 45 * time.Second}}
+
+	cfg, err := p.detectorConfig(AnalyzeOptions{MinClipLen: 5 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.MinClipLength != 5*time.Second || cfg.MaxClipLength != 45*time.Second {
+		t.Errorf("expected 5s-45s from options and pipeline config, got %s-%s", cfg.MinClipLength, cfg.MaxClipLength)
+	}
+
+	cfg, err = p.detectorConfig(AnalyzeOptions{MinClipLen: 5 * time.Second, MaxClipLen: 20 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.MaxClipLength != 20*time.Second {
+		t.Errorf("expected MaxClipLen to win, got %s", cfg.MaxClipLength)
+	}
+
+	if _, err := p.detectorConfig(AnalyzeOptions{MinClipLen: 30 * time.Second, MaxClipLen: 30 * time.Second}); err == nil {
+		t.Error("expected error when max does not exceed min")
+	}
+	// The default minimum (10s) applies when only a max is given
+	if _, err := (&Pipeline{config: &Config{}}).detectorConfig(AnalyzeOptions{MaxClipLen: 8 * time.Second}); err == nil {
+		t.Error("expected error when max is below the default min")
+	}
+}
+
+func TestRequireVideo(t *testing.T) {
+	video := &ffmpeg.MediaInfo{Kind: ffmpeg.MediaVideo, HasVideo: true}
+	video.Duration = time.Minute
+	if err := requireVideo("clip.mp4", video); err != nil {
+		t.Errorf("expected a video to be accepted: %v", err)
+	}
+
+	audio := &ffmpeg.MediaInfo{Kind: ffmpeg.MediaAudio}
+	audio.Duration = time.Minute
+	if err := requireVideo("song.mp3", audio); err == nil || !strings.Contains(err.Error(), "audio-only") {
+		t.Errorf("expected an audio-only error, got %v", err)
+	}
+
+	if err := requireVideo("empty.mp4", &ffmpeg.MediaInfo{Kind: ffmpeg.MediaVideo, HasVideo: true}); err == nil {
+		t.Error("expected a video without duration to be rejected")
+	}
+}
@@ -0,0 +1,70 @@
+package pipeline
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/keagan/slopcannon/internal/ffmpeg"
+	"github.com/keagan/slopcannon/pkg/util"
+)
+
+const (
+	// PreviewDirName is the work dir subfolder previews are written to
+	PreviewDirName = "preview"
+	// previewPreset trades compression for encoding speed
+	previewPreset = "ultrafast"
+	// previewSize is the shorter side of a preview proxy (480p)
+	previewSize = 480
+)
+
+// previewScaleFilter scales the shorter side to previewSize, keeping the
+// aspect ratio, so landscape and vertical renders both come out as 480p
+var previewScaleFilter = fmt.Sprintf("scale=w='if(gt(iw,ih),-2,%d)':h='if(gt(iw,ih),%d,-2)'", previewSize, previewSize)
+
+// previewDir is where preview renders go
+func (p *Pipeline) previewDir() string {
+	return filepath.Join(p.workDir, PreviewDirName)
+}
+
+// routePreview points a preview render's outputs into the preview dir,
+// keeping file names, and creates the dir
+func (p *Pipeline) routePreview(opts RenderOptions) (RenderOptions, error) {
+	if !opts.Preview {
+		return opts, nil
+	}
+	if err := util.EnsureDir(p.previewDir()); err != nil {
+		return opts, fmt.Errorf("failed to create preview dir: %w", err)
+	}
+	if opts.OutputPath != "" {
+		opts.OutputPath = filepath.Join(p.previewDir(), filepath.Base(opts.OutputPath))
+	}
+	opts.OutputDir = p.previewDir()
+	return opts, nil
+}
+
+// previewRenderOptions turns an ffmpeg render into a fast proxy encode.
+// Two-pass, bitrate limits, loudness normalization and hardware encoding
+// are dropped: they cost time or setup a throwaway preview doesn't need.
+// Audio is copied unless the output format wants another codec than the
+// cut clips have.
+func previewRenderOptions(ro ffmpeg.RenderOptions) ffmpeg.RenderOptions {
+	ro.Preset = previewPreset
+	ro.Width, ro.Height, ro.Scale, ro.Fit = 0, 0, "", false
+	ro.Filters = append(ro.Filters, previewScaleFilter)
+	if ro.AudioCodec == "" || ro.AudioCodec == ffmpeg.DefaultAudioCodec {
+		ro.AudioCodec = "copy"
+	}
+	ro.TargetBitrate, ro.MaxBitrate = "", ""
+	ro.TwoPass = false
+	ro.Loudness = 0
+	ro.HWAccel = ffmpeg.HWAccelNone
+	return ro
+}
+
+// extractPreset is the encoder preset used when cutting clips
+func extractPreset(opts RenderOptions) string {
+	if opts.Preview {
+		return previewPreset
+	}
+	return ""
+}
@@ -0,0 +1,63 @@
+package pipeline
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/keagan/slopcannon/internal/ffmpeg"
+)
+
+func TestRoutePreview(t *testing.T) {
+	work := t.TempDir()
+	p := &Pipeline{workDir: work}
+
+	full := RenderOptions{OutputPath: "/videos/out.mp4", OutputDir: "/videos/clips"}
+	if got, err := p.routePreview(full); err != nil || got.OutputPath != full.OutputPath || got.OutputDir != full.OutputDir {
+		t.Errorf("expected a full render to keep its outputs, got %+v (%v)", got, err)
+	}
+
+	preview := full
+	preview.Preview = true
+	got, err := p.routePreview(preview)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(work, PreviewDirName)
+	if got.OutputPath != filepath.Join(dir, "out.mp4") || got.OutputDir != dir {
+		t.Errorf("expected outputs in %s, got %q and %q", dir, got.OutputPath, got.OutputDir)
+	}
+}
+
+func TestPreviewRenderOptions(t *testing.T) {
+	opts := RenderOptions{
+		Quality:       20,
+		Preset:        "slow",
+		Width:         1920,
+		Height:        1080,
+		TargetBitrate: "8M",
+		TwoPass:       true,
+		Reframe:       ffmpeg.ReframeBlurPad,
+		Preview:       true,
+	}
+	if !needsFinalPass(RenderOptions{Preview: true}) {
+		t.Error("expected previews to always re-render")
+	}
+
+	ro := finalRenderOptions(opts, "in.mp4", "out.mp4", "")
+	if ro.Preset != previewPreset || ro.AudioCodec != "copy" {
+		t.Errorf("expected an ultrafast encode with copied audio, got preset %q audio %q", ro.Preset, ro.AudioCodec)
+	}
+	if ro.TwoPass || ro.TargetBitrate != "" || ro.HWAccel != ffmpeg.HWAccelNone {
+		t.Errorf("expected two-pass, bitrate and hardware encoding off, got %+v", ro)
+	}
+	if ro.Width != 0 || ro.Height != 0 || len(ro.Filters) != 1 || ro.Filters[0] != previewScaleFilter {
+		t.Errorf("expected only the 480p scale, got %dx%d %v", ro.Width, ro.Height, ro.Filters)
+	}
+	if ro.Reframe != ffmpeg.ReframeBlurPad || ro.CRF != 20 {
+		t.Errorf("expected layout and quality to carry over, got %+v", ro)
+	}
+
+	if extractPreset(opts) != previewPreset || extractPreset(RenderOptions{}) != "" {
+		t.Error("expected only previews to cut clips with the preview preset")
+	}
+}
@@ -0,0 +1,234 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/keagan/slopcannon/internal/clips"
+)
+
+// projectFileVersion is bumped when the on-disk format changes
+const projectFileVersion = 1
+
+// projectFile is the JSON representation of a Project. Durations are stored
+// as nanoseconds and metadata values carry their Go type so that a
+// save/load round trip is lossless.
+type projectFile struct {
+	Version   int                  `json:"version"`
+	Name      string               `json:"name"`
+	InputPath string               `json:"input_path"`
+	Clips     []clipRecord         `json:"clips"`
+	Timeline  *timelineRecord      `json:"timeline,omitempty"`
+	Metadata  map[string]metaValue `json:"metadata,omitempty"`
+	CreatedAt time.Time            `json:"created_at"`
+	UpdatedAt time.Time            `json:"updated_at"`
+}
+
+type clipRecord struct {
+	ID         string               `json:"id"`
+	StartNS    int64                `json:"start_ns"`
+	EndNS      int64                `json:"end_ns"`
+	DurationNS int64                `json:"duration_ns"`
+	Score      float64              `json:"score"`
+	SourceURL  string               `json:"source_url"`
+	Metadata   map[string]metaValue `json:"metadata,omitempty"`
+}
+
+type timelineRecord struct {
+	ClipIDs  []string        `json:"clip_ids"`
+	Overlays []overlayRecord `json:"overlays,omitempty"`
+	SFX      []sfxRecord     `json:"sfx,omitempty"`
+}
+
+type overlayRecord struct {
+	Type        string  `json:"type"`
+	Path        string  `json:"path"`
+	StartTimeNS int64   `json:"start_time_ns"`
+	EndTimeNS   int64   `json:"end_time_ns"`
+	Opacity     float64 `json:"opacity"`
+	X           int     `json:"x"`
+	Y           int     `json:"y"`
+}
+
+type sfxRecord struct {
+	Path        string  `json:"path"`
+	TimestampNS int64   `json:"timestamp_ns"`
+	Volume      float64 `json:"volume"`
+}
+
+// Save writes the project to path as JSON
+func (p *Project) Save(path string) error {
+	data, err := p.MarshalIndent()
+	if err != nil {
+		return err
+	}
+
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create project dir: %w", err)
+		}
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
+// MarshalIndent returns the project's indented JSON encoding
+func (p *Project) MarshalIndent() ([]byte, error) {
+	file, err := p.toFile()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode project: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// LoadProject reads a project previously written by Project.Save
+func LoadProject(path string) (*Project, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file projectFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse project %s: %w", path, err)
+	}
+	if file.Version > projectFileVersion {
+		return nil, fmt.Errorf("project %s has unsupported version %d", path, file.Version)
+	}
+
+	return file.toProject()
+}
+
+func (p *Project) toFile() (*projectFile, error) {
+	file := &projectFile{
+		Version:   projectFileVersion,
+		Name:      p.Name,
+		InputPath: p.InputPath,
+		Clips:     make([]clipRecord, 0, len(p.Clips)),
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: p.UpdatedAt,
+	}
+
+	var err error
+	if file.Metadata, err = encodeMetadata(p.Metadata); err != nil {
+		return nil, fmt.Errorf("project metadata: %w", err)
+	}
+
+	for _, clip := range p.Clips {
+		meta, err := encodeMetadata(clip.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("clip %s metadata: %w", clip.ID, err)
+		}
+		file.Clips = append(file.Clips, clipRecord{
+			ID:         clip.ID,
+			StartNS:    int64(clip.Start),
+			EndNS:      int64(clip.End),
+			DurationNS: int64(clip.Duration),
+			Score:      clip.Score,
+			SourceURL:  clip.SourceURL,
+			Metadata:   meta,
+		})
+	}
+
+	if p.Timeline != nil {
+		tl := &timelineRecord{ClipIDs: make([]string, 0, len(p.Timeline.Clips))}
+		for _, clip := range p.Timeline.Clips {
+			tl.ClipIDs = append(tl.ClipIDs, clip.ID)
+		}
+		for _, o := range p.Timeline.Overlays {
+			tl.Overlays = append(tl.Overlays, overlayRecord{
+				Type:        o.Type,
+				Path:        o.Path,
+				StartTimeNS: int64(o.StartTime),
+				EndTimeNS:   int64(o.EndTime),
+				Opacity:     o.Opacity,
+				X:           o.X,
+				Y:           o.Y,
+			})
+		}
+		for _, sfx := range p.Timeline.SFX {
+			tl.SFX = append(tl.SFX, sfxRecord{
+				Path:        sfx.Path,
+				TimestampNS: int64(sfx.Timestamp),
+				Volume:      sfx.Volume,
+			})
+		}
+		file.Timeline = tl
+	}
+
+	return file, nil
+}
+
+func (f *projectFile) toProject() (*Project, error) {
+	project := &Project{
+		Name:      f.Name,
+		InputPath: f.InputPath,
+		Clips:     make([]*clips.Clip, 0, len(f.Clips)),
+		CreatedAt: f.CreatedAt,
+		UpdatedAt: f.UpdatedAt,
+	}
+
+	var err error
+	if project.Metadata, err = decodeMetadata(f.Metadata); err != nil {
+		return nil, fmt.Errorf("project metadata: %w", err)
+	}
+
+	byID := make(map[string]*clips.Clip, len(f.Clips))
+	for _, rec := range f.Clips {
+		meta, err := decodeMetadata(rec.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("clip %s metadata: %w", rec.ID, err)
+		}
+		clip := &clips.Clip{
+			ID:        rec.ID,
+			Start:     time.Duration(rec.StartNS),
+			End:       time.Duration(rec.EndNS),
+			Duration:  time.Duration(rec.DurationNS),
+			Score:     rec.Score,
+			SourceURL: rec.SourceURL,
+			Metadata:  meta,
+		}
+		project.Clips = append(project.Clips, clip)
+		byID[clip.ID] = clip
+	}
+
+	if f.Timeline != nil {
+		tl := &Timeline{}
+		for _, id := range f.Timeline.ClipIDs {
+			clip, ok := byID[id]
+			if !ok {
+				return nil, fmt.Errorf("timeline references unknown clip %q", id)
+			}
+			tl.Clips = append(tl.Clips, clip)
+		}
+		for _, o := range f.Timeline.Overlays {
+			tl.Overlays = append(tl.Overlays, Overlay{
+				Type:      o.Type,
+				Path:      o.Path,
+				StartTime: time.Duration(o.StartTimeNS),
+				EndTime:   time.Duration(o.EndTimeNS),
+				Opacity:   o.Opacity,
+				X:         o.X,
+				Y:         o.Y,
+			})
+		}
+		for _, sfx := range f.Timeline.SFX {
+			tl.SFX = append(tl.SFX, SoundEffect{
+				Path:      sfx.Path,
+				Timestamp: time.Duration(sfx.TimestampNS),
+				Volume:    sfx.Volume,
+			})
+		}
+		project.Timeline = tl
+	}
+
+	return project, nil
+}
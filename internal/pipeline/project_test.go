@@ -0,0 +1,72 @@
+package pipeline
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/keagan/slopcannon/internal/clips"
+)
+
+func TestProjectSaveLoadRoundTrip(t *testing.T) {
+	clip := &clips.Clip{
+		ID:        "clip_0",
+		Start:     1500 * time.Millisecond,
+		End:       31*time.Second + 250*time.Millisecond,
+		Duration:  29*time.Second + 750*time.Millisecond,
+		Score:     0.73,
+		SourceURL: "input.mp4",
+		Metadata: map[string]interface{}{
+			"scene_changes": 4,
+			"silence_ratio": 0.0,
+			"peak_volume":   -3.5,
+			"clip_score":    0.91,
+			"offset":        250 * time.Millisecond,
+		},
+	}
+
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	project := &Project{
+		Name:      "project_1",
+		InputPath: "input.mp4",
+		Clips:     []*clips.Clip{clip},
+		Timeline: &Timeline{
+			Clips:    []*clips.Clip{clip},
+			Overlays: []Overlay{{Type: "video", Path: "mc.mp4", StartTime: time.Second, EndTime: 10 * time.Second, Opacity: 0.5}},
+		},
+		Metadata:  map[string]interface{}{"duration": 120.5, "width": 1920, "has_audio": true},
+		CreatedAt: created,
+		UpdatedAt: created,
+	}
+
+	path := filepath.Join(t.TempDir(), "project.json")
+	if err := project.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := LoadProject(path)
+	if err != nil {
+		t.Fatalf("LoadProject failed: %v", err)
+	}
+
+	got := loaded.Clips[0]
+	if got.Start != clip.Start || got.End != clip.End || got.Duration != clip.Duration {
+		t.Errorf("durations not preserved: %+v", got)
+	}
+	if !reflect.DeepEqual(got.Metadata, clip.Metadata) {
+		t.Errorf("clip metadata mismatch:\n got %#v\nwant %#v", got.Metadata, clip.Metadata)
+	}
+	if !reflect.DeepEqual(loaded.Metadata, project.Metadata) {
+		t.Errorf("project metadata mismatch:\n got %#v\nwant %#v", loaded.Metadata, project.Metadata)
+	}
+	if loaded.Timeline.Clips[0] != got {
+		t.Error("timeline clips should reference the loaded clip")
+	}
+	if loaded.Timeline.Overlays[0].StartTime != time.Second {
+		t.Errorf("overlay start not preserved: %v", loaded.Timeline.Overlays[0].StartTime)
+	}
+	if !loaded.CreatedAt.Equal(created) {
+		t.Errorf("created_at not preserved: %v", loaded.CreatedAt)
+	}
+}
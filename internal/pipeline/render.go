@@ -0,0 +1,272 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/keagan/slopcannon/internal/clips"
+	"github.com/keagan/slopcannon/internal/ffmpeg"
+	"github.com/keagan/slopcannon/internal/overlays"
+	"github.com/keagan/slopcannon/pkg/util"
+	"golang.org/x/sync/errgroup"
+)
+
+// extractFunc cuts a single clip; matches ffmpeg.Executor.ExtractClip
+type extractFunc func(ctx context.Context, input string, opts ffmpeg.ClipOptions) error
+
+// Render executes the rendering pipeline for a project
+func (p *Pipeline) Render(ctx context.Context, project *Project, opts RenderOptions) (string, error) {
+	// Validate project
+	if project == nil {
+		return "", fmt.Errorf("project cannot be nil")
+	}
+
+	if len(project.Clips) == 0 {
+		return "", fmt.Errorf("project has no clips to render")
+	}
+	if opts.OutputPath == "" {
+		return "", fmt.Errorf("output path cannot be empty")
+	}
+	if err := ffmpeg.ValidateTransition(opts.Transition); err != nil {
+		return "", err
+	}
+	opts, err := applySocialPreset(opts)
+	if err != nil {
+		return "", err
+	}
+	opts, err = applyFormat(opts)
+	if err != nil {
+		return "", err
+	}
+	opts, err = p.routePreview(opts)
+	if err != nil {
+		return "", err
+	}
+
+	p.logger.Info().
+		Str("project", project.Name).
+		Str("output", opts.OutputPath).
+		Bool("preview", opts.Preview).
+		Msg("starting render pipeline")
+
+	opts.Captions = p.styleCaptions(opts.Captions)
+	opts.FPS = p.outputFPS(ctx, project, opts)
+	project, err = p.fitProject(project, opts, false)
+	if err != nil {
+		return "", err
+	}
+
+	workDir, err := p.renderTempDir()
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(workDir)
+
+	// Stage 1: Extract clips from source video (in parallel)
+	parts, err := extractClips(ctx, project.Clips, project.InputPath, workDir, p.config.Workers, opts, p.extract)
+	if err != nil {
+		return "", fmt.Errorf("clip extraction failed: %w", err)
+	}
+
+	// Timeline overlays are composited last, so earlier stages write to a
+	// scratch file when there are any
+	composed := opts.OutputPath
+	timelineOverlays := projectOverlays(project)
+	if len(timelineOverlays) > 0 {
+		composed = filepath.Join(workDir, "composed.mp4")
+	}
+
+	// Stage 2: Concatenate clips in timeline order
+	joined := composed
+	if needsFinalPass(opts) {
+		joined = filepath.Join(workDir, "joined.mp4")
+	}
+
+	if len(parts) == 1 && joined != opts.OutputPath {
+		joined = parts[0]
+	} else if err := p.ffmpeg.Concat(ctx, ffmpeg.ConcatOptions{
+		Inputs:             parts,
+		Output:             joined,
+		Transition:         opts.Transition,
+		TransitionDuration: opts.TransitionDuration,
+	}); err != nil {
+		return "", fmt.Errorf("concat failed: %w", err)
+	}
+
+	// Stage 3: Final render with effects
+	var overlay string
+	if needsFinalPass(opts) {
+		overlay, err = p.resolveOverlay(opts.OverlayPath)
+		if err != nil {
+			return "", err
+		}
+		if err := p.ffmpeg.Render(ctx, finalRenderOptions(opts, joined, composed, overlay)); err != nil {
+			return "", fmt.Errorf("final render failed: %w", err)
+		}
+	}
+
+	// Stage 4: Composite timeline overlays in a single pass
+	if len(timelineOverlays) > 0 {
+		if !needsFinalPass(opts) {
+			composed = joined
+		}
+
+		renderer := overlays.NewFFmpegRenderer(p.logger, p.ffmpeg, p.overlays)
+		if err := renderer.Render(ctx, composed, opts.OutputPath, timelineOverlays); err != nil {
+			return "", fmt.Errorf("overlay render failed: %w", err)
+		}
+	}
+
+	// Every clip ends up in the one joined output
+	outputs := make([]string, len(project.Clips))
+	overlayPicks := make([]string, len(project.Clips))
+	for i := range outputs {
+		outputs[i] = opts.OutputPath
+		overlayPicks[i] = overlay
+	}
+	// Named after the output, so renders sharing a directory keep theirs
+	if err := p.writeManifest(ctx, project, opts.OutputPath+ManifestSuffix, outputs, overlayPicks); err != nil {
+		return "", err
+	}
+
+	p.logger.Info().
+		Str("output", opts.OutputPath).
+		Msg("render pipeline complete")
+
+	return opts.OutputPath, nil
+}
+
+// projectOverlays converts the project's timeline overlays for rendering
+func projectOverlays(project *Project) []overlays.Overlay {
+	if project.Timeline == nil {
+		return nil
+	}
+
+	list := make([]overlays.Overlay, 0, len(project.Timeline.Overlays))
+	for _, ov := range project.Timeline.Overlays {
+		list = append(list, overlays.Overlay{
+			Type:     ov.Type,
+			Path:     ov.Path,
+			Start:    ov.StartTime,
+			End:      ov.EndTime,
+			Opacity:  ov.Opacity,
+			Position: overlays.Position{X: ov.X, Y: ov.Y},
+		})
+	}
+	return list
+}
+
+// renderTempDir creates a scratch directory for a single render
+func (p *Pipeline) renderTempDir() (string, error) {
+	base := p.tempDir
+	if base == "" {
+		base = os.TempDir()
+	}
+	if err := util.EnsureDir(base); err != nil {
+		return "", fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	return os.MkdirTemp(base, "render-*")
+}
+
+// finalRenderOptions maps pipeline options onto an ffmpeg render of input;
+// overlay is the resolved gameplay clip for split-screen layouts
+func finalRenderOptions(opts RenderOptions, input, output, overlay string) ffmpeg.RenderOptions {
+	ro := ffmpeg.RenderOptions{
+		Input:          input,
+		Output:         output,
+		VideoCodec:     opts.VideoCodec,
+		AudioCodec:     opts.AudioCodec,
+		CRF:            opts.Quality,
+		Preset:         opts.Preset,
+		Width:          opts.Width,
+		Height:         opts.Height,
+		Fit:            opts.Fit,
+		FPS:            opts.FPS,
+		Reframe:        opts.Reframe,
+		ReframeOverlay: overlay,
+		BlurSigma:      opts.BlurSigma,
+		TargetBitrate:  opts.TargetBitrate,
+		TwoPass:        opts.TwoPass,
+		MaxBitrate:     opts.MaxBitrate,
+		Loudness:       opts.Loudness,
+		Captions:       opts.Captions,
+	}
+	if opts.Preview {
+		ro = previewRenderOptions(ro)
+	}
+	return ro
+}
+
+// styleCaptions fills unset caption styling from the subtitles config
+func (p *Pipeline) styleCaptions(captions []ffmpeg.Caption) []ffmpeg.Caption {
+	styled := make([]ffmpeg.Caption, len(captions))
+	for i, c := range captions {
+		styled[i] = ffmpeg.Caption{Text: c.Text, Options: c.Options.WithDefaults(p.captionStyle)}
+	}
+	return styled
+}
+
+// resolveOverlay maps a registered overlay name to its file ("" stays "")
+func (p *Pipeline) resolveOverlay(nameOrPath string) (string, error) {
+	if nameOrPath == "" {
+		return "", nil
+	}
+	return p.overlays.Resolve(nameOrPath)
+}
+
+// needsFinalPass reports whether the joined clips must be re-rendered
+func needsFinalPass(opts RenderOptions) bool {
+	return opts.Width > 0 || opts.Height > 0 || opts.FPS > 0 || opts.Reframe != ffmpeg.ReframeNone ||
+		len(opts.Captions) > 0 ||
+		opts.TargetBitrate != "" || opts.TwoPass || opts.Preview || transcodes(opts) ||
+		opts.MaxBitrate != "" || opts.Loudness != 0
+}
+
+// extractClips cuts every clip into dir using up to workers concurrent
+// extractions. The returned paths follow clip order regardless of which
+// extraction finishes first. On error, already-created files are removed.
+func extractClips(ctx context.Context, clipList []*clips.Clip, source, dir string, workers int, opts RenderOptions, extract extractFunc) ([]string, error) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	outputs := make([]string, len(clipList))
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(workers)
+
+	for i, clip := range clipList {
+		i, clip := i, clip
+		outputs[i] = filepath.Join(dir, fmt.Sprintf("clip_%03d.mp4", i))
+
+		g.Go(func() error {
+			if err := gctx.Err(); err != nil {
+				return err
+			}
+
+			input := clip.SourceURL
+			if input == "" {
+				input = source
+			}
+
+			if err := extract(gctx, input, ffmpeg.ClipOptions{
+				Start:  clip.Start,
+				End:    clip.End,
+				Output: outputs[i],
+				CRF:    opts.Quality,
+				Preset: extractPreset(opts),
+			}); err != nil {
+				return fmt.Errorf("clip %s: %w", clip.ID, err)
+			}
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		util.CleanupFiles(outputs...)
+		return nil, err
+	}
+
+	return outputs, nil
+}
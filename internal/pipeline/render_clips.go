@@ -0,0 +1,183 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/keagan/slopcannon/internal/clips"
+	"github.com/keagan/slopcannon/internal/ffmpeg"
+	"github.com/keagan/slopcannon/internal/overlays"
+	"github.com/keagan/slopcannon/pkg/util"
+	"golang.org/x/sync/errgroup"
+)
+
+// RenderClips renders every clip in the project to its own file in
+// opts.OutputDir and returns the paths in clip order
+func (p *Pipeline) RenderClips(ctx context.Context, project *Project, opts RenderOptions) ([]string, error) {
+	if project == nil {
+		return nil, fmt.Errorf("project cannot be nil")
+	}
+	if len(project.Clips) == 0 {
+		return nil, fmt.Errorf("project has no clips to render")
+	}
+	opts, err := applySocialPreset(opts)
+	if err != nil {
+		return nil, err
+	}
+	opts, err = applyFormat(opts)
+	if err != nil {
+		return nil, err
+	}
+	opts, err = p.routePreview(opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.OutputDir == "" {
+		return nil, fmt.Errorf("output directory cannot be empty")
+	}
+	if err := util.EnsureDir(opts.OutputDir); err != nil {
+		return nil, fmt.Errorf("failed to create output dir: %w", err)
+	}
+
+	project, err = p.fitProject(project, opts, true)
+	if err != nil {
+		return nil, err
+	}
+	names, err := clipOutputNames(opts.NameTemplate, project)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.Captions = p.styleCaptions(opts.Captions)
+	opts.FPS = p.outputFPS(ctx, project, opts)
+
+	picks, err := selectOverlays(p.overlays, len(project.Clips), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	workDir, err := p.renderTempDir()
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(workDir)
+
+	p.logger.Info().
+		Str("project", project.Name).
+		Str("output_dir", opts.OutputDir).
+		Int("clips", len(project.Clips)).
+		Str("overlay_strategy", string(opts.OverlayStrategy)).
+		Msg("rendering clips")
+
+	workers := p.config.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	outputs := make([]string, len(project.Clips))
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(workers)
+
+	for i, clip := range project.Clips {
+		i, clip := i, clip
+		outputs[i] = filepath.Join(opts.OutputDir, names[i])
+
+		g.Go(func() error {
+			if err := gctx.Err(); err != nil {
+				return err
+			}
+			if err := p.renderClip(gctx, project, clip, workDir, outputs[i], picks[i], opts); err != nil {
+				return fmt.Errorf("clip %s: %w", clip.ID, err)
+			}
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		util.CleanupFiles(outputs...)
+		return nil, err
+	}
+
+	if err := p.writeManifest(ctx, project, filepath.Join(opts.OutputDir, ManifestFile), outputs, picks); err != nil {
+		return nil, err
+	}
+
+	p.logger.Info().Int("rendered", len(outputs)).Msg("clip rendering complete")
+	return outputs, nil
+}
+
+// renderClip cuts one clip and, when needed, re-renders it with overlay
+func (p *Pipeline) renderClip(ctx context.Context, project *Project, clip *clips.Clip, workDir, output, overlay string, opts RenderOptions) error {
+	input := clip.SourceURL
+	if input == "" {
+		input = project.InputPath
+	}
+
+	cut := output
+	if needsFinalPass(opts) {
+		cut = filepath.Join(workDir, filepath.Base(output))
+		defer os.Remove(cut)
+	}
+
+	if err := p.extract(ctx, input, ffmpeg.ClipOptions{
+		Start:  clip.Start,
+		End:    clip.End,
+		Output: cut,
+		CRF:    opts.Quality,
+		Preset: extractPreset(opts),
+	}); err != nil {
+		return err
+	}
+
+	if cut == output {
+		return nil
+	}
+
+	if overlay != "" {
+		p.logger.Debug().Str("clip", clip.ID).Str("overlay", overlay).Msg("clip overlay selected")
+	}
+	return p.ffmpeg.Render(ctx, finalRenderOptions(opts, cut, output, overlay))
+}
+
+// selectOverlays returns the resolved overlay file for each of n clips
+func selectOverlays(registry *overlays.Registry, n int, opts RenderOptions) ([]string, error) {
+	picks := make([]string, n)
+
+	switch opts.OverlayStrategy {
+	case "", overlays.StrategyFixed:
+		path := opts.OverlayPath
+		if path != "" {
+			resolved, err := registry.Resolve(path)
+			if err != nil {
+				return nil, err
+			}
+			path = resolved
+		}
+		for i := range picks {
+			picks[i] = path
+		}
+
+	case overlays.StrategyRandom, overlays.StrategyRoundRobin:
+		if opts.Reframe != ffmpeg.ReframeSplitScreen {
+			return nil, fmt.Errorf("overlay strategy %q picks gameplay for --reframe %s; it has no effect without it", opts.OverlayStrategy, ffmpeg.ReframeSplitScreen)
+		}
+		next := registry.Random
+		if opts.OverlayStrategy == overlays.StrategyRoundRobin {
+			next = registry.RoundRobin()
+		}
+		for i := range picks {
+			_, path := next()
+			if path == "" {
+				return nil, fmt.Errorf("overlay strategy %q needs at least one overlay video; none were found", opts.OverlayStrategy)
+			}
+			picks[i] = path
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown overlay strategy %q (use fixed, random or roundrobin)", opts.OverlayStrategy)
+	}
+
+	return picks, nil
+}
@@ -0,0 +1,180 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/keagan/slopcannon/internal/clips"
+	"github.com/keagan/slopcannon/internal/ffmpeg"
+	"github.com/keagan/slopcannon/internal/overlays"
+	"github.com/rs/zerolog"
+)
+
+func fakeClips(n int) []*clips.Clip {
+	list := make([]*clips.Clip, n)
+	for i := range list {
+		list[i] = &clips.Clip{
+			ID:    fmt.Sprintf("clip_%d", i),
+			Start: time.Duration(i) * 10 * time.Second,
+			End:   time.Duration(i+1) * 10 * time.Second,
+		}
+	}
+	return list
+}
+
+func TestExtractClipsPreservesOrder(t *testing.T) {
+	dir := t.TempDir()
+	clipList := fakeClips(8)
+
+	var running, peak int32
+	extract := func(ctx context.Context, input string, opts ffmpeg.ClipOptions) error {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+
+		// Finish in random order
+		time.Sleep(time.Duration(rand.Intn(20)) * time.Millisecond)
+		return os.WriteFile(opts.Output, []byte(opts.Start.String()), 0644)
+	}
+
+	outputs, err := extractClips(context.Background(), clipList, "source.mp4", dir, 3, RenderOptions{}, extract)
+	if err != nil {
+		t.Fatalf("extractClips failed: %v", err)
+	}
+
+	if len(outputs) != len(clipList) {
+		t.Fatalf("expected %d outputs, got %d", len(clipList), len(outputs))
+	}
+	for i, output := range outputs {
+		data, err := os.ReadFile(output)
+		if err != nil {
+			t.Fatalf("missing output %d: %v", i, err)
+		}
+		if string(data) != clipList[i].Start.String() {
+			t.Errorf("output %d holds clip starting at %s, want %s", i, data, clipList[i].Start)
+		}
+	}
+	if peak > 3 {
+		t.Errorf("expected at most 3 concurrent extractions, saw %d", peak)
+	}
+}
+
+func TestExtractClipsCleansUpOnError(t *testing.T) {
+	dir := t.TempDir()
+
+	extract := func(ctx context.Context, input string, opts ffmpeg.ClipOptions) error {
+		if opts.Start == 50*time.Second {
+			return errors.New("boom")
+		}
+		return os.WriteFile(opts.Output, nil, 0644)
+	}
+
+	if _, err := extractClips(context.Background(), fakeClips(8), "source.mp4", dir, 2, RenderOptions{}, extract); err == nil {
+		t.Fatal("expected extraction error")
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 0 {
+		t.Errorf("expected temp files to be cleaned up, found %d", len(entries))
+	}
+}
+
+func TestRenderCancelledRemovesTempFiles(t *testing.T) {
+	tempDir := t.TempDir()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Each extraction leaves a partial file behind, then runs until cancelled
+	var started int32
+	extract := func(ctx context.Context, input string, opts ffmpeg.ClipOptions) error {
+		if err := os.WriteFile(opts.Output, []byte("partial"), 0644); err != nil {
+			return err
+		}
+		if atomic.AddInt32(&started, 1) == 2 {
+			cancel()
+		}
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	p := &Pipeline{
+		logger:  zerolog.Nop(),
+		config:  &Config{Workers: 2},
+		tempDir: tempDir,
+		extract: extract,
+	}
+	project := &Project{Name: "demo", InputPath: "source.mp4", Clips: fakeClips(4)}
+
+	_, err := p.Render(ctx, project, RenderOptions{OutputPath: filepath.Join(t.TempDir(), "out.mp4")})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancellation error, got %v", err)
+	}
+
+	entries, _ := os.ReadDir(tempDir)
+	if len(entries) != 0 {
+		t.Errorf("expected temp dir to be empty after cancellation, found %d entries", len(entries))
+	}
+}
+
+func TestSelectOverlays(t *testing.T) {
+	dir := t.TempDir()
+	registry := overlays.NewRegistry()
+	for _, name := range []string{"parkour", "surf"} {
+		path := filepath.Join(dir, name+".mp4")
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+		registry.Register(name, path)
+	}
+	logo := filepath.Join(dir, "logo.png")
+	if err := os.WriteFile(logo, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	registry.Register("logo", logo)
+
+	fixed, err := selectOverlays(registry, 3, RenderOptions{OverlayPath: "surf"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range fixed {
+		if path != filepath.Join(dir, "surf.mp4") {
+			t.Errorf("fixed strategy should resolve to surf.mp4, got %q", path)
+		}
+	}
+
+	splitScreen := RenderOptions{Reframe: ffmpeg.ReframeSplitScreen, OverlayStrategy: overlays.StrategyRoundRobin}
+	rotating, err := selectOverlays(registry, 3, splitScreen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rotating[0] == rotating[1] || rotating[0] != rotating[2] {
+		t.Errorf("round robin should alternate, got %v", rotating)
+	}
+	for _, path := range rotating {
+		if filepath.Ext(path) != ".mp4" {
+			t.Errorf("round robin picked a non-video overlay %q", path)
+		}
+	}
+
+	if _, err := selectOverlays(registry, 2, RenderOptions{OverlayStrategy: overlays.StrategyRandom}); err == nil {
+		t.Error("expected error for a rotating strategy without split-screen")
+	}
+	if _, err := selectOverlays(overlays.NewRegistry(), 2, RenderOptions{Reframe: ffmpeg.ReframeSplitScreen, OverlayStrategy: overlays.StrategyRandom}); err == nil {
+		t.Error("expected error when no overlays exist")
+	}
+	if _, err := selectOverlays(registry, 2, RenderOptions{OverlayStrategy: "shuffle"}); err == nil {
+		t.Error("expected error for unknown strategy")
+	}
+}
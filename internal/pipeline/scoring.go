@@ -0,0 +1,256 @@
+package pipeline
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/keagan/slopcannon/internal/ai"
+	"github.com/keagan/slopcannon/internal/subtitles"
+	"github.com/rs/zerolog"
+)
+
+// Scorer names (ai.Scorer.Name) used as keys in ai.scoring_weights
+const (
+	ScorerHeuristic  = "heuristic"
+	ScorerAesthetic  = "aesthetic"
+	ScorerCLIP       = "clip"
+	ScorerKeyword    = "keyword"
+	ScorerModel      = "model"
+	ScorerFace       = "face"
+	ScorerAudioEvent = "audio_event"
+)
+
+// defaultScoringWeights apply to scorers missing from the configured weights
+var defaultScoringWeights = map[string]float64{
+	ScorerHeuristic:  0.3,
+	ScorerAesthetic:  0.2,
+	ScorerCLIP:       0.5,
+	ScorerKeyword:    0.2,
+	ScorerModel:      0.5,
+	ScorerFace:       0.2,
+	ScorerAudioEvent: 0.2,
+}
+
+// buildScorer creates appropriate scorer based on pipeline config.
+func (p *Pipeline) buildScorer(detectorCfg ai.DetectorConfig, transcript subtitles.Transcript) ai.Scorer {
+	// Always have heuristic + aesthetic scoring
+	aesthetic := ai.NewAestheticScorer(p.logger, p.ffmpeg)
+	aesthetic.SetFrameSampling(detectorCfg.FrameSamples, detectorCfg.FrameAggregation)
+	aesthetic.SetFrameOptions(detectorCfg.FrameOptions)
+
+	scorers := []ai.Scorer{ai.NewHeuristicScorer(), aesthetic}
+
+	if clipScorer := p.buildCLIPScorer(detectorCfg); clipScorer != nil {
+		scorers = append(scorers, clipScorer)
+	}
+
+	if modelScorer := p.buildModelScorer(detectorCfg); modelScorer != nil {
+		scorers = append(scorers, modelScorer)
+	}
+
+	if faceScorer := p.buildFaceScorer(detectorCfg); faceScorer != nil {
+		scorers = append(scorers, faceScorer)
+	}
+
+	if audioScorer := p.buildAudioEventScorer(); audioScorer != nil {
+		scorers = append(scorers, audioScorer)
+	}
+
+	// Keyword scoring needs a transcript to read
+	if len(transcript) > 0 && len(p.keywords) > 0 {
+		keywordScorer := ai.NewKeywordScorer(transcript, p.keywords)
+		scorers = append(scorers, keywordScorer)
+	}
+
+	// Weights are keyed by scorer name
+	names := make([]string, len(scorers))
+	for i, s := range scorers {
+		names[i] = s.Name()
+	}
+
+	weights := normalizeWeights(p.logger, names, p.weights)
+	p.logger.Info().
+		Strs("scorers", names).
+		Floats64("weights", weights).
+		Msg("composite scorer configured")
+
+	composite := ai.NewCompositeScorer(scorers, weights)
+	composite.SetLogger(p.logger)
+	if p.failMode != "" {
+		composite.SetFailureMode(p.failMode)
+	}
+	return composite
+}
+
+// buildCLIPScorer loads the CLIP encoder + virality head, or returns nil
+// (with a warning) when the models are unavailable
+func (p *Pipeline) buildCLIPScorer(detectorCfg ai.DetectorConfig) *ai.CLIPScorer {
+	modelDir := p.config.ModelPath
+	if modelDir == "" {
+		p.logger.Info().Msg("no model path configured; skipping CLIP scoring")
+		return nil
+	}
+
+	encoderPath := filepath.Join(modelDir, "clip_image_encoder.onnx")
+	headPath := filepath.Join(modelDir, "virality_head.onnx")
+
+	// Sanity check: files exist
+	if _, err := os.Stat(encoderPath); err != nil {
+		p.logger.Warn().Err(err).
+			Str("encoder", encoderPath).
+			Msg("encoder model not found; skipping CLIP scoring")
+		return nil
+	}
+	if _, err := os.Stat(headPath); err != nil {
+		p.logger.Warn().Err(err).
+			Str("head", headPath).
+			Msg("virality head model not found; skipping CLIP scoring")
+		return nil
+	}
+
+	clipScorer, err := ai.NewCLIPScorerWithProvider(p.logger, p.ffmpeg, encoderPath, headPath, p.provider)
+	if err != nil {
+		p.logger.Warn().Err(err).
+			Str("encoder", encoderPath).
+			Str("head", headPath).
+			Msg("failed to initialize CLIP scorer; skipping CLIP scoring")
+		return nil
+	}
+
+	clipScorer.SetFrameSampling(detectorCfg.FrameSamples, detectorCfg.FrameAggregation)
+	clipScorer.SetFrameOptions(detectorCfg.FrameOptions)
+	if p.batchSize > 0 {
+		clipScorer.SetBatchSize(p.batchSize)
+	}
+
+	p.logger.Info().
+		Str("encoder_model", encoderPath).
+		Str("head_model", headPath).
+		Msg("CLIP scoring enabled")
+
+	return clipScorer
+}
+
+// buildModelScorer loads a single fine-tuned virality model dropped into the
+// model directory, or returns nil when there is none
+func (p *Pipeline) buildModelScorer(detectorCfg ai.DetectorConfig) *ai.ModelScorer {
+	if p.config.ModelPath == "" {
+		return nil
+	}
+
+	modelPath := filepath.Join(ai.ModelDir(p.config.ModelPath), ai.ViralityModelFile)
+	if _, err := os.Stat(modelPath); err != nil {
+		return nil
+	}
+
+	modelScorer, err := ai.NewModelScorer(p.logger, p.ffmpeg, modelPath, p.provider)
+	if err != nil {
+		p.logger.Warn().Err(err).
+			Str("model", modelPath).
+			Msg("failed to initialize virality model; skipping model scoring")
+		return nil
+	}
+	modelScorer.SetFrameSampling(detectorCfg.FrameSamples, detectorCfg.FrameAggregation)
+	modelScorer.SetFrameOptions(detectorCfg.FrameOptions)
+
+	p.logger.Info().Str("model", modelPath).Msg("model scoring enabled")
+	return modelScorer
+}
+
+// buildFaceScorer loads the face detector, or returns nil when there is no
+// model so its weight goes to the other scorers
+func (p *Pipeline) buildFaceScorer(detectorCfg ai.DetectorConfig) *ai.FaceScorer {
+	modelPath := p.faceModel
+	if modelPath == "" {
+		if p.config.ModelPath == "" {
+			return nil
+		}
+		modelPath = filepath.Join(ai.ModelDir(p.config.ModelPath), ai.FaceModelFile)
+	}
+	if _, err := os.Stat(modelPath); err != nil {
+		p.logger.Debug().Str("model", modelPath).Msg("face model not found; skipping face scoring")
+		return nil
+	}
+
+	faceScorer, err := ai.NewFaceScorer(p.logger, p.ffmpeg, modelPath, p.provider)
+	if err != nil {
+		p.logger.Warn().Err(err).
+			Str("model", modelPath).
+			Msg("failed to initialize face scorer; skipping face scoring")
+		return nil
+	}
+	faceScorer.SetFrameSampling(detectorCfg.FrameSamples, detectorCfg.FrameAggregation)
+	faceScorer.SetFrameOptions(detectorCfg.FrameOptions)
+
+	p.logger.Info().Str("model", modelPath).Msg("face scoring enabled")
+	return faceScorer
+}
+
+// buildAudioEventScorer loads the audio event classifier, or returns nil
+// when there is no model
+func (p *Pipeline) buildAudioEventScorer() *ai.AudioEventScorer {
+	modelPath := p.audioModel
+	if modelPath == "" {
+		if p.config.ModelPath == "" {
+			return nil
+		}
+		modelPath = filepath.Join(ai.ModelDir(p.config.ModelPath), ai.AudioEventModelFile)
+	}
+	if _, err := os.Stat(modelPath); err != nil {
+		p.logger.Debug().Str("model", modelPath).Msg("audio event model not found; skipping audio event scoring")
+		return nil
+	}
+
+	audioScorer, err := ai.NewAudioEventScorer(p.logger, p.ffmpeg, modelPath, p.provider)
+	if err != nil {
+		p.logger.Warn().Err(err).
+			Str("model", modelPath).
+			Msg("failed to initialize audio event scorer; skipping audio event scoring")
+		return nil
+	}
+
+	p.logger.Info().Str("model", modelPath).Msg("audio event scoring enabled")
+	return audioScorer
+}
+
+// normalizeWeights returns weights for the constructed scorers that sum to 1.
+// Weights of configured scorers that weren't constructed are redistributed
+// proportionally across the rest.
+func normalizeWeights(logger zerolog.Logger, names []string, configured map[string]float64) []float64 {
+	available := make(map[string]bool, len(names))
+	for _, name := range names {
+		available[name] = true
+	}
+	for name, weight := range configured {
+		if !available[name] && weight > 0 {
+			logger.Info().
+				Str("scorer", name).
+				Float64("weight", weight).
+				Msg("configured scorer unavailable; redistributing its weight")
+		}
+	}
+
+	weights := make([]float64, len(names))
+	var total float64
+	for i, name := range names {
+		weight, ok := configured[name]
+		if !ok {
+			weight = defaultScoringWeights[name]
+		}
+		weights[i] = weight
+		total += weight
+	}
+
+	// All-zero weights degrade to an equal split
+	if total <= 0 {
+		for i := range weights {
+			weights[i] = 1.0 / float64(len(weights))
+		}
+		return weights
+	}
+
+	for i := range weights {
+		weights[i] /= total
+	}
+	return weights
+}
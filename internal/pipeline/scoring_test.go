@@ -0,0 +1,54 @@
+package pipeline
+
+import (
+	"math"
+	"testing"
+
+	"github.com/keagan/slopcannon/internal/ai"
+	"github.com/rs/zerolog"
+)
+
+func TestNormalizeWeights(t *testing.T) {
+	configured := map[string]float64{
+		ScorerHeuristic: 0.3,
+		ScorerAesthetic: 0.2,
+		ScorerCLIP:      0.5,
+	}
+
+	// All scorers available: weights already sum to 1
+	got := normalizeWeights(zerolog.Nop(), []string{ScorerHeuristic, ScorerAesthetic, ScorerCLIP}, configured)
+	assertWeights(t, got, []float64{0.3, 0.2, 0.5})
+
+	// CLIP unavailable: its weight is redistributed proportionally
+	got = normalizeWeights(zerolog.Nop(), []string{ScorerHeuristic, ScorerAesthetic}, configured)
+	assertWeights(t, got, []float64{0.6, 0.4})
+
+	// Unconfigured scorers fall back to defaults
+	got = normalizeWeights(zerolog.Nop(), []string{ScorerHeuristic, ScorerAesthetic}, map[string]float64{ScorerHeuristic: 0.2})
+	assertWeights(t, got, []float64{0.5, 0.5})
+}
+
+func assertWeights(t *testing.T, got, want []float64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-9 {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestScorerNamesMatchWeightKeys(t *testing.T) {
+	scorers := map[string]ai.Scorer{
+		ScorerHeuristic: ai.NewHeuristicScorer(),
+		ScorerAesthetic: ai.NewAestheticScorer(zerolog.Nop(), nil),
+		ScorerKeyword:   ai.NewKeywordScorer(nil, nil),
+	}
+	for key, scorer := range scorers {
+		if scorer.Name() != key {
+			t.Errorf("scorer %T is named %q, but weighted as %q", scorer, scorer.Name(), key)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/keagan/slopcannon/internal/clips"
+	"github.com/keagan/slopcannon/internal/ffmpeg"
+	"github.com/rs/zerolog"
+)
+
+// SocialPreset is an upload target's export settings. Vertical presets
+// reframe landscape sources (blur-pad unless a reframe mode is set).
+type SocialPreset struct {
+	Name   string
+	Width  int
+	Height int
+	// MaxFPS caps the frame rate; slower sources keep theirs
+	MaxFPS float64
+	// MaxDuration is the longest video the platform accepts
+	MaxDuration time.Duration
+	// MaxBitrate is the video bitrate ceiling, e.g. "8M"
+	MaxBitrate string
+	// Loudness is the integrated loudness target in LUFS
+	Loudness float64
+}
+
+// socialPresets are the built-in upload targets; a new platform only needs
+// an entry here
+var socialPresets = []SocialPreset{
+	{Name: "tiktok", Width: 1080, Height: 1920, MaxFPS: 60, MaxDuration: 10 * time.Minute, MaxBitrate: "10M", Loudness: -14},
+	{Name: "reels", Width: 1080, Height: 1920, MaxFPS: 30, MaxDuration: 3 * time.Minute, MaxBitrate: "8M", Loudness: -14},
+	{Name: "shorts", Width: 1080, Height: 1920, MaxFPS: 60, MaxDuration: 3 * time.Minute, MaxBitrate: "12M", Loudness: -14},
+	{Name: "twitter", Width: 1280, Height: 720, MaxFPS: 60, MaxDuration: 140 * time.Second, MaxBitrate: "5M", Loudness: -16},
+}
+
+// LookupSocialPreset returns the named preset (case-insensitive)
+func LookupSocialPreset(name string) (SocialPreset, error) {
+	for _, preset := range socialPresets {
+		if strings.EqualFold(preset.Name, name) {
+			return preset, nil
+		}
+	}
+	return SocialPreset{}, fmt.Errorf("unknown social preset %q (use %s)", name, strings.Join(SocialPresetNames(), ", "))
+}
+
+// SocialPresetNames lists the built-in presets, sorted
+func SocialPresetNames() []string {
+	names := make([]string, len(socialPresets))
+	for i, preset := range socialPresets {
+		names[i] = preset.Name
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Vertical reports whether the preset is portrait
+func (s SocialPreset) Vertical() bool {
+	return s.Height > s.Width
+}
+
+// applySocialPreset fills render settings from opts.SocialPreset; settings
+// the user already chose are kept
+func applySocialPreset(opts RenderOptions) (RenderOptions, error) {
+	if opts.SocialPreset == "" {
+		return opts, nil
+	}
+	preset, err := LookupSocialPreset(opts.SocialPreset)
+	if err != nil {
+		return opts, err
+	}
+
+	if opts.Width == 0 && opts.Height == 0 {
+		// Sources rarely match the preset's aspect ratio; never stretch them
+		opts.Width, opts.Height = preset.Width, preset.Height
+		opts.Fit = true
+	}
+	if preset.Vertical() && opts.Reframe == ffmpeg.ReframeNone {
+		opts.Reframe = ffmpeg.ReframeBlurPad
+	}
+	if opts.MaxFPS == 0 {
+		opts.MaxFPS = preset.MaxFPS
+	}
+	if opts.MaxDuration == 0 {
+		opts.MaxDuration = preset.MaxDuration
+	}
+	if opts.MaxBitrate == "" {
+		opts.MaxBitrate = preset.MaxBitrate
+	}
+	if opts.Loudness == 0 {
+		opts.Loudness = preset.Loudness
+	}
+	return opts, nil
+}
+
+// cappedFPS returns the output frame rate for a source running at source
+// fps (0 = unknown): requested if set, never above limit (0 = no limit)
+func cappedFPS(requested, source, limit float64) float64 {
+	if limit <= 0 {
+		return requested
+	}
+	if requested > 0 {
+		return math.Min(requested, limit)
+	}
+	if source > 0 && source <= limit {
+		// Already within the cap; leave the frame rate untouched
+		return 0
+	}
+	return limit
+}
+
+// outputFPS applies opts.MaxFPS to the frame rate of the project's source
+func (p *Pipeline) outputFPS(ctx context.Context, project *Project, opts RenderOptions) float64 {
+	if opts.MaxFPS <= 0 || opts.FPS > 0 {
+		return cappedFPS(opts.FPS, 0, opts.MaxFPS)
+	}
+
+	source := project.InputPath
+	if source == "" && len(project.Clips) > 0 {
+		source = project.Clips[0].SourceURL
+	}
+	var sourceFPS float64
+	if info, err := p.ffmpeg.ProbeVideoCached(ctx, source); err == nil {
+		sourceFPS = info.FPS
+	} else {
+		p.logger.Debug().Err(err).Msg("source frame rate unknown; using the preset's cap")
+	}
+	return cappedFPS(0, sourceFPS, opts.MaxFPS)
+}
+
+// fitProject applies opts.MaxDuration to the joined output or, with
+// perClip, to every clip. It returns project itself when nothing changes.
+func (p *Pipeline) fitProject(project *Project, opts RenderOptions, perClip bool) (*Project, error) {
+	if opts.MaxDuration <= 0 {
+		return project, nil
+	}
+
+	var fitted []*clips.Clip
+	if perClip {
+		for _, clip := range project.Clips {
+			kept, err := fitDuration(p.logger.With().Str("clip", clip.ID).Logger(), []*clips.Clip{clip}, opts.MaxDuration, opts.EnforceDuration)
+			if err != nil {
+				return nil, err
+			}
+			fitted = append(fitted, kept...)
+		}
+	} else {
+		var err error
+		fitted, err = fitDuration(p.logger, project.Clips, opts.MaxDuration, opts.EnforceDuration)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !opts.EnforceDuration {
+		return project, nil
+	}
+	copied := *project
+	copied.Clips = fitted
+	return &copied, nil
+}
+
+// fitDuration keeps list within limit total length (0 = no limit).
+// Without enforce it only warns; with it, the clip crossing the limit is
+// trimmed and later ones are dropped. list itself is never modified.
+func fitDuration(logger zerolog.Logger, list []*clips.Clip, limit time.Duration, enforce bool) ([]*clips.Clip, error) {
+	if limit <= 0 {
+		return list, nil
+	}
+
+	var total time.Duration
+	for _, clip := range list {
+		total += clip.End - clip.Start
+	}
+	if total <= limit {
+		return list, nil
+	}
+
+	if !enforce {
+		logger.Warn().
+			Dur("duration", total).
+			Dur("max_duration", limit).
+			Msg("output is longer than the platform allows; use --enforce to trim it")
+		return list, nil
+	}
+
+	editor := clips.NewEditor()
+	fitted := make([]*clips.Clip, 0, len(list))
+	remaining := limit
+	for _, clip := range list {
+		length := clip.End - clip.Start
+		if length <= remaining {
+			fitted = append(fitted, clip)
+			remaining -= length
+			continue
+		}
+		if remaining > 0 {
+			trimmed, err := editor.Trim(clip, clip.Start, clip.Start+remaining)
+			if err != nil {
+				return nil, err
+			}
+			fitted = append(fitted, trimmed)
+		}
+		break
+	}
+
+	logger.Warn().
+		Dur("duration", total).
+		Dur("max_duration", limit).
+		Int("clips_kept", len(fitted)).
+		Msg("trimmed output to the platform's max duration")
+	return fitted, nil
+}
@@ -0,0 +1,85 @@
+package pipeline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keagan/slopcannon/internal/clips"
+	"github.com/keagan/slopcannon/internal/ffmpeg"
+	"github.com/rs/zerolog"
+)
+
+func TestApplySocialPreset(t *testing.T) {
+	opts, err := applySocialPreset(RenderOptions{SocialPreset: "TikTok", MaxFPS: 24})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.Width != 1080 || opts.Height != 1920 || opts.Reframe != ffmpeg.ReframeBlurPad {
+		t.Errorf("expected a vertical blur-pad 1080x1920 render, got %dx%d %q", opts.Width, opts.Height, opts.Reframe)
+	}
+	if opts.MaxFPS != 24 {
+		t.Errorf("expected the user's fps cap to win, got %v", opts.MaxFPS)
+	}
+	if opts.MaxBitrate != "10M" || opts.Loudness != -14 || opts.MaxDuration != 10*time.Minute {
+		t.Errorf("unexpected preset settings %+v", opts)
+	}
+
+	twitter, _ := applySocialPreset(RenderOptions{SocialPreset: "twitter"})
+	if twitter.Reframe != ffmpeg.ReframeNone {
+		t.Errorf("expected a landscape preset not to reframe, got %q", twitter.Reframe)
+	}
+	if ro := finalRenderOptions(twitter, "in.mp4", "out.mp4", ""); !ro.Fit || ro.Width != 1280 || ro.Height != 720 {
+		t.Errorf("expected the preset size to letterbox rather than stretch, got %+v", ro)
+	}
+
+	if _, err := applySocialPreset(RenderOptions{SocialPreset: "myspace"}); err == nil {
+		t.Error("expected an error for an unknown preset")
+	}
+}
+
+func TestCappedFPS(t *testing.T) {
+	tests := []struct {
+		name                     string
+		requested, source, limit float64
+		want                     float64
+	}{
+		{"no cap", 0, 60, 0, 0},
+		{"source under cap", 0, 24, 30, 0},
+		{"source over cap", 0, 60, 30, 30},
+		{"unknown source", 0, 0, 30, 30},
+		{"requested over cap", 50, 60, 30, 30},
+		{"requested under cap", 25, 60, 30, 25},
+	}
+	for _, tt := range tests {
+		if got := cappedFPS(tt.requested, tt.source, tt.limit); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestFitDuration(t *testing.T) {
+	list := []*clips.Clip{
+		{ID: "a", Start: 0, End: 40 * time.Second},
+		{ID: "b", Start: time.Minute, End: 2 * time.Minute},
+		{ID: "c", Start: 3 * time.Minute, End: 4 * time.Minute},
+	}
+
+	warned, err := fitDuration(zerolog.Nop(), list, time.Minute, false)
+	if err != nil || len(warned) != 3 {
+		t.Fatalf("expected clips untouched without enforce, got %v (%v)", warned, err)
+	}
+
+	fitted, err := fitDuration(zerolog.Nop(), list, time.Minute, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fitted) != 2 || fitted[0] != list[0] {
+		t.Fatalf("expected the first clip and a trimmed second, got %v", fitted)
+	}
+	if got := fitted[1].End - fitted[1].Start; got != 20*time.Second {
+		t.Errorf("expected the second clip trimmed to 20s, got %v", got)
+	}
+	if list[1].End != 2*time.Minute {
+		t.Error("expected the original clip to be left alone")
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"time"
 
 	"github.com/keagan/slopcannon/internal/clips"
+	"github.com/keagan/slopcannon/internal/ffmpeg"
+	"github.com/keagan/slopcannon/internal/overlays"
+	"github.com/keagan/slopcannon/internal/subtitles"
 )
 
 // Project represents a slopCannon project
@@ -47,19 +50,81 @@ type AnalyzeOptions struct {
 	Model      string
 	Overlay    string
 	MinClipLen time.Duration
+	// Longer candidates are split; must exceed MinClipLen (0 = default)
+	MaxClipLen time.Duration
 	MaxClips   int
 	UseAI      bool
+	// Longest a single clip may take to score before it gets 0 (0 = no limit)
+	ScoreTimeout time.Duration
+	// Optional transcript; enables keyword scoring
+	Transcript subtitles.Transcript
+	// DumpFeatures writes every candidate's features and score to this
+	// .csv or .jsonl file (single-input analysis only)
+	DumpFeatures string
+	// Dedupe records a perceptual hash per clip; AnalyzeBatch then drops
+	// clips within DedupeDistance bits of one already kept (0 = identical
+	// hashes only, negative = DefaultDedupeDistance)
+	Dedupe         bool
+	DedupeDistance int
 }
 
 // RenderOptions configures render behavior
 type RenderOptions struct {
 	OutputPath string
+	// Format names an output container (mp4|webm|mov|mkv); its codecs are
+	// used unless VideoCodec or AudioCodec override them
 	Format     string
+	VideoCodec string
+	AudioCodec string
 	Quality    int // CRF value
 	Preset     string
 	Width      int
 	Height     int
 	FPS        float64
+	// Fit letterboxes into Width x Height rather than stretching
+	Fit bool
+
+	// Vertical reframing mode and split-screen gameplay overlay
+	// (a registered overlay name or a file path); BlurSigma sets the
+	// background blur for blur-pad (0 = default)
+	Reframe     ffmpeg.ReframeMode
+	OverlayPath string
+	BlurSigma   float64
+
+	// Effect between joined clips (none|fade|crossfade); forces re-encoding
+	Transition         ffmpeg.Transition
+	TransitionDuration time.Duration
+
+	// Timed text hooks; unset styling comes from the subtitles config
+	Captions []ffmpeg.Caption
+
+	// Target bitrate (e.g. "4M") instead of Quality; TwoPass for accuracy
+	TargetBitrate string
+	TwoPass       bool
+
+	// SocialPreset names an upload target (tiktok|reels|shorts|twitter)
+	// that fills in the settings below and the output size
+	SocialPreset string
+	// MaxFPS caps the source frame rate; MaxBitrate caps the video bitrate
+	MaxFPS     float64
+	MaxBitrate string
+	// Loudness normalizes audio to this many LUFS (0 = untouched)
+	Loudness float64
+	// MaxDuration is the longest output allowed; longer ones are only
+	// warned about unless EnforceDuration trims them
+	MaxDuration     time.Duration
+	EnforceDuration bool
+
+	// Per-clip rendering (RenderClips): destination directory and how each
+	// clip's gameplay overlay is chosen (fixed uses OverlayPath).
+	// NameTemplate names each file, e.g. "{source}_{index}_score{score}.mp4"
+	OutputDir       string
+	OverlayStrategy overlays.Strategy
+	NameTemplate    string
+
+	// Preview renders fast low-res proxies into <work_dir>/preview instead:
+	// ultrafast preset, 480p, copied audio, no two-pass or hardware encoding
+	Preview bool
 }
 
 // Config holds pipeline-specific configuration
@@ -69,6 +134,10 @@ type Config struct {
 	EnableCache bool
 	// New: where ONNX models live (directory with clip_image_encoder.onnx, virality_head.onnx)
 	ModelPath string
+	// Progress receives ffmpeg progress for every stage (optional)
+	Progress ffmpeg.ProgressFunc
+	// OnEvent receives stage milestones, e.g. to drive a GUI (optional)
+	OnEvent func(Event)
 
 	// Other per-pipeline knobs you might have
 	MinClipLength time.Duration
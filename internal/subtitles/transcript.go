@@ -0,0 +1,62 @@
+package subtitles
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Segment is a timed span of transcribed speech
+type Segment struct {
+	Start time.Duration
+	End   time.Duration
+	Text  string
+}
+
+// Transcript is a time-ordered list of segments
+type Transcript []Segment
+
+// Overlapping returns the segments that intersect [start, end)
+func (t Transcript) Overlapping(start, end time.Duration) []Segment {
+	var out []Segment
+	for _, seg := range t {
+		if seg.Start < end && seg.End > start {
+			out = append(out, seg)
+		}
+	}
+	return out
+}
+
+// whisperOutput mirrors the JSON written by `whisper --output_format json`
+type whisperOutput struct {
+	Segments []struct {
+		Start float64 `json:"start"`
+		End   float64 `json:"end"`
+		Text  string  `json:"text"`
+	} `json:"segments"`
+}
+
+// LoadTranscript reads a Whisper-style JSON transcript (times in seconds)
+func LoadTranscript(path string) (Transcript, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transcript: %w", err)
+	}
+
+	var out whisperOutput
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("failed to parse transcript: %w", err)
+	}
+
+	transcript := make(Transcript, 0, len(out.Segments))
+	for _, seg := range out.Segments {
+		transcript = append(transcript, Segment{
+			Start: time.Duration(seg.Start * float64(time.Second)),
+			End:   time.Duration(seg.End * float64(time.Second)),
+			Text:  seg.Text,
+		})
+	}
+
+	return transcript, nil
+}
@@ -0,0 +1,31 @@
+package subtitles
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadTranscript(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "talk.json")
+	data := `{"text":"hi there","segments":[{"id":0,"start":0.0,"end":1.5,"text":" hi"},{"id":1,"start":1.5,"end":3.25,"text":" there"}]}`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	transcript, err := LoadTranscript(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(transcript) != 2 {
+		t.Fatalf("expected 2 segments, got %d", len(transcript))
+	}
+	if transcript[1].End != 3250*time.Millisecond {
+		t.Errorf("expected end 3.25s, got %v", transcript[1].End)
+	}
+
+	if got := transcript.Overlapping(time.Second, 1500*time.Millisecond); len(got) != 1 || got[0].Text != " hi" {
+		t.Errorf("unexpected overlap result %v", got)
+	}
+}
@@ -0,0 +1,43 @@
+package subtitles
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/keagan/slopcannon/pkg/util"
+)
+
+// WriteSRT writes the transcript as SubRip (.srt) cues
+func (t Transcript) WriteSRT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	for i, seg := range t {
+		fmt.Fprintf(bw, "%d\n%s --> %s\n%s\n\n",
+			i+1, util.FormatSRTTimestamp(seg.Start), util.FormatSRTTimestamp(seg.End), cueText(seg.Text))
+	}
+	return bw.Flush()
+}
+
+// WriteVTT writes the transcript as WebVTT (.vtt) cues
+func (t Transcript) WriteVTT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("WEBVTT\n\n")
+	for _, seg := range t {
+		fmt.Fprintf(bw, "%s --> %s\n%s\n\n",
+			util.FormatVTTTimestamp(seg.Start), util.FormatVTTTimestamp(seg.End), cueText(seg.Text))
+	}
+	return bw.Flush()
+}
+
+// cueText trims Whisper's leading spaces and drops blank lines, which
+// would otherwise end the cue early
+func cueText(text string) string {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,39 @@
+package subtitles
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+var writerSample = Transcript{
+	{Start: 0, End: 1500 * time.Millisecond, Text: " hi"},
+	{Start: time.Hour + 2*time.Minute + 3456*time.Millisecond, End: time.Hour + 2*time.Minute + 5*time.Second, Text: " wait\n\nfor it"},
+}
+
+func TestWriteSRT(t *testing.T) {
+	var b strings.Builder
+	if err := writerSample.WriteSRT(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "1\n00:00:00,000 --> 00:00:01,500\nhi\n\n" +
+		"2\n01:02:03,456 --> 01:02:05,000\nwait\nfor it\n\n"
+	if b.String() != want {
+		t.Errorf("WriteSRT =\n%q\nwant\n%q", b.String(), want)
+	}
+}
+
+func TestWriteVTT(t *testing.T) {
+	var b strings.Builder
+	if err := writerSample.WriteVTT(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "WEBVTT\n\n" +
+		"00:00.000 --> 00:01.500\nhi\n\n" +
+		"01:02:03.456 --> 01:02:05.000\nwait\nfor it\n\n"
+	if b.String() != want {
+		t.Errorf("WriteVTT =\n%q\nwant\n%q", b.String(), want)
+	}
+}
@@ -0,0 +1,123 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/keagan/slopcannon/internal/ffmpeg"
+)
+
+const (
+	barWidth      = 30
+	redrawEvery   = 100 * time.Millisecond
+	spinnerFrames = `|/-\`
+)
+
+// ProgressBar renders ffmpeg progress on a terminal line: a bar with
+// percentage, speed and ETA when the total is known, otherwise a spinner
+// with the current position
+type ProgressBar struct {
+	mu       sync.Mutex
+	out      io.Writer
+	label    string
+	started  time.Time
+	lastDraw time.Time
+	spin     int
+	width    int // length of the last drawn line, to clear leftovers
+}
+
+// NewProgressBar creates a bar that writes to out
+func NewProgressBar(out io.Writer, label string) *ProgressBar {
+	return &ProgressBar{
+		out:     out,
+		label:   label,
+		started: time.Now(),
+	}
+}
+
+// Update redraws the bar; it matches ffmpeg.ProgressFunc and is safe for
+// concurrent use
+func (b *ProgressBar) Update(p *ffmpeg.Progress) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(b.lastDraw) < redrawEvery {
+		return
+	}
+	b.lastDraw = now
+	b.draw(formatProgress(b.label, p, now.Sub(b.started), b.spin))
+	b.spin++
+}
+
+// Finish clears the bar line
+func (b *ProgressBar) Finish() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.width > 0 {
+		fmt.Fprintf(b.out, "\r%s\r", strings.Repeat(" ", b.width))
+		b.width = 0
+	}
+}
+
+func (b *ProgressBar) draw(line string) {
+	pad := ""
+	if len(line) < b.width {
+		pad = strings.Repeat(" ", b.width-len(line))
+	}
+	fmt.Fprintf(b.out, "\r%s%s", line, pad)
+	b.width = len(line)
+}
+
+// formatProgress renders one progress line
+func formatProgress(label string, p *ffmpeg.Progress, elapsed time.Duration, spin int) string {
+	speed := ""
+	if p.Speed != "" && p.Speed != "N/A" {
+		speed = "  " + p.Speed
+	}
+
+	if p.Percentage <= 0 {
+		frame := spinnerFrames[spin%len(spinnerFrames)]
+		return fmt.Sprintf("%s %c %s%s", label, frame, strings.TrimSuffix(p.Time, "000"), speed)
+	}
+
+	filled := int(p.Percentage / 100 * barWidth)
+	if filled > barWidth {
+		filled = barWidth
+	}
+	bar := strings.Repeat("=", filled) + strings.Repeat(" ", barWidth-filled)
+
+	eta := "--:--"
+	if p.Percentage < 100 {
+		remaining := time.Duration(float64(elapsed) * (100 - p.Percentage) / p.Percentage)
+		eta = formatETA(remaining)
+	}
+
+	return fmt.Sprintf("%s [%s] %5.1f%%%s  ETA %s", label, bar, p.Percentage, speed, eta)
+}
+
+// formatETA renders a duration as m:ss (or h:mm:ss)
+func formatETA(d time.Duration) string {
+	d = d.Round(time.Second)
+	h := int(d / time.Hour)
+	m := int(d%time.Hour) / int(time.Minute)
+	s := int(d%time.Minute) / int(time.Second)
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%d:%02d", m, s)
+}
+
+// IsTerminal reports whether f is an interactive terminal
+func IsTerminal(f *os.File) bool {
+	stat, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return stat.Mode()&os.ModeCharDevice != 0
+}
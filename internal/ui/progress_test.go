@@ -0,0 +1,33 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keagan/slopcannon/internal/ffmpeg"
+)
+
+func TestFormatProgressBar(t *testing.T) {
+	line := formatProgress("render", &ffmpeg.Progress{Percentage: 25, Speed: "2.5x"}, 30*time.Second, 0)
+
+	for _, want := range []string{"render [=======", "25.0%", "2.5x", "ETA 1:30"} {
+		if !strings.Contains(line, want) {
+			t.Errorf("line %q should contain %q", line, want)
+		}
+	}
+}
+
+func TestFormatProgressSpinner(t *testing.T) {
+	line := formatProgress("analyze", &ffmpeg.Progress{Time: "00:01:02.500000", Speed: "N/A"}, time.Second, 1)
+
+	if line != "analyze / 00:01:02.500" {
+		t.Errorf("unexpected spinner line %q", line)
+	}
+}
+
+func TestFormatETA(t *testing.T) {
+	if got := formatETA(3723 * time.Second); got != "1:02:03" {
+		t.Errorf("expected 1:02:03, got %s", got)
+	}
+}
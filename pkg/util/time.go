@@ -2,6 +2,7 @@ package util
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"time"
@@ -16,9 +17,37 @@ func FormatDuration(d time.Duration) string {
 	return fmt.Sprintf("%02d:%02d:%06.3f", hours, minutes, secs)
 }
 
-// ParseTimestamp parses a timestamp string (HH:MM:SS.mmm or SS.mmm or MM:SS)
+// FormatSRTTimestamp formats d as an SRT cue time (HH:MM:SS,mmm)
+func FormatSRTTimestamp(d time.Duration) string {
+	h, m, sec, ms := splitMillis(d)
+	return fmt.Sprintf("%02d:%02d:%02d,%03d", h, m, sec, ms)
+}
+
+// FormatVTTTimestamp formats d as a WebVTT cue time: MM:SS.mmm, with an
+// HH: prefix only when there are hours
+func FormatVTTTimestamp(d time.Duration) string {
+	h, m, sec, ms := splitMillis(d)
+	if h > 0 {
+		return fmt.Sprintf("%02d:%02d:%02d.%03d", h, m, sec, ms)
+	}
+	return fmt.Sprintf("%02d:%02d.%03d", m, sec, ms)
+}
+
+// splitMillis breaks d (rounded to the millisecond, clamped at 0) into
+// hours, minutes, seconds and milliseconds
+func splitMillis(d time.Duration) (h, m, s, ms int64) {
+	if d < 0 {
+		d = 0
+	}
+	total := d.Round(time.Millisecond).Milliseconds()
+	return total / 3600000, total / 60000 % 60, total / 1000 % 60, total % 1000
+}
+
+// ParseTimestamp parses a timestamp string (HH:MM:SS.mmm or SS.mmm or MM:SS).
+// A comma decimal separator, as in SRT (00:00:01,500), is also accepted.
 func ParseTimestamp(s string) (time.Duration, error) {
 	s = strings.TrimSpace(s)
+	s = strings.Replace(s, ",", ".", 1)
 
 	// Handle different formats
 	parts := strings.Split(s, ":")
@@ -68,12 +97,42 @@ func ParseTimestamp(s string) (time.Duration, error) {
 	return time.Duration(totalSeconds * float64(time.Second)), nil
 }
 
+// ParseTimecode parses HH:MM:SS:FF frame timecode (as in EDL cut lists),
+// reading the trailing FF as frames at fps. Anything else is parsed by
+// ParseTimestamp, so plain timestamps work too.
+func ParseTimecode(s string, fps float64) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+
+	parts := strings.Split(s, ":")
+	if len(parts) != 4 {
+		return ParseTimestamp(s)
+	}
+	if fps <= 0 {
+		return 0, fmt.Errorf("timecode %s needs a positive frame rate (got %g)", s, fps)
+	}
+
+	base, err := ParseTimestamp(strings.Join(parts[:3], ":"))
+	if err != nil {
+		return 0, fmt.Errorf("invalid timecode format: %s", s)
+	}
+	frames, err := strconv.Atoi(parts[3])
+	if err != nil || frames < 0 {
+		return 0, fmt.Errorf("invalid timecode format: %s", s)
+	}
+	if float64(frames) >= math.Ceil(fps) {
+		return 0, fmt.Errorf("timecode %s has frame %d, beyond %g fps", s, frames, fps)
+	}
+
+	return base + time.Duration(math.Round(float64(frames)/fps*float64(time.Second))), nil
+}
+
 // FormatTimestamp formats a duration as a simple timestamp string
 func FormatTimestamp(d time.Duration) string {
 	return FormatDuration(d)
 }
 
-// ParseFrameRate parses frame rate from ffprobe format (e.g., "30/1")
+// ParseFrameRate parses frame rate from ffprobe format (e.g., "30/1").
+// Unknown rates ("0/0", "") return 0.
 func ParseFrameRate(s string) float64 {
 	parts := strings.Split(s, "/")
 	if len(parts) != 2 {
@@ -0,0 +1,101 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"45.5", 45500 * time.Millisecond},
+		{"01:30", 90 * time.Second},
+		{"00:01:23.250", 83250 * time.Millisecond},
+		// SRT-style comma decimals
+		{"00:00:01,500", 1500 * time.Millisecond},
+		{"01:02:03,456", time.Hour + 2*time.Minute + 3456*time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTimestamp(tt.in)
+		if err != nil {
+			t.Errorf("ParseTimestamp(%q): %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseTimestamp(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	if _, err := ParseTimestamp("1:2:3:4"); err == nil {
+		t.Error("expected error for a four-part timestamp")
+	}
+}
+
+func TestParseTimecode(t *testing.T) {
+	tests := []struct {
+		in   string
+		fps  float64
+		want time.Duration
+	}{
+		{"00:01:02:15", 30, 62500 * time.Millisecond},
+		{"00:00:10:12", 24, 10500 * time.Millisecond},
+		{"00:00:01:00", 29.97, time.Second},
+		// Without a frame field it's an ordinary timestamp
+		{"00:00:01,500", 30, 1500 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTimecode(tt.in, tt.fps)
+		if err != nil {
+			t.Errorf("ParseTimecode(%q, %g): %v", tt.in, tt.fps, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseTimecode(%q, %g) = %v, want %v", tt.in, tt.fps, got, tt.want)
+		}
+	}
+}
+
+func TestParseTimecodeInvalid(t *testing.T) {
+	tests := []struct {
+		in  string
+		fps float64
+	}{
+		{"00:01:02:15", 0},
+		{"00:01:02:30", 30},
+		{"00:01:02:xx", 30},
+		{"00:01:02:-1", 30},
+	}
+
+	for _, tt := range tests {
+		if _, err := ParseTimecode(tt.in, tt.fps); err == nil {
+			t.Errorf("ParseTimecode(%q, %g): expected error", tt.in, tt.fps)
+		}
+	}
+}
+
+func TestFormatSubtitleTimestamps(t *testing.T) {
+	tests := []struct {
+		d   time.Duration
+		srt string
+		vtt string
+	}{
+		{0, "00:00:00,000", "00:00.000"},
+		{time.Hour + 2*time.Minute + 3456*time.Millisecond, "01:02:03,456", "01:02:03.456"},
+		{83*time.Second + 250*time.Millisecond, "00:01:23,250", "01:23.250"},
+		// Sub-millisecond precision rounds rather than truncates
+		{1999999 * time.Microsecond, "00:00:02,000", "00:02.000"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatSRTTimestamp(tt.d); got != tt.srt {
+			t.Errorf("FormatSRTTimestamp(%v) = %q, want %q", tt.d, got, tt.srt)
+		}
+		if got := FormatVTTTimestamp(tt.d); got != tt.vtt {
+			t.Errorf("FormatVTTTimestamp(%v) = %q, want %q", tt.d, got, tt.vtt)
+		}
+	}
+}